@@ -0,0 +1,83 @@
+package basicdatastructures
+
+// AggregatingQueue demonstrates a FIFO queue built from two stacks, which can also report
+// the aggregate of all the values it holds under any associative operator, such as sum,
+// min, max, gcd, or matrix product, in O(1) amortized time.
+//
+// Values are pushed onto the back stack, which keeps a running aggregate of everything
+// pushed onto it. Values are popped from the front stack; when it runs empty, the back stack
+// is popped over onto the front stack in reverse, which puts the oldest value on top.
+// Each entry on the front stack stores the aggregate of itself and all the entries below it,
+// which are all the values enqueued after it that have been moved to the front stack.
+//
+// The aggregate of the whole queue is then the aggregate at the top of the front stack
+// combined with the running aggregate of the back stack. The operator does not need to be
+// commutative, as values are always combined in the order they were enqueued, but it must
+// be associative, as they are not combined in a fixed grouping.
+//
+// Each value is moved from the back stack to the front stack at most once,
+// so DeQueue takes O(1) amortized time.
+type AggregatingQueue[T any] struct {
+	front         []aggregated[T]
+	back          []T
+	backAggregate T
+	op            func(a, b T) T
+}
+
+type aggregated[T any] struct {
+	value     T
+	aggregate T
+}
+
+func NewAggregatingQueue[T any](op func(a, b T) T) *AggregatingQueue[T] {
+	return &AggregatingQueue[T]{op: op}
+}
+
+func (aq *AggregatingQueue[T]) EnQueue(v T) error {
+	if len(aq.back) == 0 {
+		aq.backAggregate = v
+	} else {
+		aq.backAggregate = aq.op(aq.backAggregate, v)
+	}
+	aq.back = append(aq.back, v)
+	return nil
+}
+
+func (aq *AggregatingQueue[T]) DeQueue() (T, error) {
+	if aq.Len() == 0 {
+		var zero T
+		return zero, QueueEmptyError{}
+	}
+	if len(aq.front) == 0 {
+		for i := len(aq.back) - 1; i >= 0; i-- {
+			v := aq.back[i]
+			agg := v
+			if len(aq.front) > 0 {
+				agg = aq.op(v, aq.front[len(aq.front)-1].aggregate)
+			}
+			aq.front = append(aq.front, aggregated[T]{value: v, aggregate: agg})
+		}
+		aq.back = aq.back[:0]
+	}
+	top := aq.front[len(aq.front)-1]
+	aq.front = aq.front[:len(aq.front)-1]
+	return top.value, nil
+}
+
+// Aggregate combines all the values in the queue, in the order they were enqueued
+func (aq *AggregatingQueue[T]) Aggregate() (T, error) {
+	switch {
+	case aq.Len() == 0:
+		var zero T
+		return zero, QueueEmptyError{}
+	case len(aq.front) == 0:
+		return aq.backAggregate, nil
+	case len(aq.back) == 0:
+		return aq.front[len(aq.front)-1].aggregate, nil
+	}
+	return aq.op(aq.front[len(aq.front)-1].aggregate, aq.backAggregate), nil
+}
+
+func (aq *AggregatingQueue[T]) Len() int {
+	return len(aq.front) + len(aq.back)
+}
@@ -0,0 +1,85 @@
+package basicdatastructures
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// matrix is a 2x2 matrix; matrix multiplication is associative but not commutative,
+// so it catches any aggregation which combines values out of order
+type matrix [2][2]int
+
+func multiply(a, b matrix) matrix {
+	var product matrix
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 2; k++ {
+				product[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return product
+}
+
+func TestAggregatingQueue(t *testing.T) {
+
+	t.Run("empty queue", func(t *testing.T) {
+		queue := NewAggregatingQueue(func(a, b int) int { return a + b })
+		_, err := queue.Aggregate()
+		assert.ErrorIs(t, err, QueueEmptyError{})
+		_, err = queue.DeQueue()
+		assert.ErrorIs(t, err, QueueEmptyError{})
+	})
+
+	t.Run("gcd", func(t *testing.T) {
+		queue := NewAggregatingQueue(gcd)
+		for _, v := range []int{7, 12, 18, 30} {
+			assert.Nil(t, queue.EnQueue(v))
+		}
+		for _, expected := range []int{1, 6, 6, 30} {
+			agg, err := queue.Aggregate()
+			assert.Nil(t, err)
+			assert.Equal(t, expected, agg)
+			_, err = queue.DeQueue()
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("random operations match a brute force matrix product", func(t *testing.T) {
+		r := rand.New(rand.NewSource(30))
+		queue := NewAggregatingQueue(multiply)
+		var model []matrix
+		for i := 0; i < 2000; i++ {
+			if r.Intn(3) > 0 {
+				m := matrix{{r.Intn(3), r.Intn(3) - 1}, {r.Intn(3) - 1, r.Intn(3)}}
+				assert.Nil(t, queue.EnQueue(m))
+				model = append(model, m)
+			} else if len(model) > 0 {
+				m, err := queue.DeQueue()
+				assert.Nil(t, err)
+				assert.Equal(t, model[0], m)
+				model = model[1:]
+			}
+
+			assert.Equal(t, len(model), queue.Len())
+			if len(model) > 0 {
+				expected := model[0]
+				for _, m := range model[1:] {
+					expected = multiply(expected, m)
+				}
+				actual, err := queue.Aggregate()
+				assert.Nil(t, err)
+				assert.Equal(t, expected, actual)
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package basicdatastructures
+
+import "cmp"
+
+// MonotonicQueue demonstrates a FIFO queue which can also report the maximum (or minimum)
+// of the values it holds in O(1) amortized time.
+//
+// Alongside the queue of values, it keeps a deque of "candidates": values which could still
+// become the maximum at some point in the future. A value stops being a candidate as soon
+// as a larger value is enqueued after it, since the larger value will be in the queue for at
+// least as long. So the candidates are always in decreasing order from front to back, and the
+// maximum of the whole queue is just the candidate at the front.
+//
+// Each value is pushed onto and popped off of the candidates at most once,
+// so the extra work is O(1) amortized per EnQueue.
+type MonotonicQueue[T any] struct {
+	data       []T
+	candidates []candidate[T]
+	enqueued   int               // sequence number of the next value to be enqueued
+	dequeued   int               // sequence number of the next value to be dequeued
+	before     func(a, b T) bool // whether a should be reported ahead of b
+}
+
+type candidate[T any] struct {
+	value T
+	seq   int
+}
+
+// NewMonotonicQueue creates a MonotonicQueue whose Aggregate is the value that comes
+// before all others according to the given function; for example, the largest value
+// when before(a, b) reports whether a > b
+func NewMonotonicQueue[T any](before func(a, b T) bool) *MonotonicQueue[T] {
+	return &MonotonicQueue[T]{before: before}
+}
+
+func NewMaxQueue[T cmp.Ordered]() *MonotonicQueue[T] {
+	return NewMonotonicQueue(func(a, b T) bool { return a > b })
+}
+
+func NewMinQueue[T cmp.Ordered]() *MonotonicQueue[T] {
+	return NewMonotonicQueue(func(a, b T) bool { return a < b })
+}
+
+func (mq *MonotonicQueue[T]) EnQueue(v T) error {
+	mq.data = append(mq.data, v)
+
+	// drop every candidate the new value beats; ties are kept, so duplicates of the
+	// current maximum stay candidates until their own turn to be dequeued
+	for len(mq.candidates) > 0 && mq.before(v, mq.candidates[len(mq.candidates)-1].value) {
+		mq.candidates = mq.candidates[:len(mq.candidates)-1]
+	}
+	mq.candidates = append(mq.candidates, candidate[T]{value: v, seq: mq.enqueued})
+	mq.enqueued++
+	return nil
+}
+
+func (mq *MonotonicQueue[T]) DeQueue() (T, error) {
+	if len(mq.data) == 0 {
+		var zero T
+		return zero, QueueEmptyError{}
+	}
+	v := mq.data[0]
+	mq.data = mq.data[1:]
+
+	if mq.candidates[0].seq == mq.dequeued {
+		mq.candidates = mq.candidates[1:]
+	}
+	mq.dequeued++
+	return v, nil
+}
+
+// Aggregate returns the value which comes before all others currently in the queue
+func (mq *MonotonicQueue[T]) Aggregate() (T, error) {
+	if len(mq.data) == 0 {
+		var zero T
+		return zero, QueueEmptyError{}
+	}
+	return mq.candidates[0].value, nil
+}
+
+func (mq *MonotonicQueue[T]) Len() int {
+	return len(mq.data)
+}
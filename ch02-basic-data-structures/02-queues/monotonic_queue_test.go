@@ -0,0 +1,75 @@
+package basicdatastructures
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonotonicQueue(t *testing.T) {
+
+	t.Run("empty queue", func(t *testing.T) {
+		queue := NewMaxQueue[int]()
+		_, err := queue.Aggregate()
+		assert.ErrorIs(t, err, QueueEmptyError{})
+		_, err = queue.DeQueue()
+		assert.ErrorIs(t, err, QueueEmptyError{})
+	})
+
+	t.Run("max and min follow the queue", func(t *testing.T) {
+		maxQueue, minQueue := NewMaxQueue[int](), NewMinQueue[int]()
+		inputs := []int{4, 2, 12, 3, 3, 12, 1, 8}
+		expectedMax := []int{12, 12, 12, 12, 12, 12, 8, 8}
+		expectedMin := []int{1, 1, 1, 1, 1, 1, 1, 8}
+
+		for _, v := range inputs {
+			assert.Nil(t, maxQueue.EnQueue(v))
+			assert.Nil(t, minQueue.EnQueue(v))
+		}
+		for i, v := range inputs {
+			max, err := maxQueue.Aggregate()
+			assert.Nil(t, err)
+			assert.Equal(t, expectedMax[i], max)
+			min, err := minQueue.Aggregate()
+			assert.Nil(t, err)
+			assert.Equal(t, expectedMin[i], min)
+
+			val, err := maxQueue.DeQueue()
+			assert.Nil(t, err)
+			assert.Equal(t, v, val)
+			_, err = minQueue.DeQueue()
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 0, maxQueue.Len())
+	})
+
+	t.Run("random operations match a brute force max", func(t *testing.T) {
+		r := rand.New(rand.NewSource(30))
+		queue := NewMaxQueue[int]()
+		var model []int
+		for i := 0; i < 2000; i++ {
+			if r.Intn(3) > 0 {
+				v := r.Intn(20)
+				assert.Nil(t, queue.EnQueue(v))
+				model = append(model, v)
+			} else if len(model) > 0 {
+				v, err := queue.DeQueue()
+				assert.Nil(t, err)
+				assert.Equal(t, model[0], v)
+				model = model[1:]
+			}
+
+			assert.Equal(t, len(model), queue.Len())
+			if len(model) > 0 {
+				expected := model[0]
+				for _, v := range model {
+					expected = max(expected, v)
+				}
+				actual, err := queue.Aggregate()
+				assert.Nil(t, err)
+				assert.Equal(t, expected, actual)
+			}
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package basicdatastructures
+
+import "time"
+
+// WindowAggregator is a Queue of typed values which can also report an aggregate
+// of all the values it currently holds, such as a MonotonicQueue or AggregatingQueue
+type WindowAggregator[T any] interface {
+	EnQueue(v T) error
+	DeQueue() (T, error)
+	Len() int
+	Aggregate() (T, error)
+}
+
+type windowBounds struct {
+	count    int           // maximum number of values in the window; 0 for no limit
+	duration time.Duration // maximum age of values in the window; 0 for no limit
+	clock    func() time.Time
+}
+
+type WindowQueueOpt func(bounds *windowBounds)
+
+// WithWindowCount keeps only the most recent count values in the window
+func WithWindowCount(count int) WindowQueueOpt {
+	return func(bounds *windowBounds) {
+		bounds.count = count
+	}
+}
+
+// WithWindowDuration keeps only the values enqueued less than duration ago
+func WithWindowDuration(duration time.Duration) WindowQueueOpt {
+	return func(bounds *windowBounds) {
+		bounds.duration = duration
+	}
+}
+
+// WithWindowClock sets the source of timestamps used by EnQueue; defaults to time.Now
+func WithWindowClock(clock func() time.Time) WindowQueueOpt {
+	return func(bounds *windowBounds) {
+		bounds.clock = clock
+	}
+}
+
+// WindowQueue maintains a sliding window over a stream of values, evicting old values from
+// the underlying WindowAggregator as new ones arrive, so the rolling aggregate of the window
+// is always available in O(1) amortized time.
+//
+// The window can be bounded by count, by age, or both.
+// Without any bounds given, the window holds the DefaultQueueCapacity most recent values.
+type WindowQueue[T any] struct {
+	windowBounds
+	aggregator WindowAggregator[T]
+	times      []time.Time // when each value in the window was enqueued, oldest first
+}
+
+func NewWindowQueue[T any](aggregator WindowAggregator[T], opts ...WindowQueueOpt) *WindowQueue[T] {
+	window := &WindowQueue[T]{
+		windowBounds: windowBounds{clock: time.Now},
+		aggregator:   aggregator,
+	}
+	for _, opt := range opts {
+		opt(&window.windowBounds)
+	}
+	if window.count == 0 && window.duration == 0 {
+		window.count = DefaultQueueCapacity
+	}
+	return window
+}
+
+func (wq *WindowQueue[T]) EnQueue(v T) error {
+	return wq.EnQueueAt(v, wq.clock())
+}
+
+// EnQueueAt adds a value to the window with the given timestamp, which should be no earlier
+// than the timestamps of the values already in the window, then evicts values which have
+// fallen out of the window
+func (wq *WindowQueue[T]) EnQueueAt(v T, at time.Time) error {
+	err := wq.aggregator.EnQueue(v)
+	if err != nil {
+		return err
+	}
+	wq.times = append(wq.times, at)
+
+	for wq.count > 0 && wq.Len() > wq.count {
+		err = wq.evict()
+		if err != nil {
+			return err
+		}
+	}
+	return wq.Advance(at)
+}
+
+// Advance evicts all values which are too old to be in the window as of the given time,
+// for time-based windows where the stream may go quiet for a while
+func (wq *WindowQueue[T]) Advance(now time.Time) error {
+	if wq.duration == 0 {
+		return nil
+	}
+	for wq.Len() > 0 && now.Sub(wq.times[0]) >= wq.duration {
+		err := wq.evict()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Aggregate returns the aggregate of all values currently in the window
+func (wq *WindowQueue[T]) Aggregate() (T, error) {
+	return wq.aggregator.Aggregate()
+}
+
+func (wq *WindowQueue[T]) Len() int {
+	return wq.aggregator.Len()
+}
+
+func (wq *WindowQueue[T]) evict() error {
+	_, err := wq.aggregator.DeQueue()
+	if err != nil {
+		return err
+	}
+	wq.times = wq.times[1:]
+	return nil
+}
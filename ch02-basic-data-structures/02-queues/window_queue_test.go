@@ -0,0 +1,106 @@
+package basicdatastructures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mean aggregates a sum and a count, so a rolling average can be
+// computed from an AggregatingQueue with an associative operator
+type mean struct {
+	sum   float64
+	count int
+}
+
+func (m mean) value() float64 {
+	return m.sum / float64(m.count)
+}
+
+func addMeans(a, b mean) mean {
+	return mean{sum: a.sum + b.sum, count: a.count + b.count}
+}
+
+func TestWindowQueue(t *testing.T) {
+
+	t.Run("count-based rolling max", func(t *testing.T) {
+		window := NewWindowQueue[int](NewMaxQueue[int](), WithWindowCount(3))
+		inputs := []int{1, 3, -1, -3, 5, 3, 6, 7}
+		expected := []int{1, 3, 3, 3, 5, 5, 6, 7}
+
+		for i, v := range inputs {
+			assert.Nil(t, window.EnQueue(v))
+			assert.Equal(t, min(i+1, 3), window.Len())
+			max, err := window.Aggregate()
+			assert.Nil(t, err)
+			assert.Equal(t, expected[i], max)
+		}
+	})
+
+	t.Run("default count", func(t *testing.T) {
+		sum := func(a, b int) int { return a + b }
+		window := NewWindowQueue[int](NewAggregatingQueue(sum))
+		for i := 1; i <= 2*DefaultQueueCapacity; i++ {
+			assert.Nil(t, window.EnQueue(i))
+		}
+		assert.Equal(t, DefaultQueueCapacity, window.Len())
+		total, err := window.Aggregate()
+		assert.Nil(t, err)
+		assert.Equal(t, 155, total) // 11 + 12 + ... + 20
+	})
+
+	t.Run("time-based rolling average", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		now := start
+		window := NewWindowQueue[mean](
+			NewAggregatingQueue(addMeans),
+			WithWindowDuration(time.Minute),
+			WithWindowClock(func() time.Time { return now }),
+		)
+
+		events := []struct {
+			after    time.Duration
+			value    float64
+			expected float64
+		}{
+			{after: 0, value: 10, expected: 10},
+			{after: 20 * time.Second, value: 20, expected: 15},
+			{after: 20 * time.Second, value: 60, expected: 30},
+			{after: 30 * time.Second, value: 0, expected: 80.0 / 3}, // first event is 70s old
+			{after: 30 * time.Second, value: 5, expected: 2.5},      // 60s old is out of the window
+		}
+		for _, event := range events {
+			now = now.Add(event.after)
+			assert.Nil(t, window.EnQueue(mean{sum: event.value, count: 1}))
+			avg, err := window.Aggregate()
+			assert.Nil(t, err)
+			assert.Equal(t, event.expected, avg.value())
+		}
+
+		// the stream goes quiet and everything ages out of the window
+		assert.Nil(t, window.Advance(now.Add(time.Minute)))
+		assert.Equal(t, 0, window.Len())
+		_, err := window.Aggregate()
+		assert.ErrorIs(t, err, QueueEmptyError{})
+	})
+
+	t.Run("count and time bounds together", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		window := NewWindowQueue[int](
+			NewMinQueue[int](), WithWindowCount(2), WithWindowDuration(10*time.Second),
+		)
+		assert.Nil(t, window.EnQueueAt(1, start))
+		assert.Nil(t, window.EnQueueAt(5, start.Add(time.Second)))
+		assert.Nil(t, window.EnQueueAt(4, start.Add(2*time.Second))) // count evicts 1
+		min, err := window.Aggregate()
+		assert.Nil(t, err)
+		assert.Equal(t, 4, min)
+
+		assert.Nil(t, window.EnQueueAt(9, start.Add(12*time.Second))) // age evicts 5 and 4
+		assert.Equal(t, 1, window.Len())
+		min, err = window.Aggregate()
+		assert.Nil(t, err)
+		assert.Equal(t, 9, min)
+	})
+}
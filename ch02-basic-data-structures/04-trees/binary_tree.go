@@ -1,58 +1,184 @@
 package trees
 
-type BinaryTree struct {
-	value      any
-	leftChild  *BinaryTree
-	rightChild *BinaryTree
+type BinaryTree[T any] struct {
+	value      T
+	parent     *BinaryTree[T]
+	leftChild  *BinaryTree[T]
+	rightChild *BinaryTree[T]
 }
 
-type BinaryTreeVisit func(tree *BinaryTree) error
+type BinaryTreeVisit[T any] func(tree *BinaryTree[T]) error
 
-func (bt *BinaryTree) Value() any {
+func NewBinaryTree[T any](v T) *BinaryTree[T] {
+	return &BinaryTree[T]{value: v}
+}
+
+func (bt *BinaryTree[T]) Value() T {
 	return bt.value
 }
 
-func (bt *BinaryTree) LeftChild() *BinaryTree {
+func (bt *BinaryTree[T]) SetValue(v T) {
+	bt.value = v
+}
+
+func (bt *BinaryTree[T]) Root() Tree[T] {
+	return bt.root()
+}
+
+func (bt *BinaryTree[T]) Parent() Tree[T] {
+	if bt.parent == nil {
+		return nil
+	}
+	return bt.parent
+}
+
+// Children returns the left and right children of the tree, skipping any which are missing
+func (bt *BinaryTree[T]) Children() []Tree[T] {
+	var children []Tree[T]
+	for _, child := range binaryChildren(bt) {
+		children = append(children, child)
+	}
+	return children
+}
+
+func (bt *BinaryTree[T]) IsExternal() bool {
+	return bt.leftChild == nil && bt.rightChild == nil
+}
+
+func (bt *BinaryTree[T]) Depth() int {
+	depth := 0
+	for ancestor := bt.parent; ancestor != nil; ancestor = ancestor.parent {
+		depth++
+	}
+	return depth
+}
+
+func (bt *BinaryTree[T]) Height() int {
+	maxHeight := 0
+	for _, child := range []*BinaryTree[T]{bt.leftChild, bt.rightChild} {
+		if child != nil {
+			maxHeight = max(maxHeight, child.Height())
+		}
+	}
+	return maxHeight + 1
+}
+
+func (bt *BinaryTree[T]) LeftChild() *BinaryTree[T] {
 	return bt.leftChild
 }
 
-func (bt *BinaryTree) RightChild() *BinaryTree {
+func (bt *BinaryTree[T]) RightChild() *BinaryTree[T] {
 	return bt.rightChild
 }
 
-func (bt *BinaryTree) TraverseEuler(leftVisit, belowVisit, rightVisit BinaryTreeVisit) error {
-	if leftVisit != nil {
-		err := leftVisit(bt)
-		if err != nil {
-			return err
-		}
+// SetLeft attaches a tree as the left child of this tree, detaching any previous left child.
+// Setting a nil left child just detaches the previous one.
+//
+// The child must be the root of its own separate tree: it cannot already have a parent,
+// and it cannot be the root of this tree, which would create a cycle.
+func (bt *BinaryTree[T]) SetLeft(child *BinaryTree[T]) error {
+	if child == bt.leftChild {
+		return nil
+	}
+	err := bt.checkAttach(child)
+	if err != nil {
+		return err
+	}
+	if bt.leftChild != nil {
+		bt.leftChild.parent = nil
 	}
+	bt.leftChild = child
+	if child != nil {
+		child.parent = bt
+	}
+	return nil
+}
 
-	if leftChild := bt.LeftChild(); leftChild != nil {
-		err := leftChild.TraverseEuler(leftVisit, belowVisit, rightVisit)
-		if err != nil {
-			return err
-		}
+// SetRight attaches a tree as the right child of this tree, detaching any previous right child.
+// Setting a nil right child just detaches the previous one.
+func (bt *BinaryTree[T]) SetRight(child *BinaryTree[T]) error {
+	if child == bt.rightChild {
+		return nil
 	}
-	if belowVisit != nil {
-		err := belowVisit(bt)
-		if err != nil {
-			return err
-		}
+	err := bt.checkAttach(child)
+	if err != nil {
+		return err
+	}
+	if bt.rightChild != nil {
+		bt.rightChild.parent = nil
+	}
+	bt.rightChild = child
+	if child != nil {
+		child.parent = bt
 	}
+	return nil
+}
 
-	if rightChild := bt.RightChild(); rightChild != nil {
-		err := rightChild.TraverseEuler(leftVisit, belowVisit, rightVisit)
-		if err != nil {
-			return err
-		}
+// Detach removes this tree from its parent, leaving it as the root of its own tree
+func (bt *BinaryTree[T]) Detach() {
+	parent := bt.parent
+	if parent == nil {
+		return
 	}
-	if rightVisit != nil {
-		err := rightVisit(bt)
-		if err != nil {
-			return err
-		}
+	if parent.leftChild == bt {
+		parent.leftChild = nil
+	} else {
+		parent.rightChild = nil
+	}
+	bt.parent = nil
+}
+
+// Replace puts the replacement tree in this tree's place under its parent,
+// leaving this tree detached as the root of its own tree.
+// Replacing with nil just detaches this tree.
+func (bt *BinaryTree[T]) Replace(replacement *BinaryTree[T]) error {
+	parent := bt.parent
+	if parent == nil {
+		return TreeRootError{}
+	}
+	err := parent.checkAttach(replacement)
+	if err != nil {
+		return err
+	}
+	if parent.leftChild == bt {
+		bt.Detach()
+		return parent.SetLeft(replacement)
 	}
+	bt.Detach()
+	return parent.SetRight(replacement)
+}
 
+func (bt *BinaryTree[T]) checkAttach(child *BinaryTree[T]) error {
+	if child == nil {
+		return nil
+	}
+	if child.parent != nil {
+		return TreeHasParentError{}
+	}
+	if bt.root() == child {
+		return TreeCycleError{}
+	}
 	return nil
 }
+
+func (bt *BinaryTree[T]) root() *BinaryTree[T] {
+	root := bt
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+func (bt *BinaryTree[T]) TraverseEuler(leftVisit, belowVisit, rightVisit BinaryTreeVisit[T]) error {
+	return bt.WalkEuler(binaryWalk(leftVisit), binaryWalk(belowVisit), binaryWalk(rightVisit))
+}
+
+// binaryWalk adapts a visitor which doesn't care about depth or path into a walker
+func binaryWalk[T any](visit BinaryTreeVisit[T]) BinaryTreeWalkFunc[T] {
+	if visit == nil {
+		return nil
+	}
+	return func(tree *BinaryTree[T], _ int, _ []*BinaryTree[T]) error {
+		return visit(tree)
+	}
+}
@@ -0,0 +1,84 @@
+package trees
+
+import "iter"
+
+func binaryChildren[T any](bt *BinaryTree[T]) []*BinaryTree[T] {
+	var children []*BinaryTree[T]
+	if bt.leftChild != nil {
+		children = append(children, bt.leftChild)
+	}
+	if bt.rightChild != nil {
+		children = append(children, bt.rightChild)
+	}
+	return children
+}
+
+// All iterates over the positions of the tree in pre-order, the same order
+// OrderedTree.All uses, so code written against either tree sees the same order
+func (bt *BinaryTree[T]) All() iter.Seq[*BinaryTree[T]] {
+	return bt.PreOrderCursor().All()
+}
+
+// InOrder iterates over the positions of the tree in-order,
+// which for a binary search tree is the order of their keys
+func (bt *BinaryTree[T]) InOrder() iter.Seq[*BinaryTree[T]] {
+	return bt.InOrderCursor().All()
+}
+
+func (bt *BinaryTree[T]) PreOrderCursor() *Cursor[*BinaryTree[T]] {
+	return preOrderCursor(bt, binaryChildren[T])
+}
+
+func (bt *BinaryTree[T]) PostOrderCursor() *Cursor[*BinaryTree[T]] {
+	return postOrderCursor(bt, binaryChildren[T])
+}
+
+// InOrderCursor visits each position after its left subtree and before its right subtree.
+//
+// The stack holds the positions whose left subtrees are still being visited: to find the
+// next position, go as far left as possible from the current position, pushing everything
+// along the way, then pop the last one pushed and continue from its right child.
+func (bt *BinaryTree[T]) InOrderCursor() *Cursor[*BinaryTree[T]] {
+	stack := newGrowingStack()
+	current := bt
+	return &Cursor[*BinaryTree[T]]{next: func() (*BinaryTree[T], bool) {
+		for ; current != nil; current = current.leftChild {
+			stack.push(current)
+		}
+		v, ok := stack.pop()
+		if !ok {
+			return nil, false
+		}
+		node := v.(*BinaryTree[T])
+		current = node.rightChild
+		return node, true
+	}}
+}
+
+func (bt *BinaryTree[T]) LevelOrderCursor() *Cursor[*BinaryTree[T]] {
+	return levelOrderCursor(bt, binaryChildren[T])
+}
+
+func (bt *BinaryTree[T]) ReverseLevelOrderCursor() *Cursor[*BinaryTree[T]] {
+	return reverseLevelOrderCursor(bt, binaryChildren[T])
+}
+
+func (bt *BinaryTree[T]) ZigZagLevelOrderCursor() *Cursor[*BinaryTree[T]] {
+	return zigZagLevelOrderCursor(bt, binaryChildren[T])
+}
+
+func (bt *BinaryTree[T]) TraverseInOrder(visit BinaryTreeVisit[T]) error {
+	return traverse(bt.InOrderCursor(), visit)
+}
+
+func (bt *BinaryTree[T]) TraverseLevelOrder(visit BinaryTreeVisit[T]) error {
+	return traverse(bt.LevelOrderCursor(), visit)
+}
+
+func (bt *BinaryTree[T]) TraverseReverseLevelOrder(visit BinaryTreeVisit[T]) error {
+	return traverse(bt.ReverseLevelOrderCursor(), visit)
+}
+
+func (bt *BinaryTree[T]) TraverseZigZagLevelOrder(visit BinaryTreeVisit[T]) error {
+	return traverse(bt.ZigZagLevelOrderCursor(), visit)
+}
@@ -0,0 +1,107 @@
+package trees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newNumberTree builds the binary tree
+//
+//	     1
+//	   /   \
+//	  2     3
+//	 / \     \
+//	4   5     6
+//	   /     /
+//	  7     8
+func newNumberTree() *BinaryTree[int] {
+	nodes := map[int]*BinaryTree[int]{}
+	for i := 1; i <= 8; i++ {
+		nodes[i] = NewBinaryTree(i)
+	}
+	_ = nodes[1].SetLeft(nodes[2])
+	_ = nodes[1].SetRight(nodes[3])
+	_ = nodes[2].SetLeft(nodes[4])
+	_ = nodes[2].SetRight(nodes[5])
+	_ = nodes[3].SetRight(nodes[6])
+	_ = nodes[5].SetLeft(nodes[7])
+	_ = nodes[6].SetLeft(nodes[8])
+	return nodes[1]
+}
+
+func TestBinaryTree_Cursors(t *testing.T) {
+	tree := newNumberTree()
+
+	tests := []struct {
+		name     string
+		cursor   *Cursor[*BinaryTree[int]]
+		expected []int
+	}{
+		{"pre-order", tree.PreOrderCursor(), []int{1, 2, 4, 5, 7, 3, 6, 8}},
+		{"in-order", tree.InOrderCursor(), []int{4, 2, 7, 5, 1, 3, 8, 6}},
+		{"post-order", tree.PostOrderCursor(), []int{4, 7, 5, 2, 8, 6, 3, 1}},
+		{"level-order", tree.LevelOrderCursor(), []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"reverse level-order", tree.ReverseLevelOrderCursor(), []int{7, 8, 4, 5, 6, 2, 3, 1}},
+		{"zig-zag level-order", tree.ZigZagLevelOrderCursor(), []int{1, 3, 2, 4, 5, 6, 8, 7}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, collect[*BinaryTree[int], int](test.cursor))
+			_, ok := test.cursor.Next()
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("All is pre-order", func(t *testing.T) {
+		var values []int
+		for node := range tree.All() {
+			values = append(values, node.Value())
+		}
+		assert.Equal(t, tests[0].expected, values)
+	})
+
+	t.Run("InOrder is in-order", func(t *testing.T) {
+		var values []int
+		for node := range tree.InOrder() {
+			values = append(values, node.Value())
+		}
+		assert.Equal(t, tests[1].expected, values)
+	})
+
+	t.Run("traversals match cursors", func(t *testing.T) {
+		traversals := map[int]func(BinaryTreeVisit[int]) error{
+			1: tree.TraverseInOrder,
+			3: tree.TraverseLevelOrder,
+			4: tree.TraverseReverseLevelOrder,
+			5: tree.TraverseZigZagLevelOrder,
+		}
+		for i, traversal := range traversals {
+			var values []int
+			err := traversal(func(tree *BinaryTree[int]) error {
+				values = append(values, tree.Value())
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tests[i].expected, values)
+		}
+	})
+}
+
+func TestBinaryTree_DeepCursors(t *testing.T) {
+	// a left-leaning path, the worst case for the in-order stack
+	const depth = 100000
+	root := NewBinaryTree(depth - 1)
+	for i := depth - 2; i >= 0; i-- {
+		parent := NewBinaryTree(i)
+		_ = parent.SetLeft(root)
+		root = parent
+	}
+
+	count := 0
+	for node := range root.InOrder() {
+		assert.Equal(t, depth-1-count, node.Value())
+		count++
+	}
+	assert.Equal(t, depth, count)
+}
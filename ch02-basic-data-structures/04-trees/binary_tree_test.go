@@ -10,90 +10,94 @@ func TestBinaryTree_TraverseEuler(t *testing.T) {
 	// expression tree for ((((3 + 1) * 3)/((9 − 5) + 2)) − ((3 * (7 − 4)) + 6))
 	// the constructed expression string will be a valid python expression
 	// the expression evaluates to -13
-	expressionTree := &BinaryTree{
-		value: "-",
-		leftChild: &BinaryTree{
-			value: "/",
-			leftChild: &BinaryTree{
-				value: "*",
-				leftChild: &BinaryTree{
-					value: "+",
-					leftChild: &BinaryTree{
-						value: "3",
-					},
-					rightChild: &BinaryTree{
-						value: "1",
-					},
-				},
-				rightChild: &BinaryTree{
-					value:      "3",
-					leftChild:  nil,
-					rightChild: nil,
-				},
-			},
-			rightChild: &BinaryTree{
-				value: "+",
-				leftChild: &BinaryTree{
-					value: "-",
-					leftChild: &BinaryTree{
-						value: "9",
-					},
-					rightChild: &BinaryTree{
-						value: "5",
-					},
-				},
-				rightChild: &BinaryTree{
-					value: "2",
-				},
-			},
-		},
-		rightChild: &BinaryTree{
-			value: "+",
-			leftChild: &BinaryTree{
-				value: "*",
-				leftChild: &BinaryTree{
-					value: "3",
-				},
-				rightChild: &BinaryTree{
-					value: "-",
-					leftChild: &BinaryTree{
-						value: "7",
-					},
-					rightChild: &BinaryTree{
-						value: "4",
-					},
-				},
-			},
-			rightChild: &BinaryTree{
-				value: "6",
-			},
-		},
-	}
+	expressionTree, err := ParseBinaryTree("-(/(*(+(3,1),3),+(-(9,5),2)),+(*(3,-(7,4)),6))", parseString)
+	assert.Nil(t, err)
+
 	expression := ""
-	leftVisit := func(tree *BinaryTree) error {
+	leftVisit := func(tree *BinaryTree[string]) error {
 		if tree.LeftChild() != nil && tree.RightChild() != nil {
 			expression += "("
 		}
 		return nil
 	}
-	belowVisit := func(tree *BinaryTree) error {
+	belowVisit := func(tree *BinaryTree[string]) error {
 		if tree.LeftChild() != nil || tree.RightChild() != nil {
 			// not external; is an operand; pad with spaces
-			expression += " " + tree.Value().(string) + " "
+			expression += " " + tree.Value() + " "
 		} else {
 			// external; is a value; do not pad
-			expression += tree.Value().(string)
+			expression += tree.Value()
 		}
 		return nil
 	}
-	rightVisit := func(tree *BinaryTree) error {
+	rightVisit := func(tree *BinaryTree[string]) error {
 		if tree.LeftChild() != nil && tree.RightChild() != nil {
 			expression += ")"
 		}
 		return nil
 	}
-	err := expressionTree.TraverseEuler(leftVisit, belowVisit, rightVisit)
+	err = expressionTree.TraverseEuler(leftVisit, belowVisit, rightVisit)
 	assert.Nil(t, err)
 
 	assert.Equal(t, "((((3 + 1) * 3) / ((9 - 5) + 2)) - ((3 * (7 - 4)) + 6))", expression)
 }
+
+func TestBinaryTree_Mutation(t *testing.T) {
+	root := NewBinaryTree("+")
+	left, right := NewBinaryTree("1"), NewBinaryTree("2")
+
+	assert.Nil(t, root.SetLeft(left))
+	assert.Nil(t, root.SetRight(right))
+	assert.Equal(t, left, root.LeftChild())
+	assert.Equal(t, right, root.RightChild())
+	assert.Equal(t, root, left.Parent())
+	assert.Equal(t, root, right.Parent())
+	// setting the same child again is a no-op
+	assert.Nil(t, root.SetLeft(left))
+
+	t.Run("invariant checks", func(t *testing.T) {
+		// a node cannot have two parents
+		assert.ErrorIs(t, right.SetLeft(left), TreeHasParentError{})
+		// a tree cannot be attached below itself
+		assert.ErrorIs(t, left.SetRight(root), TreeCycleError{})
+		assert.ErrorIs(t, root.SetRight(root), TreeCycleError{})
+		// the root has no place in a parent to be replaced
+		assert.ErrorIs(t, root.Replace(NewBinaryTree("x")), TreeRootError{})
+		assert.ErrorIs(t, left.Replace(right), TreeHasParentError{})
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		product := NewBinaryTree("*")
+		assert.Nil(t, left.Replace(product))
+		assert.Nil(t, left.Parent())
+		assert.Equal(t, product, root.LeftChild())
+		assert.Equal(t, root, product.Parent())
+
+		// the replaced subtree can be reused below its replacement
+		assert.Nil(t, product.SetLeft(left))
+		assert.Nil(t, product.SetRight(NewBinaryTree("3")))
+		product.SetValue("-")
+		assert.Equal(t, "-", root.LeftChild().Value())
+	})
+
+	t.Run("setting a new child detaches the old one", func(t *testing.T) {
+		replacement := NewBinaryTree("4")
+		assert.Nil(t, root.SetRight(replacement))
+		assert.Nil(t, right.Parent())
+		assert.Equal(t, root, replacement.Parent())
+
+		assert.Nil(t, root.SetRight(nil))
+		assert.Nil(t, replacement.Parent())
+		assert.Nil(t, root.RightChild())
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		product := root.LeftChild()
+		product.Detach()
+		assert.Nil(t, product.Parent())
+		assert.Nil(t, root.LeftChild())
+		// detaching a root does nothing
+		product.Detach()
+		assert.Equal(t, left, product.LeftChild())
+	})
+}
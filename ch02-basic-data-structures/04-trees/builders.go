@@ -0,0 +1,48 @@
+package trees
+
+// CompleteBinaryTree builds the complete binary tree holding the values in level order,
+// filling each level from left to right before starting the next, the same layout an
+// array-backed binary heap uses. An empty slice builds an empty, nil, tree.
+//
+// The children of the position holding values[i] hold values[2i+1] and values[2i+2],
+// so unlike BinaryTreeFromLevelOrderArray, no nils are needed to mark missing children.
+func CompleteBinaryTree[T any](values []T) *BinaryTree[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	nodes := make([]*BinaryTree[T], len(values))
+	for i, v := range values {
+		nodes[i] = NewBinaryTree(v)
+		if i > 0 {
+			parent := nodes[(i-1)/2]
+			nodes[i].parent = parent
+			if i%2 == 1 {
+				parent.leftChild = nodes[i]
+			} else {
+				parent.rightChild = nodes[i]
+			}
+		}
+	}
+	return nodes[0]
+}
+
+// BinaryTreeFromSorted builds a balanced binary tree whose in-order traversal gives back
+// the values in the order they are given, so a sorted slice builds a balanced binary
+// search tree. An empty slice builds an empty, nil, tree.
+//
+// The middle value becomes the root, with the values before it built into the left subtree
+// and the values after it into the right subtree. The two halves never differ in size by
+// more than one, so neither do the heights of the two subtrees of any position.
+func BinaryTreeFromSorted[T any](values []T) *BinaryTree[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	middle := (len(values) - 1) / 2
+	tree := NewBinaryTree(values[middle])
+	tree.leftChild = BinaryTreeFromSorted(values[:middle])
+	tree.rightChild = BinaryTreeFromSorted(values[middle+1:])
+	for _, child := range binaryChildren(tree) {
+		child.parent = tree
+	}
+	return tree
+}
@@ -0,0 +1,62 @@
+package trees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequence returns the values 0 to n-1 in order
+func sequence(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+func TestCompleteBinaryTree(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(CompleteBinaryTree[int](nil))
+
+	tree := CompleteBinaryTree([]int{1, 2, 3, 4, 5, 6})
+	assert.Equal("1(2(4,5),3(6,))", tree.Parenthetic())
+	assert.Equal(tree, tree.leftChild.parent)
+	assert.Equal(tree.rightChild, tree.rightChild.leftChild.parent)
+
+	for n := 1; n <= 64; n++ {
+		tree := CompleteBinaryTree(sequence(n))
+		m := tree.Metrics()
+		assert.Equal(n, m.Size)
+		assert.True(m.Complete)
+		assert.True(m.Balanced)
+		assert.Equal(n&(n+1) == 0, m.Perfect, "only sizes one short of a power of two fill every level")
+		assert.Equal(sequence(n), collect(tree.LevelOrderCursor()))
+	}
+}
+
+func TestBinaryTreeFromSorted(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(BinaryTreeFromSorted[int](nil))
+
+	tree := BinaryTreeFromSorted([]string{"a", "b", "c", "d", "e", "f"})
+	assert.Equal("c(a(,b),e(d,f))", tree.Parenthetic())
+	assert.Equal(tree, tree.rightChild.parent)
+	assert.Equal(tree.leftChild, tree.leftChild.rightChild.parent)
+
+	for n := 1; n <= 64; n++ {
+		tree := BinaryTreeFromSorted(sequence(n))
+		m := tree.Metrics()
+		assert.Equal(n, m.Size)
+		assert.True(m.Balanced)
+		assert.Equal(sequence(n), collect(tree.InOrderCursor()))
+		for node := range tree.All() {
+			if node.leftChild != nil && node.rightChild != nil {
+				assert.LessOrEqual(node.rightChild.Size()-node.leftChild.Size(), 1)
+				assert.GreaterOrEqual(node.rightChild.Size()-node.leftChild.Size(), 0)
+			}
+		}
+	}
+}
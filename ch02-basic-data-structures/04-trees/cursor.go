@@ -0,0 +1,260 @@
+package trees
+
+import (
+	"iter"
+
+	stacks "algorithms-and-data-structures/ch02-basic-data-structures/01-stacks"
+	queues "algorithms-and-data-structures/ch02-basic-data-structures/02-queues"
+)
+
+// Cursor is a pull-style iterator over the positions of a tree.
+//
+// Cursors keep their place in the traversal with an explicit ArrayStack or ArrayQueue
+// rather than the goroutine's call stack, so they can walk arbitrarily deep trees,
+// and the caller can simply stop calling Next to end the traversal early.
+type Cursor[N any] struct {
+	next func() (N, bool)
+}
+
+// Next returns the next position in the traversal,
+// or false once the traversal is complete
+func (c *Cursor[N]) Next() (N, bool) {
+	return c.next()
+}
+
+// All adapts the remainder of the traversal for use in a range loop
+func (c *Cursor[N]) All() iter.Seq[N] {
+	return func(yield func(N) bool) {
+		for n, ok := c.Next(); ok; n, ok = c.Next() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// growingStack wraps an ArrayStack, moving its contents into a new stack of double
+// the capacity whenever it fills up, in the same way DynamicArray resizes its storage
+type growingStack struct {
+	stack    *stacks.ArrayStack
+	capacity int
+}
+
+func newGrowingStack() *growingStack {
+	return &growingStack{
+		stack:    stacks.NewArrayStack(),
+		capacity: stacks.DefaultStackCapacity,
+	}
+}
+
+func (gs *growingStack) push(v any) {
+	if gs.stack.Push(v) == nil {
+		return
+	}
+	gs.capacity *= 2
+	bigger := stacks.NewArrayStack(stacks.WithStackCapacity(gs.capacity))
+	values := make([]any, gs.stack.Len())
+	for i := len(values) - 1; i >= 0; i-- {
+		values[i], _ = gs.stack.Pop()
+	}
+	for _, value := range values {
+		_ = bigger.Push(value)
+	}
+	gs.stack = bigger
+	_ = gs.stack.Push(v)
+}
+
+func (gs *growingStack) pop() (any, bool) {
+	v, err := gs.stack.Pop()
+	return v, err == nil
+}
+
+func (gs *growingStack) len() int {
+	return gs.stack.Len()
+}
+
+// growingQueue wraps an ArrayQueue, moving its contents into a new queue of double
+// the capacity whenever it fills up
+type growingQueue struct {
+	queue    *queues.ArrayQueue
+	capacity int
+}
+
+func newGrowingQueue() *growingQueue {
+	return &growingQueue{
+		queue:    queues.NewArrayQueue(),
+		capacity: queues.DefaultQueueCapacity,
+	}
+}
+
+func (gq *growingQueue) enQueue(v any) {
+	if gq.queue.EnQueue(v) == nil {
+		return
+	}
+	gq.capacity *= 2
+	bigger := queues.NewArrayQueue(queues.WithQueueCapacity(gq.capacity))
+	for gq.queue.Len() > 0 {
+		value, _ := gq.queue.DeQueue()
+		_ = bigger.EnQueue(value)
+	}
+	gq.queue = bigger
+	_ = gq.queue.EnQueue(v)
+}
+
+func (gq *growingQueue) deQueue() (any, bool) {
+	v, err := gq.queue.DeQueue()
+	return v, err == nil
+}
+
+func (gq *growingQueue) len() int {
+	return gq.queue.Len()
+}
+
+// The traversals below work for any kind of tree node, given a function listing
+// the children of a node in order
+
+type cursorFrame[N any] struct {
+	node N
+	next int // index of the next child to descend into
+}
+
+func preOrderCursor[N any](root N, children func(N) []N) *Cursor[N] {
+	stack := newGrowingStack()
+	stack.push(root)
+	return &Cursor[N]{next: func() (N, bool) {
+		v, ok := stack.pop()
+		if !ok {
+			var zero N
+			return zero, false
+		}
+		node := v.(N)
+		// push children in reverse, so the first child is on top of the stack
+		kids := children(node)
+		for i := len(kids) - 1; i >= 0; i-- {
+			stack.push(kids[i])
+		}
+		return node, true
+	}}
+}
+
+func postOrderCursor[N any](root N, children func(N) []N) *Cursor[N] {
+	stack := newGrowingStack()
+	stack.push(&cursorFrame[N]{node: root})
+	return &Cursor[N]{next: func() (N, bool) {
+		for {
+			v, ok := stack.pop()
+			if !ok {
+				var zero N
+				return zero, false
+			}
+			frame := v.(*cursorFrame[N])
+			kids := children(frame.node)
+			if frame.next == len(kids) {
+				// all children have been visited
+				return frame.node, true
+			}
+			child := kids[frame.next]
+			frame.next++
+			stack.push(frame)
+			stack.push(&cursorFrame[N]{node: child})
+		}
+	}}
+}
+
+func levelOrderCursor[N any](root N, children func(N) []N) *Cursor[N] {
+	queue := newGrowingQueue()
+	queue.enQueue(root)
+	return &Cursor[N]{next: func() (N, bool) {
+		v, ok := queue.deQueue()
+		if !ok {
+			var zero N
+			return zero, false
+		}
+		node := v.(N)
+		for _, child := range children(node) {
+			queue.enQueue(child)
+		}
+		return node, true
+	}}
+}
+
+// reverseLevelOrderCursor visits the deepest level first, with each level from left to right.
+//
+// A level-order traversal which enqueues children from right to left visits each level
+// from right to left, with the deepest level last; pushing the nodes onto a stack in that
+// order then pops them off in exactly the reverse order we need.
+func reverseLevelOrderCursor[N any](root N, children func(N) []N) *Cursor[N] {
+	var stack *growingStack
+	return &Cursor[N]{next: func() (N, bool) {
+		if stack == nil {
+			stack = newGrowingStack()
+			queue := newGrowingQueue()
+			queue.enQueue(root)
+			for v, ok := queue.deQueue(); ok; v, ok = queue.deQueue() {
+				node := v.(N)
+				stack.push(node)
+				kids := children(node)
+				for i := len(kids) - 1; i >= 0; i-- {
+					queue.enQueue(kids[i])
+				}
+			}
+		}
+		v, ok := stack.pop()
+		if !ok {
+			var zero N
+			return zero, false
+		}
+		return v.(N), true
+	}}
+}
+
+// zigZagLevelOrderCursor visits the root level from left to right,
+// the next level from right to left, and so on, alternating direction at each level.
+//
+// The current level is popped off of one stack while the next level is pushed onto another.
+// Popping a stack reverses the order things were pushed, so pushing children from left to
+// right while visiting a level from left to right sets up the next level from right to left.
+func zigZagLevelOrderCursor[N any](root N, children func(N) []N) *Cursor[N] {
+	current, next := newGrowingStack(), newGrowingStack()
+	current.push(root)
+	leftToRight := true
+	return &Cursor[N]{next: func() (N, bool) {
+		if current.len() == 0 {
+			current, next = next, current
+			leftToRight = !leftToRight
+		}
+		v, ok := current.pop()
+		if !ok {
+			var zero N
+			return zero, false
+		}
+		node := v.(N)
+		kids := children(node)
+		if leftToRight {
+			for _, child := range kids {
+				next.push(child)
+			}
+		} else {
+			for i := len(kids) - 1; i >= 0; i-- {
+				next.push(kids[i])
+			}
+		}
+		return node, true
+	}}
+}
+
+// traverse calls visit on each position from the cursor, stopping at the first error.
+// A cursor has always queued up the children of a position by the time it is visited,
+// so SkipChildren is ignored, while StopTraversal ends the traversal without an error.
+func traverse[N any](cursor *Cursor[N], visit func(N) error) error {
+	for node := range cursor.All() {
+		if visit == nil {
+			continue
+		}
+		err := skipped(visit(node))
+		if err != nil {
+			return endWalk(err)
+		}
+	}
+	return nil
+}
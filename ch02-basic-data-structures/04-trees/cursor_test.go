@@ -0,0 +1,38 @@
+package trees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collect[N interface{ Value() T }, T any](cursor *Cursor[N]) []T {
+	var values []T
+	for node := range cursor.All() {
+		values = append(values, node.Value())
+	}
+	return values
+}
+
+func TestGrowingStackAndQueue(t *testing.T) {
+	stack, queue := newGrowingStack(), newGrowingQueue()
+	const n = 100
+	for i := 0; i < n; i++ {
+		stack.push(i)
+		queue.enQueue(i)
+	}
+	assert.Equal(t, n, stack.len())
+	assert.Equal(t, n, queue.len())
+	for i := 0; i < n; i++ {
+		v, ok := stack.pop()
+		assert.True(t, ok)
+		assert.Equal(t, n-1-i, v)
+		v, ok = queue.deQueue()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	_, ok := stack.pop()
+	assert.False(t, ok)
+	_, ok = queue.deQueue()
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package trees
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DOT returns the tree as a Graphviz DOT digraph, with an edge from each position to each
+// of its children, which can be rendered with a command like `dot -Tsvg`.
+// Each position is labeled with its value as written by fmt.Sprint.
+func (ot *OrderedTree[T]) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+	ids := map[*OrderedTree[T]]int{ot: 0}
+	for node := range ot.All() {
+		writeDOTNode(&sb, ids[node], node.value)
+		for _, child := range node.children {
+			ids[child] = len(ids)
+			writeDOTEdge(&sb, ids[node], ids[child], false)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// DOT returns the binary tree as a Graphviz DOT digraph.
+//
+// Graphviz lays out the children of a node in the order their edges are written,
+// so a position with a single child is given an invisible placeholder for its missing
+// child to keep a lone left child to the left, and a lone right child to the right.
+func (bt *BinaryTree[T]) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+	ids := map[*BinaryTree[T]]int{bt: 0}
+	next := 1
+	for node := range bt.PreOrderCursor().All() {
+		writeDOTNode(&sb, ids[node], node.value)
+		if node.leftChild == nil && node.rightChild == nil {
+			continue
+		}
+		for _, child := range []*BinaryTree[T]{node.leftChild, node.rightChild} {
+			id := next
+			next++
+			if child == nil {
+				fmt.Fprintf(&sb, "\tn%d [shape=point, style=invis];\n", id)
+				writeDOTEdge(&sb, ids[node], id, true)
+				continue
+			}
+			ids[child] = id
+			writeDOTEdge(&sb, ids[node], id, false)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func writeDOTNode(sb *strings.Builder, id int, value any) {
+	fmt.Fprintf(sb, "\tn%d [label=\"%s\"];\n", id, dotEscaper.Replace(fmt.Sprint(value)))
+}
+
+func writeDOTEdge(sb *strings.Builder, from, to int, invisible bool) {
+	if invisible {
+		fmt.Fprintf(sb, "\tn%d -> n%d [style=invis];\n", from, to)
+		return
+	}
+	fmt.Fprintf(sb, "\tn%d -> n%d;\n", from, to)
+}
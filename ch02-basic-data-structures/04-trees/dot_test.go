@@ -0,0 +1,41 @@
+package trees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedTree_DOT(t *testing.T) {
+	tree, err := ParseOrderedTree(`A(B(D,E),"C")`, parseString)
+	assert.Nil(t, err)
+	assert.Equal(t, `digraph {
+	n0 [label="A"];
+	n0 -> n1;
+	n0 -> n2;
+	n1 [label="B"];
+	n1 -> n3;
+	n1 -> n4;
+	n3 [label="D"];
+	n4 [label="E"];
+	n2 [label="\"C\""];
+}
+`, tree.DOT())
+}
+
+func TestBinaryTree_DOT(t *testing.T) {
+	tree, err := ParseBinaryTree("1(2(,4),3)", parseString)
+	assert.Nil(t, err)
+	assert.Equal(t, `digraph {
+	n0 [label="1"];
+	n0 -> n1;
+	n0 -> n2;
+	n1 [label="2"];
+	n3 [shape=point, style=invis];
+	n1 -> n3 [style=invis];
+	n1 -> n4;
+	n4 [label="4"];
+	n2 [label="3"];
+}
+`, tree.DOT())
+}
@@ -0,0 +1,166 @@
+package trees
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EqualOrderedTrees reports whether two trees have the same shape and the same values
+// in the same positions, with two nil trees counting as equal.
+//
+// The values are compared with ==, so just as with ==, if T is an interface type, values
+// holding something which can't be compared, such as a slice, panic when compared.
+func EqualOrderedTrees[T comparable](a, b *OrderedTree[T]) bool {
+	return EqualOrderedTreesFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualOrderedTreesFunc is EqualOrderedTrees with the values compared by eq,
+// which allows comparing trees of different value types
+func EqualOrderedTreesFunc[A, B any](a *OrderedTree[A], b *OrderedTree[B], eq func(A, B) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if len(a.children) != len(b.children) || !eq(a.value, b.value) {
+		return false
+	}
+	for i := range a.children {
+		if !EqualOrderedTreesFunc(a.children[i], b.children[i], eq) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsomorphicOrderedTrees reports whether two trees have the same shape,
+// ignoring their values
+func IsomorphicOrderedTrees[A, B any](a *OrderedTree[A], b *OrderedTree[B]) bool {
+	return EqualOrderedTreesFunc(a, b, func(A, B) bool { return true })
+}
+
+// UnorderedEqualOrderedTrees reports whether two trees are equal
+// once the children of each position are allowed to be rearranged.
+// It panics on values which can't be compared, in the same way as EqualOrderedTrees.
+func UnorderedEqualOrderedTrees[T comparable](a, b *OrderedTree[T]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	intern := interner[T]()
+	label := func(ot *OrderedTree[T]) int { return intern(ot.value) }
+	forms := map[canonicalKey]int{}
+	return canonicalForm(a, orderedChildren[T], label, forms) == canonicalForm(b, orderedChildren[T], label, forms)
+}
+
+// UnorderedIsomorphicOrderedTrees reports whether two trees have the same shape,
+// ignoring their values, once the children of each position are allowed to be rearranged
+func UnorderedIsomorphicOrderedTrees[A, B any](a *OrderedTree[A], b *OrderedTree[B]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	forms := map[canonicalKey]int{}
+	return canonicalForm(a, orderedChildren[A], noLabel[*OrderedTree[A]], forms) ==
+		canonicalForm(b, orderedChildren[B], noLabel[*OrderedTree[B]], forms)
+}
+
+// EqualBinaryTrees reports whether two binary trees have the same shape, down to which
+// side each child is on, and the same values in the same positions.
+// It panics on values which can't be compared, in the same way as EqualOrderedTrees.
+func EqualBinaryTrees[T comparable](a, b *BinaryTree[T]) bool {
+	return EqualBinaryTreesFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualBinaryTreesFunc is EqualBinaryTrees with the values compared by eq,
+// which allows comparing trees of different value types
+func EqualBinaryTreesFunc[A, B any](a *BinaryTree[A], b *BinaryTree[B], eq func(A, B) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return eq(a.value, b.value) &&
+		EqualBinaryTreesFunc(a.leftChild, b.leftChild, eq) &&
+		EqualBinaryTreesFunc(a.rightChild, b.rightChild, eq)
+}
+
+// IsomorphicBinaryTrees reports whether two binary trees have the same shape,
+// ignoring their values
+func IsomorphicBinaryTrees[A, B any](a *BinaryTree[A], b *BinaryTree[B]) bool {
+	return EqualBinaryTreesFunc(a, b, func(A, B) bool { return true })
+}
+
+// UnorderedEqualBinaryTrees reports whether two binary trees are equal once the left and
+// right children of any position are allowed to be swapped, also known as flip equivalence.
+// It panics on values which can't be compared, in the same way as EqualOrderedTrees.
+func UnorderedEqualBinaryTrees[T comparable](a, b *BinaryTree[T]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	intern := interner[T]()
+	label := func(bt *BinaryTree[T]) int { return intern(bt.value) }
+	forms := map[canonicalKey]int{}
+	return canonicalForm(a, binaryChildren[T], label, forms) == canonicalForm(b, binaryChildren[T], label, forms)
+}
+
+// UnorderedIsomorphicBinaryTrees reports whether two binary trees have the same shape,
+// ignoring their values, once the left and right children of any position are allowed
+// to be swapped
+func UnorderedIsomorphicBinaryTrees[A, B any](a *BinaryTree[A], b *BinaryTree[B]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	forms := map[canonicalKey]int{}
+	return canonicalForm(a, binaryChildren[A], noLabel[*BinaryTree[A]], forms) ==
+		canonicalForm(b, binaryChildren[B], noLabel[*BinaryTree[B]], forms)
+}
+
+// canonicalKey identifies a subtree up to rearranging children: the number of its label,
+// and the sorted canonical forms of its children
+type canonicalKey struct {
+	label    int
+	children string
+}
+
+// interner numbers each distinct value it is given, in the order they are first seen,
+// so that labels of any comparable type can be part of a canonicalKey
+func interner[T comparable]() func(T) int {
+	ids := map[T]int{}
+	return func(v T) int {
+		id, ok := ids[v]
+		if !ok {
+			id = len(ids)
+			ids[v] = id
+		}
+		return id
+	}
+}
+
+// canonicalForm numbers each distinct subtree found in a post-order pass, following the
+// Aho-Hopcroft-Ullman algorithm for unordered tree isomorphism.
+//
+// Once the children of a position have been numbered, sorting their numbers gives the same
+// list no matter what order the children are in, so that list, along with the position's
+// own label, is looked up to number the position. Two subtrees get the same number exactly
+// when they are equal up to rearranging children, as long as they share the same forms map.
+func canonicalForm[N any](node N, children func(N) []N, label func(N) int, forms map[canonicalKey]int) int {
+	var childForms []int
+	for _, child := range children(node) {
+		childForms = append(childForms, canonicalForm(child, children, label, forms))
+	}
+	slices.Sort(childForms)
+
+	var sb strings.Builder
+	for _, form := range childForms {
+		sb.WriteString(strconv.Itoa(form))
+		sb.WriteByte(',')
+	}
+	key := canonicalKey{label: label(node), children: sb.String()}
+	form, ok := forms[key]
+	if !ok {
+		form = len(forms)
+		forms[key] = form
+	}
+	return form
+}
+
+// noLabel gives every position the same label, so only the shapes are compared
+func noLabel[N any](N) int {
+	return 0
+}
@@ -0,0 +1,178 @@
+package trees
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// shuffleOrderedTree copies a tree with the children of every position shuffled
+func shuffleOrderedTree[T any](r *rand.Rand, ot *OrderedTree[T]) *OrderedTree[T] {
+	shuffled := NewOrderedTree(ot.value)
+	for _, i := range r.Perm(len(ot.children)) {
+		_ = shuffled.AddChild(shuffleOrderedTree(r, ot.children[i]))
+	}
+	return shuffled
+}
+
+// flipBinaryTree copies a binary tree with the children of random positions swapped
+func flipBinaryTree[T any](r *rand.Rand, bt *BinaryTree[T]) *BinaryTree[T] {
+	if bt == nil {
+		return nil
+	}
+	flipped := NewBinaryTree(bt.value)
+	left, right := flipBinaryTree(r, bt.leftChild), flipBinaryTree(r, bt.rightChild)
+	if r.Intn(2) == 0 {
+		left, right = right, left
+	}
+	_ = flipped.SetLeft(left)
+	_ = flipped.SetRight(right)
+	return flipped
+}
+
+// naiveUnorderedIsomorphic tries every way of matching up the children of two positions
+func naiveUnorderedIsomorphic[A, B any](a *OrderedTree[A], b *OrderedTree[B]) bool {
+	if len(a.children) != len(b.children) {
+		return false
+	}
+	used := make([]bool, len(b.children))
+	var match func(i int) bool
+	match = func(i int) bool {
+		if i == len(a.children) {
+			return true
+		}
+		for j, child := range b.children {
+			if !used[j] && naiveUnorderedIsomorphic(a.children[i], child) {
+				used[j] = true
+				if match(i + 1) {
+					return true
+				}
+				used[j] = false
+			}
+		}
+		return false
+	}
+	return match(0)
+}
+
+func TestOrderedTree_Equality(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := ParseOrderedTree("A(B(D,E),C)", parseString)
+	assert.Nil(err)
+	same, err := ParseOrderedTree("A(B(D,E),C)", parseString)
+	assert.Nil(err)
+	relabeled, err := ParseOrderedTree("1(2(3,4),5)", strconv.Atoi)
+	assert.Nil(err)
+	reordered, err := ParseOrderedTree("A(C,B(E,D))", parseString)
+	assert.Nil(err)
+	reshaped, err := ParseOrderedTree("A(B(D),C(E))", parseString)
+	assert.Nil(err)
+
+	assert.True(EqualOrderedTrees(a, same))
+	assert.True(EqualOrderedTrees[string](nil, nil))
+	assert.False(EqualOrderedTrees(a, nil))
+	assert.False(EqualOrderedTrees(a, reordered))
+	numbers := map[string]int{"A": 1, "B": 2, "D": 3, "E": 4, "C": 5}
+	assert.True(EqualOrderedTreesFunc(a, relabeled, func(s string, i int) bool {
+		return numbers[s] == i
+	}))
+
+	assert.True(IsomorphicOrderedTrees(a, relabeled))
+	assert.False(IsomorphicOrderedTrees(a, reordered))
+	assert.False(IsomorphicOrderedTrees(a, reshaped))
+
+	assert.True(UnorderedEqualOrderedTrees(a, reordered))
+	assert.False(UnorderedEqualOrderedTrees(a, reshaped))
+	assert.True(UnorderedIsomorphicOrderedTrees(reordered, relabeled))
+	assert.False(UnorderedIsomorphicOrderedTrees(a, reshaped))
+	assert.False(UnorderedIsomorphicOrderedTrees(a, (*OrderedTree[int])(nil)))
+
+	r := rand.New(rand.NewSource(37))
+	for i := 0; i < 100; i++ {
+		tree := RandomOrderedTree(r, 1+r.Intn(30), GeometricDegree(0.5))
+		shuffled := shuffleOrderedTree(r, tree)
+		assert.True(UnorderedEqualOrderedTrees(tree, shuffled))
+		assert.True(UnorderedIsomorphicOrderedTrees(tree, shuffled))
+		assert.Equal(EqualOrderedTrees(tree, shuffled), tree.Parenthetic() == shuffled.Parenthetic())
+		if EqualOrderedTrees(tree, shuffled) {
+			assert.True(IsomorphicOrderedTrees(tree, shuffled))
+		}
+	}
+
+	// small random trees of the same size are often, but not always, isomorphic
+	isomorphic := 0
+	for i := 0; i < 300; i++ {
+		n := 1 + r.Intn(7)
+		a, b := RandomOrderedTree(r, n, GeometricDegree(0.5)), RandomOrderedTree(r, n, GeometricDegree(0.5))
+		expected := naiveUnorderedIsomorphic(a, b)
+		assert.Equal(expected, UnorderedIsomorphicOrderedTrees(a, b))
+		if expected {
+			isomorphic++
+		}
+	}
+	assert.Greater(isomorphic, 0)
+	assert.Less(isomorphic, 300)
+}
+
+func TestBinaryTree_Equality(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := ParseBinaryTree("1(2(,4),3)", strconv.Atoi)
+	assert.Nil(err)
+	same, err := ParseBinaryTree("1(2(,4),3)", strconv.Atoi)
+	assert.Nil(err)
+	relabeled, err := ParseBinaryTree("A(B(,D),C)", parseString)
+	assert.Nil(err)
+	mirrored, err := ParseBinaryTree("1(3,2(4,))", strconv.Atoi)
+	assert.Nil(err)
+	sideSwapped, err := ParseBinaryTree("1(2(4,),3)", strconv.Atoi)
+	assert.Nil(err)
+
+	assert.True(EqualBinaryTrees(a, same))
+	assert.True(EqualBinaryTrees[int](nil, nil))
+	assert.False(EqualBinaryTrees(a, sideSwapped))
+	assert.True(IsomorphicBinaryTrees(a, relabeled))
+	assert.False(IsomorphicBinaryTrees(a, sideSwapped))
+	assert.True(EqualBinaryTreesFunc(a, relabeled, func(i int, s string) bool {
+		return int(s[0]-'A'+1) == i
+	}))
+
+	assert.True(UnorderedEqualBinaryTrees(a, mirrored))
+	assert.True(UnorderedEqualBinaryTrees(a, sideSwapped))
+	assert.False(UnorderedEqualBinaryTrees(a, newNumberTree()))
+	assert.True(UnorderedIsomorphicBinaryTrees(mirrored, relabeled))
+	assert.False(UnorderedIsomorphicBinaryTrees(a, (*BinaryTree[string])(nil)))
+
+	r := rand.New(rand.NewSource(37))
+	for i := 0; i < 100; i++ {
+		tree := RandomBinaryTree(r, 1+r.Intn(30))
+		flipped := flipBinaryTree(r, tree)
+		assert.True(UnorderedEqualBinaryTrees(tree, flipped))
+		assert.True(UnorderedIsomorphicBinaryTrees(tree, flipped))
+		assert.Equal(EqualBinaryTrees(tree, flipped), tree.Parenthetic() == flipped.Parenthetic())
+	}
+}
+
+func TestEquality_InterfaceValues(t *testing.T) {
+	assert := assert.New(t)
+
+	// values of an interface type are fine as long as what they hold can be compared
+	a := NewOrderedTree[any](1)
+	assert.Nil(a.AddChild(NewOrderedTree[any]("x")))
+	assert.Nil(a.AddChild(NewOrderedTree[any](2.5)))
+	b := NewOrderedTree[any](1)
+	assert.Nil(b.AddChild(NewOrderedTree[any](2.5)))
+	assert.Nil(b.AddChild(NewOrderedTree[any]("x")))
+	assert.False(EqualOrderedTrees(a, b))
+	assert.True(UnorderedEqualOrderedTrees(a, b))
+
+	// but, as with ==, a value holding a slice panics
+	c := NewOrderedTree[any]([]int{1})
+	assert.Panics(func() { EqualOrderedTrees(c, c) })
+	assert.Panics(func() { UnorderedEqualOrderedTrees(c, c) })
+	d := NewBinaryTree[any]([]int{1})
+	assert.Panics(func() { UnorderedEqualBinaryTrees(d, d) })
+}
@@ -0,0 +1,78 @@
+package expr
+
+// Differentiate returns the simplified derivative of an expression with respect to
+// a variable, applying the rules of differentiation recursively down the tree:
+//
+//	(u + v)' = u' + v'
+//	(u - v)' = u' - v'
+//	(u * v)' = u' * v + u * v'
+//	(u / v)' = (u' * v - u * v') / v ^ 2
+//	(u ^ c)' = c * u ^ (c - 1) * u'    for an exponent c without the variable in it
+//
+// Any other variable is treated as a constant. Differentiating an exponent containing the
+// variable would need logarithms, which expressions can't hold, so it is an error.
+func Differentiate(e *Expression, variable string) (*Expression, error) {
+	err := validate(e)
+	if err != nil {
+		return nil, err
+	}
+	derivative, err := differentiate(e, variable)
+	if err != nil {
+		return nil, err
+	}
+	return simplify(derivative), nil
+}
+
+func differentiate(e *Expression, variable string) (*Expression, error) {
+	token := e.Value()
+	switch token.Kind {
+	case Number:
+		return NewNumber(0), nil
+	case Variable:
+		if token.Symbol == variable {
+			return NewNumber(1), nil
+		}
+		return NewNumber(0), nil
+	}
+
+	u, v := e.LeftChild(), e.RightChild()
+	if token.Symbol == "^" {
+		if contains(v, variable) {
+			return nil, UnsupportedDerivativeError{}
+		}
+		du, err := differentiate(u, variable)
+		if err != nil {
+			return nil, err
+		}
+		power := operation("^", Clone(u), operation("-", Clone(v), NewNumber(1)))
+		return operation("*", operation("*", Clone(v), power), du), nil
+	}
+
+	du, err := differentiate(u, variable)
+	if err != nil {
+		return nil, err
+	}
+	dv, err := differentiate(v, variable)
+	if err != nil {
+		return nil, err
+	}
+	switch token.Symbol {
+	case "+", "-":
+		return operation(token.Symbol, du, dv), nil
+	case "*":
+		return operation("+", operation("*", du, Clone(v)), operation("*", Clone(u), dv)), nil
+	default: // "/"
+		numerator := operation("-", operation("*", du, Clone(v)), operation("*", Clone(u), dv))
+		return operation("/", numerator, operation("^", Clone(v), NewNumber(2))), nil
+	}
+}
+
+// contains reports whether a variable appears anywhere in an expression
+func contains(e *Expression, variable string) bool {
+	for position := range e.All() {
+		if token := position.Value(); token.Kind == Variable && token.Symbol == variable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package expr
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifferentiate(t *testing.T) {
+	tests := []struct {
+		postfix  string
+		expected string
+	}{
+		{"5", "0"},
+		{"x", "1"},
+		{"y", "0"},
+		{"x 3 *", "3"},
+		{"x x *", "2 * x"},
+		{"x 3 ^", "3 * x ^ 2"},
+		{"x 2 ^ 3 x * + 7 -", "2 * x + 3"},
+		{"x y *", "y"},
+		{"1 x /", "(-1) / x ^ 2"},
+		{"x 1 + x 1 - /", "(x - 1 - (x + 1)) / (x - 1) ^ 2"},
+		{"x 2 * 1 + 3 ^", "6 * (2 * x + 1) ^ 2"},
+		{"x y ^", "y * x ^ (y - 1)"},
+	}
+	for _, test := range tests {
+		e, err := ParsePostfix(test.postfix)
+		assert.Nil(t, err, test.postfix)
+		derivative, err := Differentiate(e, "x")
+		assert.Nil(t, err)
+		infix, err := Infix(derivative)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, infix, test.postfix)
+	}
+
+	e, err := ParsePostfix("2 x ^")
+	assert.Nil(t, err)
+	_, err = Differentiate(e, "x")
+	assert.ErrorIs(t, err, UnsupportedDerivativeError{})
+	_, err = Differentiate(nil, "x")
+	assert.ErrorIs(t, err, MalformedExpressionError{})
+}
+
+// TestDifferentiate_FiniteDifference checks derivatives against the slope of the expression
+// measured over a tiny step either side of random points
+func TestDifferentiate_FiniteDifference(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	const h = 1e-6
+	for _, postfix := range []string{
+		"x 3 ^ 2 x * - 1 +",
+		"x 2 ^ 1 + x 1 + /",
+		"x y * x x * * y -",
+		"x 2 * 1 + 4 ^ x /",
+		"x 0.5 ^ x *",
+	} {
+		e, err := ParsePostfix(postfix)
+		assert.Nil(t, err)
+		derivative, err := Differentiate(e, "x")
+		assert.Nil(t, err)
+
+		for i := 0; i < 20; i++ {
+			x, y := 0.5+r.Float64()*2, r.Float64()*4-2
+			above, err := Eval(e, map[string]float64{"x": x + h, "y": y})
+			assert.Nil(t, err)
+			below, err := Eval(e, map[string]float64{"x": x - h, "y": y})
+			assert.Nil(t, err)
+			slope, err := Eval(derivative, map[string]float64{"x": x, "y": y})
+			assert.Nil(t, err)
+			assert.InDelta(t, (above-below)/(2*h), slope, 1e-4, postfix)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package expr
+
+import "math"
+
+// Eval evaluates an expression bottom-up, the way a post-order traversal would:
+// each operator is applied only after both of its operands have been evaluated.
+// Variables take their values from the bindings.
+func Eval(e *Expression, bindings map[string]float64) (float64, error) {
+	err := validate(e)
+	if err != nil {
+		return 0, err
+	}
+	return eval(e, bindings)
+}
+
+func eval(e *Expression, bindings map[string]float64) (float64, error) {
+	token := e.Value()
+	switch token.Kind {
+	case Number:
+		return token.Number, nil
+	case Variable:
+		v, ok := bindings[token.Symbol]
+		if !ok {
+			return 0, UnboundVariableError{}
+		}
+		return v, nil
+	}
+
+	left, err := eval(e.LeftChild(), bindings)
+	if err != nil {
+		return 0, err
+	}
+	right, err := eval(e.RightChild(), bindings)
+	if err != nil {
+		return 0, err
+	}
+	return apply(token.Symbol, left, right)
+}
+
+func apply(op string, left, right float64) (float64, error) {
+	switch op {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, DivisionByZeroError{}
+		}
+		return left / right, nil
+	case "^":
+		return math.Pow(left, right), nil
+	}
+	return 0, UnknownOperatorError{}
+}
@@ -0,0 +1,72 @@
+package expr
+
+import (
+	"testing"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval(t *testing.T) {
+	assert := assert.New(t)
+
+	e, err := ParsePostfix(eulerTourPostfix)
+	assert.Nil(err)
+	v, err := Eval(e, nil)
+	assert.Nil(err)
+	assert.Equal(-13.0, v)
+
+	// area of a trapezoid
+	area, err := ParsePostfix("a b + 2 / h *")
+	assert.Nil(err)
+	v, err = Eval(area, map[string]float64{"a": 3, "b": 5, "h": 2.5})
+	assert.Nil(err)
+	assert.Equal(10.0, v)
+
+	_, err = Eval(area, map[string]float64{"a": 3, "b": 5})
+	assert.ErrorIs(err, UnboundVariableError{})
+
+	e, err = ParsePostfix("1 x 1 - /")
+	assert.Nil(err)
+	_, err = Eval(e, map[string]float64{"x": 1})
+	assert.ErrorIs(err, DivisionByZeroError{})
+
+	e, err = ParsePostfix("2 0.5 ^")
+	assert.Nil(err)
+	v, err = Eval(e, nil)
+	assert.Nil(err)
+	assert.InDelta(1.41421356, v, 1e-8)
+}
+
+func TestEval_Malformed(t *testing.T) {
+	assert := assert.New(t)
+
+	// an operator missing an operand
+	plus := trees.NewBinaryTree(Token{Kind: Operator, Symbol: "+"})
+	assert.Nil(plus.SetLeft(NewNumber(1)))
+	_, err := Eval(plus, nil)
+	assert.ErrorIs(err, MalformedExpressionError{})
+
+	// a number with an operand
+	number := NewNumber(1)
+	assert.Nil(number.SetLeft(NewNumber(2)))
+	_, err = Eval(number, nil)
+	assert.ErrorIs(err, MalformedExpressionError{})
+
+	// an operator we don't know
+	modulo := trees.NewBinaryTree(Token{Kind: Operator, Symbol: "%"})
+	assert.Nil(modulo.SetLeft(NewNumber(1)))
+	assert.Nil(modulo.SetRight(NewNumber(2)))
+	_, err = Eval(modulo, nil)
+	assert.ErrorIs(err, UnknownOperatorError{})
+
+	_, err = Eval(nil, nil)
+	assert.ErrorIs(err, MalformedExpressionError{})
+
+	_, err = NewOperation("%", NewNumber(1), NewNumber(2))
+	assert.ErrorIs(err, UnknownOperatorError{})
+	_, err = NewOperation("+", NewNumber(1), nil)
+	assert.ErrorIs(err, MalformedExpressionError{})
+	_, err = NewOperation("+", plus.LeftChild(), NewNumber(2))
+	assert.ErrorIs(err, trees.TreeHasParentError{})
+}
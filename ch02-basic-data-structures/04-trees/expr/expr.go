@@ -0,0 +1,164 @@
+// Package expr demonstrates expression trees: binary trees whose external positions hold
+// the operands of an arithmetic expression and whose internal positions hold its operators,
+// so that the tree's structure alone decides the order the operations are carried out in.
+package expr
+
+import (
+	"strconv"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+)
+
+type Kind int
+
+const (
+	Number Kind = iota
+	Variable
+	Operator
+)
+
+// Token is the value held by a position of an expression tree
+type Token struct {
+	Kind   Kind
+	Number float64 // the value of a Number
+	Symbol string  // the name of a Variable, or the symbol of an Operator
+}
+
+func (t Token) String() string {
+	if t.Kind == Number {
+		return strconv.FormatFloat(t.Number, 'g', -1, 64)
+	}
+	return t.Symbol
+}
+
+// Expression is a binary tree of tokens, with a Number or Variable at each external position
+// and an Operator with both a left and right operand at each internal position
+type Expression = trees.BinaryTree[Token]
+
+// operators maps each supported operator to its precedence,
+// with higher precedence operators binding more tightly
+var operators = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"^": 3,
+}
+
+func NewNumber(v float64) *Expression {
+	return trees.NewBinaryTree(Token{Kind: Number, Number: v})
+}
+
+func NewVariable(name string) *Expression {
+	return trees.NewBinaryTree(Token{Kind: Variable, Symbol: name})
+}
+
+// NewOperation attaches the left and right operands to a new operator position,
+// so neither of them can already be part of another expression
+func NewOperation(op string, left, right *Expression) (*Expression, error) {
+	if _, ok := operators[op]; !ok {
+		return nil, UnknownOperatorError{}
+	}
+	if left == nil || right == nil {
+		return nil, MalformedExpressionError{}
+	}
+	e := trees.NewBinaryTree(Token{Kind: Operator, Symbol: op})
+	err := e.SetLeft(left)
+	if err != nil {
+		return nil, err
+	}
+	err = e.SetRight(right)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// operation builds an operator position from operands known to be valid and unattached
+func operation(op string, left, right *Expression) *Expression {
+	e, _ := NewOperation(op, left, right)
+	return e
+}
+
+// Clone copies an expression, so the copy can be attached to another expression
+// while the original stays where it is
+func Clone(e *Expression) *Expression {
+	if e.IsExternal() {
+		return trees.NewBinaryTree(e.Value())
+	}
+	return operation(e.Value().Symbol, Clone(e.LeftChild()), Clone(e.RightChild()))
+}
+
+// validate checks every position of an expression holds the right kind of token
+// for its place in the tree
+func validate(e *Expression) error {
+	if e == nil {
+		return MalformedExpressionError{}
+	}
+	token := e.Value()
+	switch token.Kind {
+	case Number:
+	case Variable:
+		if token.Symbol == "" {
+			return MalformedExpressionError{}
+		}
+	case Operator:
+		if _, ok := operators[token.Symbol]; !ok {
+			return UnknownOperatorError{}
+		}
+		if e.LeftChild() == nil || e.RightChild() == nil {
+			return MalformedExpressionError{}
+		}
+		err := validate(e.LeftChild())
+		if err != nil {
+			return err
+		}
+		return validate(e.RightChild())
+	default:
+		return MalformedExpressionError{}
+	}
+	if !e.IsExternal() {
+		return MalformedExpressionError{}
+	}
+	return nil
+}
+
+func isNumber(e *Expression, v float64) bool {
+	return e.Value().Kind == Number && e.Value().Number == v
+}
+
+type MalformedExpressionError struct{}
+
+func (e MalformedExpressionError) Error() string {
+	return "malformed expression"
+}
+
+type UnknownOperatorError struct{}
+
+func (e UnknownOperatorError) Error() string {
+	return "unknown operator"
+}
+
+type InvalidTokenError struct{}
+
+func (e InvalidTokenError) Error() string {
+	return "invalid token"
+}
+
+type UnboundVariableError struct{}
+
+func (e UnboundVariableError) Error() string {
+	return "variable has no value bound"
+}
+
+type DivisionByZeroError struct{}
+
+func (e DivisionByZeroError) Error() string {
+	return "division by zero"
+}
+
+type UnsupportedDerivativeError struct{}
+
+func (e UnsupportedDerivativeError) Error() string {
+	return "cannot differentiate a variable exponent"
+}
@@ -0,0 +1,176 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	stacks "algorithms-and-data-structures/ch02-basic-data-structures/01-stacks"
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+)
+
+// Infix writes an expression in the usual infix notation, using only the parentheses
+// needed to keep the structure of the tree:
+//   - an operand is wrapped when its operator binds less tightly than its parent's
+//   - the right operand of - and / is also wrapped when its operator binds just as tightly,
+//     since a - (b - c) is not a - b - c
+//   - the left operand of ^ is likewise wrapped, since ^ groups from the right
+//   - negative numbers are always wrapped when they are operands, so -2 ^ 2 can't be misread
+func Infix(e *Expression) (string, error) {
+	err := validate(e)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	writeInfix(&sb, e)
+	return sb.String(), nil
+}
+
+func writeInfix(sb *strings.Builder, e *Expression) {
+	token := e.Value()
+	if token.Kind != Operator {
+		sb.WriteString(token.String())
+		return
+	}
+	precedence := operators[token.Symbol]
+	left, right := e.LeftChild(), e.RightChild()
+
+	writeOperand(sb, left, bindsLessTightly(left, precedence, token.Symbol == "^"))
+	sb.WriteString(" " + token.Symbol + " ")
+	writeOperand(sb, right, bindsLessTightly(right, precedence, token.Symbol == "-" || token.Symbol == "/"))
+}
+
+// bindsLessTightly reports whether an operand needs parentheses under an operator
+// of the given precedence, including when it has the same precedence if orEqual is set
+func bindsLessTightly(operand *Expression, precedence int, orEqual bool) bool {
+	token := operand.Value()
+	switch token.Kind {
+	case Number:
+		return token.Number < 0
+	case Variable:
+		return false
+	}
+	operandPrecedence := operators[token.Symbol]
+	return operandPrecedence < precedence || (orEqual && operandPrecedence == precedence)
+}
+
+func writeOperand(sb *strings.Builder, operand *Expression, parenthesize bool) {
+	if parenthesize {
+		sb.WriteByte('(')
+	}
+	writeInfix(sb, operand)
+	if parenthesize {
+		sb.WriteByte(')')
+	}
+}
+
+// FormatPrefix writes an expression in prefix, or Polish, notation: the tokens of a
+// pre-order traversal separated by spaces, each operator coming before its operands
+func FormatPrefix(e *Expression) (string, error) {
+	err := validate(e)
+	if err != nil {
+		return "", err
+	}
+	return formatTokens(e.PreOrderCursor()), nil
+}
+
+// FormatPostfix writes an expression in postfix, or reverse Polish, notation: the tokens of
+// a post-order traversal separated by spaces, each operator coming after its operands
+func FormatPostfix(e *Expression) (string, error) {
+	err := validate(e)
+	if err != nil {
+		return "", err
+	}
+	return formatTokens(e.PostOrderCursor()), nil
+}
+
+func formatTokens(cursor *trees.Cursor[*Expression]) string {
+	var tokens []string
+	for position := range cursor.All() {
+		tokens = append(tokens, position.Value().String())
+	}
+	return strings.Join(tokens, " ")
+}
+
+// ParsePostfix builds an expression from space-separated tokens in postfix notation.
+//
+// Reading the tokens from left to right, each operand is pushed onto a stack as its own
+// expression, and each operator pops the two expressions on top of the stack as its right
+// and left operands, then pushes the combined expression back on.
+// Once all the tokens are read, the one expression left on the stack is the whole thing.
+func ParsePostfix(s string) (*Expression, error) {
+	tokens := strings.Fields(s)
+	return parseStack(tokens, func(left, right *Expression) (*Expression, *Expression) {
+		return left, right
+	})
+}
+
+// ParsePrefix builds an expression from space-separated tokens in prefix notation.
+//
+// Prefix notation read backwards is postfix notation with the operands of each operator
+// swapped around, so it is parsed the same way as ParsePostfix, reading from right to left.
+func ParsePrefix(s string) (*Expression, error) {
+	tokens := strings.Fields(s)
+	for i, j := 0, len(tokens)-1; i < j; i, j = i+1, j-1 {
+		tokens[i], tokens[j] = tokens[j], tokens[i]
+	}
+	return parseStack(tokens, func(first, second *Expression) (*Expression, *Expression) {
+		return second, first
+	})
+}
+
+// parseStack builds an expression from tokens in postfix order, where operands decides
+// which of the two operands popped for an operator goes on the left
+func parseStack(tokens []string, operands func(first, second *Expression) (*Expression, *Expression)) (*Expression, error) {
+	stack := stacks.NewArrayStack(stacks.WithStackCapacity(len(tokens)))
+	for _, text := range tokens {
+		token, err := parseToken(text)
+		if err != nil {
+			return nil, err
+		}
+		if token.Kind != Operator {
+			_ = stack.Push(trees.NewBinaryTree(token))
+			continue
+		}
+
+		second, err := stack.Pop()
+		if err != nil {
+			return nil, MalformedExpressionError{}
+		}
+		first, err := stack.Pop()
+		if err != nil {
+			return nil, MalformedExpressionError{}
+		}
+		left, right := operands(first.(*Expression), second.(*Expression))
+		_ = stack.Push(operation(token.Symbol, left, right))
+	}
+
+	if stack.Len() != 1 {
+		return nil, MalformedExpressionError{}
+	}
+	e, _ := stack.Pop()
+	return e.(*Expression), nil
+}
+
+// parseToken reads an operator symbol, a number, or a variable name
+// made up of letters, digits and underscores, starting with a letter
+func parseToken(text string) (Token, error) {
+	if _, ok := operators[text]; ok {
+		return Token{Kind: Operator, Symbol: text}, nil
+	}
+	if first, _ := utf8.DecodeRuneInString(text); !unicode.IsLetter(first) {
+		// checking the first letter stops variables like inf and nan being read as numbers
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return Token{}, InvalidTokenError{}
+		}
+		return Token{Kind: Number, Number: v}, nil
+	}
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return Token{}, InvalidTokenError{}
+		}
+	}
+	return Token{Kind: Variable, Symbol: text}, nil
+}
@@ -0,0 +1,96 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the expression from the binary tree Euler tour test:
+// ((((3 + 1) * 3) / ((9 - 5) + 2)) - ((3 * (7 - 4)) + 6))
+const eulerTourPostfix = "3 1 + 3 * 9 5 - 2 + / 3 7 4 - * 6 + -"
+
+func TestNotation(t *testing.T) {
+	assert := assert.New(t)
+
+	e, err := ParsePostfix(eulerTourPostfix)
+	assert.Nil(err)
+
+	infix, err := Infix(e)
+	assert.Nil(err)
+	assert.Equal("(3 + 1) * 3 / (9 - 5 + 2) - (3 * (7 - 4) + 6)", infix)
+
+	postfix, err := FormatPostfix(e)
+	assert.Nil(err)
+	assert.Equal(eulerTourPostfix, postfix)
+
+	prefix, err := FormatPrefix(e)
+	assert.Nil(err)
+	assert.Equal("- / * + 3 1 3 + - 9 5 2 + * 3 - 7 4 6", prefix)
+
+	fromPrefix, err := ParsePrefix(prefix)
+	assert.Nil(err)
+	assert.Equal(e.Parenthetic(), fromPrefix.Parenthetic())
+}
+
+func TestInfix(t *testing.T) {
+	tests := []struct {
+		postfix  string
+		expected string
+	}{
+		{"x", "x"},
+		{"-2.5", "-2.5"},
+		{"a b c - -", "a - (b - c)"},
+		{"a b - c -", "a - b - c"},
+		{"a b c + +", "a + b + c"},
+		{"a b + c *", "(a + b) * c"},
+		{"a b c * +", "a + b * c"},
+		{"x y z * /", "x / (y * z)"},
+		{"x y / z *", "x / y * z"},
+		{"a b c ^ ^", "a ^ b ^ c"},
+		{"a b ^ c ^", "(a ^ b) ^ c"},
+		{"-2 2 ^", "(-2) ^ 2"},
+		{"2 -3 *", "2 * (-3)"},
+		{"x 1e+21 *", "x * 1e+21"},
+		{"x_1 y2 -", "x_1 - y2"},
+	}
+	for _, test := range tests {
+		e, err := ParsePostfix(test.postfix)
+		assert.Nil(t, err, test.postfix)
+		infix, err := Infix(e)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, infix, test.postfix)
+
+		// the round trips through prefix and postfix notation come back to the same tree
+		postfix, err := FormatPostfix(e)
+		assert.Nil(t, err)
+		fromPostfix, err := ParsePostfix(postfix)
+		assert.Nil(t, err)
+		assert.Equal(t, e.Parenthetic(), fromPostfix.Parenthetic())
+
+		prefix, err := FormatPrefix(e)
+		assert.Nil(t, err)
+		fromPrefix, err := ParsePrefix(prefix)
+		assert.Nil(t, err)
+		assert.Equal(t, e.Parenthetic(), fromPrefix.Parenthetic())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, s := range []string{"", "1 +", "1 2", "+", "1 2 + +"} {
+		_, err := ParsePostfix(s)
+		assert.ErrorIs(t, err, MalformedExpressionError{}, s)
+		_, err = ParsePrefix(s)
+		assert.ErrorIs(t, err, MalformedExpressionError{}, s)
+	}
+	for _, s := range []string{"1 $ +", "_x", "x-y", "1..2", "%"} {
+		_, err := ParsePostfix(s)
+		assert.ErrorIs(t, err, InvalidTokenError{}, s)
+	}
+
+	// names which strconv would read as numbers are still variables
+	e, err := ParsePostfix("inf nan +")
+	assert.Nil(t, err)
+	assert.Equal(t, Variable, e.LeftChild().Value().Kind)
+	assert.Equal(t, Variable, e.RightChild().Value().Kind)
+}
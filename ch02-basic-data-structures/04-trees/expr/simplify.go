@@ -0,0 +1,94 @@
+package expr
+
+import trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+
+// Simplify returns a simplified copy of an expression, working bottom-up so that each
+// operator is simplified after its operands have been:
+//   - operators applied to two numbers are folded into a single number,
+//     unless that would divide by zero
+//   - identities drop out, like x + 0 → x, x * 1 → x, x ^ 1 → x and x - x → 0
+//   - multiplying by zero gives zero, and raising to the power zero gives one
+//   - numbers are gathered to the left of products, so 2 * (3 * x) and (x * 2) * 3 → 6 * x
+func Simplify(e *Expression) (*Expression, error) {
+	err := validate(e)
+	if err != nil {
+		return nil, err
+	}
+	return simplify(e), nil
+}
+
+func simplify(e *Expression) *Expression {
+	if e.IsExternal() {
+		return Clone(e)
+	}
+	op := e.Value().Symbol
+	left, right := simplify(e.LeftChild()), simplify(e.RightChild())
+
+	if left.Value().Kind == Number && right.Value().Kind == Number {
+		v, err := apply(op, left.Value().Number, right.Value().Number)
+		if err == nil {
+			return NewNumber(v)
+		}
+	}
+
+	equal := trees.EqualBinaryTrees(left, right)
+	switch op {
+	case "+":
+		switch {
+		case isNumber(left, 0):
+			return right
+		case isNumber(right, 0):
+			return left
+		case equal:
+			return simplify(operation("*", NewNumber(2), left))
+		}
+	case "-":
+		switch {
+		case isNumber(right, 0):
+			return left
+		case equal:
+			return NewNumber(0)
+		}
+	case "*":
+		switch {
+		case isNumber(left, 0), isNumber(right, 0):
+			return NewNumber(0)
+		case isNumber(left, 1):
+			return right
+		case isNumber(right, 1):
+			return left
+		case right.Value().Kind == Number:
+			// move the number to the left, where it may meet another one
+			return simplify(operation("*", right, left))
+		case left.Value().Kind == Number && isProductWithNumber(right):
+			// c1 * (c2 * x) → (c1 * c2) * x
+			c := left.Value().Number * right.LeftChild().Value().Number
+			return simplify(operation("*", NewNumber(c), Clone(right.RightChild())))
+		}
+	case "/":
+		switch {
+		case isNumber(right, 1):
+			return left
+		case isNumber(left, 0) && !isNumber(right, 0):
+			return NewNumber(0)
+		case equal && left.Value().Kind != Number:
+			return NewNumber(1)
+		}
+	case "^":
+		switch {
+		case isNumber(right, 0):
+			return NewNumber(1)
+		case isNumber(right, 1):
+			return left
+		case isNumber(left, 1):
+			return NewNumber(1)
+		}
+	}
+	return operation(op, left, right)
+}
+
+// isProductWithNumber reports whether an expression is a number times something else,
+// which is how simplify leaves every product with a number in it
+func isProductWithNumber(e *Expression) bool {
+	return e.Value().Kind == Operator && e.Value().Symbol == "*" && e.LeftChild().Value().Kind == Number
+}
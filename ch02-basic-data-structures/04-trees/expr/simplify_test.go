@@ -0,0 +1,76 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		postfix  string
+		expected string
+	}{
+		{eulerTourPostfix, "-13"},
+		{"x 1 *", "x"},
+		{"1 x *", "x"},
+		{"0 x +", "x"},
+		{"x 0 +", "x"},
+		{"x 0 -", "x"},
+		{"0 x -", "0 - x"},
+		{"x 0 *", "0"},
+		{"x y + 0 *", "0"},
+		{"x 1 /", "x"},
+		{"0 x /", "0"},
+		{"x y - x y - /", "1"},
+		{"x 1 ^", "x"},
+		{"x 0 ^", "1"},
+		{"1 x ^", "1"},
+		{"x y * x y * -", "0"},
+		{"x x +", "2 * x"},
+		{"x 2 *", "2 * x"},
+		{"2 3 x * *", "6 * x"},
+		{"x 2 * 3 *", "6 * x"},
+		{"2 3 + x * 1 2 - +", "5 * x + (-1)"},
+		{"x 2 3 + ^ 1 *", "x ^ 5"},
+		{"1 0 /", "1 / 0"},
+		{"x 0 0 / +", "x + 0 / 0"},
+	}
+	for _, test := range tests {
+		e, err := ParsePostfix(test.postfix)
+		assert.Nil(t, err, test.postfix)
+		before := e.Parenthetic()
+
+		simplified, err := Simplify(e)
+		assert.Nil(t, err)
+		infix, err := Infix(simplified)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, infix, test.postfix)
+		// the original expression is left alone
+		assert.Equal(t, before, e.Parenthetic())
+	}
+
+	_, err := Simplify(nil)
+	assert.ErrorIs(t, err, MalformedExpressionError{})
+}
+
+func TestSimplify_PreservesValue(t *testing.T) {
+	bindings := map[string]float64{"x": 1.5, "y": -2, "z": 3}
+	for _, postfix := range []string{
+		"x 1 * y 0 + * z z - +",
+		"2 x * 3 * y / y y + -",
+		"x 2 ^ 3 * x 2 ^ -",
+		"x y z * * 4 5 * /",
+	} {
+		e, err := ParsePostfix(postfix)
+		assert.Nil(t, err)
+		simplified, err := Simplify(e)
+		assert.Nil(t, err)
+
+		expected, err := Eval(e, bindings)
+		assert.Nil(t, err)
+		actual, err := Eval(simplified, bindings)
+		assert.Nil(t, err)
+		assert.InDelta(t, expected, actual, 1e-9, postfix)
+	}
+}
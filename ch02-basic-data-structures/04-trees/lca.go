@@ -0,0 +1,349 @@
+package trees
+
+import "math/bits"
+
+// AncestorQueries answers questions about how the positions of one OrderedTree relate
+// to each other through their ancestors, after preprocessing the tree once.
+//
+// The preprocessing takes a snapshot of the tree's shape,
+// so the answers no longer hold once the tree has been changed.
+type AncestorQueries[T any] interface {
+	// LCA finds the lowest common ancestor of two positions: the deepest position
+	// which has both of them as descendants, counting a position as its own descendant
+	LCA(u, v *OrderedTree[T]) (*OrderedTree[T], error)
+	// KthAncestor finds the ancestor k levels above a position, with k = 0 being the position itself
+	KthAncestor(u *OrderedTree[T], k int) (*OrderedTree[T], error)
+	// Distance counts the edges on the path between two positions
+	Distance(u, v *OrderedTree[T]) (int, error)
+	// Path lists the positions on the path between two positions, from u up to
+	// their lowest common ancestor and back down to v
+	Path(u, v *OrderedTree[T]) ([]*OrderedTree[T], error)
+}
+
+var _ AncestorQueries[any] = (*NaiveLCA[any])(nil)
+var _ AncestorQueries[any] = (*BinaryLiftingLCA[any])(nil)
+var _ AncestorQueries[any] = (*EulerTourLCA[any])(nil)
+
+// treeIndex numbers the positions of a tree in level order, recording the parent and depth
+// of each one by number. The numbers are used to index the arrays of preprocessed answers,
+// and the parents are recorded so trees without parent links can still be queried.
+type treeIndex[T any] struct {
+	ids    map[*OrderedTree[T]]int
+	nodes  []*OrderedTree[T]
+	parent []int // parent of the root is -1
+	depth  []int
+}
+
+func newTreeIndex[T any](root *OrderedTree[T]) treeIndex[T] {
+	index := treeIndex[T]{
+		ids:    map[*OrderedTree[T]]int{root: 0},
+		nodes:  []*OrderedTree[T]{root},
+		parent: []int{-1},
+		depth:  []int{0},
+	}
+	for node := range root.LevelOrderCursor().All() {
+		id := index.ids[node]
+		for _, child := range node.children {
+			index.ids[child] = len(index.nodes)
+			index.nodes = append(index.nodes, child)
+			index.parent = append(index.parent, id)
+			index.depth = append(index.depth, index.depth[id]+1)
+		}
+	}
+	return index
+}
+
+func (index *treeIndex[T]) id(node *OrderedTree[T]) (int, error) {
+	if node == nil {
+		return 0, NilTreeError{}
+	}
+	id, ok := index.ids[node]
+	if !ok {
+		return 0, NodeNotInTreeError{}
+	}
+	return id, nil
+}
+
+// idPair looks up the numbers of two positions at once
+func (index *treeIndex[T]) idPair(u, v *OrderedTree[T]) (int, int, error) {
+	uID, err := index.id(u)
+	if err != nil {
+		return 0, 0, err
+	}
+	vID, err := index.id(v)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uID, vID, nil
+}
+
+// climb walks up k levels from a position one parent at a time
+func (index *treeIndex[T]) climb(id, k int) int {
+	for ; k > 0; k-- {
+		id = index.parent[id]
+	}
+	return id
+}
+
+// naiveLCA climbs from the deeper position up to the depth of the other,
+// then climbs from both together until they meet
+func (index *treeIndex[T]) naiveLCA(u, v int) int {
+	if index.depth[u] < index.depth[v] {
+		u, v = v, u
+	}
+	u = index.climb(u, index.depth[u]-index.depth[v])
+	for u != v {
+		u, v = index.parent[u], index.parent[v]
+	}
+	return u
+}
+
+func (index *treeIndex[T]) checkKthAncestor(u *OrderedTree[T], k int) (int, error) {
+	id, err := index.id(u)
+	if err != nil {
+		return 0, err
+	}
+	if k < 0 || k > index.depth[id] {
+		return 0, AncestorOutOfRangeError{}
+	}
+	return id, nil
+}
+
+// distance and path share the work of finding the two positions and their LCA,
+// which is all each implementation does differently
+func (index *treeIndex[T]) distance(u, v *OrderedTree[T], lca func(u, v int) int) (int, error) {
+	uID, vID, err := index.idPair(u, v)
+	if err != nil {
+		return 0, err
+	}
+	return index.depth[uID] + index.depth[vID] - 2*index.depth[lca(uID, vID)], nil
+}
+
+func (index *treeIndex[T]) path(u, v *OrderedTree[T], lca func(u, v int) int) ([]*OrderedTree[T], error) {
+	uID, vID, err := index.idPair(u, v)
+	if err != nil {
+		return nil, err
+	}
+	ancestor := lca(uID, vID)
+
+	var path []*OrderedTree[T]
+	for id := uID; id != ancestor; id = index.parent[id] {
+		path = append(path, index.nodes[id])
+	}
+	path = append(path, index.nodes[ancestor])
+	// the way down to v is the way up from v, backwards
+	down := len(path)
+	for id := vID; id != ancestor; id = index.parent[id] {
+		path = append(path, index.nodes[id])
+	}
+	for i, j := down, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// NaiveLCA answers ancestor queries by climbing the tree one parent at a time,
+// taking O(depth) time per query after O(n) preprocessing
+type NaiveLCA[T any] struct {
+	index treeIndex[T]
+}
+
+func NewNaiveLCA[T any](root *OrderedTree[T]) *NaiveLCA[T] {
+	return &NaiveLCA[T]{index: newTreeIndex(root)}
+}
+
+func (n *NaiveLCA[T]) LCA(u, v *OrderedTree[T]) (*OrderedTree[T], error) {
+	uID, vID, err := n.index.idPair(u, v)
+	if err != nil {
+		return nil, err
+	}
+	return n.index.nodes[n.index.naiveLCA(uID, vID)], nil
+}
+
+func (n *NaiveLCA[T]) KthAncestor(u *OrderedTree[T], k int) (*OrderedTree[T], error) {
+	id, err := n.index.checkKthAncestor(u, k)
+	if err != nil {
+		return nil, err
+	}
+	return n.index.nodes[n.index.climb(id, k)], nil
+}
+
+func (n *NaiveLCA[T]) Distance(u, v *OrderedTree[T]) (int, error) {
+	return n.index.distance(u, v, n.index.naiveLCA)
+}
+
+func (n *NaiveLCA[T]) Path(u, v *OrderedTree[T]) ([]*OrderedTree[T], error) {
+	return n.index.path(u, v, n.index.naiveLCA)
+}
+
+// BinaryLiftingLCA answers ancestor queries in O(log n) time, after O(n log n) preprocessing.
+//
+// For every position, it records the ancestors 1, 2, 4, ..., 2^j levels above it,
+// each found by jumping twice the distance of the one before:
+// the 2^j-th ancestor is the 2^(j-1)-th ancestor of the 2^(j-1)-th ancestor.
+// Any climb of k levels then takes one jump for each bit set in k.
+type BinaryLiftingLCA[T any] struct {
+	index treeIndex[T]
+	// up[j][i] is the ancestor 2^j levels above position i, or -1 past the root
+	up [][]int
+}
+
+func NewBinaryLiftingLCA[T any](root *OrderedTree[T]) *BinaryLiftingLCA[T] {
+	index := newTreeIndex(root)
+	n := len(index.nodes)
+	up := [][]int{index.parent}
+	for j := 1; 1<<j < n; j++ {
+		jump := make([]int, n)
+		for i := range jump {
+			if half := up[j-1][i]; half < 0 {
+				jump[i] = -1
+			} else {
+				jump[i] = up[j-1][half]
+			}
+		}
+		up = append(up, jump)
+	}
+	return &BinaryLiftingLCA[T]{index: index, up: up}
+}
+
+// lift climbs k levels up from a position, jumping 2^j levels for each bit j set in k
+func (b *BinaryLiftingLCA[T]) lift(id, k int) int {
+	for j := 0; k > 0; j, k = j+1, k>>1 {
+		if k&1 == 1 {
+			id = b.up[j][id]
+		}
+	}
+	return id
+}
+
+// lca lifts the deeper position up to the depth of the other, then lifts both together
+// by the biggest jumps which still leave them apart, from the biggest jump down.
+// That leaves both of them just below the LCA, which is their parent.
+func (b *BinaryLiftingLCA[T]) lca(u, v int) int {
+	if b.index.depth[u] < b.index.depth[v] {
+		u, v = v, u
+	}
+	u = b.lift(u, b.index.depth[u]-b.index.depth[v])
+	if u == v {
+		return u
+	}
+	for j := len(b.up) - 1; j >= 0; j-- {
+		if b.up[j][u] != b.up[j][v] {
+			u, v = b.up[j][u], b.up[j][v]
+		}
+	}
+	return b.index.parent[u]
+}
+
+func (b *BinaryLiftingLCA[T]) LCA(u, v *OrderedTree[T]) (*OrderedTree[T], error) {
+	uID, vID, err := b.index.idPair(u, v)
+	if err != nil {
+		return nil, err
+	}
+	return b.index.nodes[b.lca(uID, vID)], nil
+}
+
+func (b *BinaryLiftingLCA[T]) KthAncestor(u *OrderedTree[T], k int) (*OrderedTree[T], error) {
+	id, err := b.index.checkKthAncestor(u, k)
+	if err != nil {
+		return nil, err
+	}
+	return b.index.nodes[b.lift(id, k)], nil
+}
+
+func (b *BinaryLiftingLCA[T]) Distance(u, v *OrderedTree[T]) (int, error) {
+	return b.index.distance(u, v, b.lca)
+}
+
+func (b *BinaryLiftingLCA[T]) Path(u, v *OrderedTree[T]) ([]*OrderedTree[T], error) {
+	return b.index.path(u, v, b.lca)
+}
+
+// EulerTourLCA answers LCA and distance queries in O(1) time, after O(n log n) preprocessing.
+//
+// An Euler tour of the tree lists each position when the tour first reaches it, and again
+// each time the tour comes back up to it from one of its children. Between the first visits
+// to any two positions, the tour climbs no higher than their LCA, and has to pass through
+// the LCA to get from one to the other, so the LCA is the shallowest position on that
+// stretch of the tour. That turns each LCA query into a range minimum query over the tour,
+// which a sparse table answers in O(1) time.
+//
+// KthAncestor and Path climb one parent at a time, since their answers take that long to list anyway.
+type EulerTourLCA[T any] struct {
+	index treeIndex[T]
+	tour  []int // the positions in the order the Euler tour visits them
+	first []int // first[i] is the index in the tour of the first visit to position i
+	// sparse[j][i] is the index in the tour of the shallowest position in tour[i : i+2^j]
+	sparse [][]int
+}
+
+func NewEulerTourLCA[T any](root *OrderedTree[T]) *EulerTourLCA[T] {
+	index := newTreeIndex(root)
+	e := &EulerTourLCA[T]{index: index, first: make([]int, len(index.nodes))}
+
+	visit := func(node *OrderedTree[T]) error {
+		e.tour = append(e.tour, index.ids[node])
+		return nil
+	}
+	_ = root.TraverseEuler(func(node *OrderedTree[T]) error {
+		e.first[index.ids[node]] = len(e.tour)
+		return visit(node)
+	}, visit, nil)
+
+	e.sparse = [][]int{make([]int, len(e.tour))}
+	for i := range e.tour {
+		e.sparse[0][i] = i
+	}
+	for j := 1; 1<<j <= len(e.tour); j++ {
+		half := 1 << (j - 1)
+		ranges := make([]int, len(e.tour)-1<<j+1)
+		for i := range ranges {
+			ranges[i] = e.shallower(e.sparse[j-1][i], e.sparse[j-1][i+half])
+		}
+		e.sparse = append(e.sparse, ranges)
+	}
+	return e
+}
+
+// shallower returns whichever of two tour indices visits the shallower position
+func (e *EulerTourLCA[T]) shallower(a, b int) int {
+	if e.index.depth[e.tour[b]] < e.index.depth[e.tour[a]] {
+		return b
+	}
+	return a
+}
+
+// lca covers the stretch of the tour between the first visits to both positions with two
+// overlapping ranges whose length is a power of two, and takes the shallower of their minimums
+func (e *EulerTourLCA[T]) lca(u, v int) int {
+	low, high := e.first[u], e.first[v]
+	if low > high {
+		low, high = high, low
+	}
+	j := bits.Len(uint(high-low+1)) - 1
+	return e.tour[e.shallower(e.sparse[j][low], e.sparse[j][high-1<<j+1])]
+}
+
+func (e *EulerTourLCA[T]) LCA(u, v *OrderedTree[T]) (*OrderedTree[T], error) {
+	uID, vID, err := e.index.idPair(u, v)
+	if err != nil {
+		return nil, err
+	}
+	return e.index.nodes[e.lca(uID, vID)], nil
+}
+
+func (e *EulerTourLCA[T]) KthAncestor(u *OrderedTree[T], k int) (*OrderedTree[T], error) {
+	id, err := e.index.checkKthAncestor(u, k)
+	if err != nil {
+		return nil, err
+	}
+	return e.index.nodes[e.index.climb(id, k)], nil
+}
+
+func (e *EulerTourLCA[T]) Distance(u, v *OrderedTree[T]) (int, error) {
+	return e.index.distance(u, v, e.lca)
+}
+
+func (e *EulerTourLCA[T]) Path(u, v *OrderedTree[T]) ([]*OrderedTree[T], error) {
+	return e.index.path(u, v, e.lca)
+}
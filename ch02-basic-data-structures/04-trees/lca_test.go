@@ -0,0 +1,213 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedTree_TraverseEuler(t *testing.T) {
+	assert := assert.New(t)
+	tree, err := ParseOrderedTree("A(B(D,E),C)", parseString)
+	assert.Nil(err)
+
+	var tour []string
+	record := func(prefix string) OrderedTreeVisit[string] {
+		return func(tree *OrderedTree[string]) error {
+			tour = append(tour, prefix+tree.Value())
+			return nil
+		}
+	}
+	assert.Nil(tree.TraverseEuler(record(""), record("^"), record("/")))
+	assert.Equal([]string{
+		"A", "B", "D", "/D", "^B", "E", "/E", "^B", "/B", "^A", "C", "/C", "^A", "/A",
+	}, tour)
+
+	tour = nil
+	assert.Nil(tree.WalkEuler(func(tree *OrderedTree[string], depth int, _ []*OrderedTree[string]) error {
+		tour = append(tour, tree.Value())
+		if depth == 1 {
+			return SkipChildren
+		}
+		return nil
+	}, nil, func(tree *OrderedTree[string], _ int, path []*OrderedTree[string]) error {
+		tour = append(tour, "/"+tree.Value())
+		if tree.Value() == "C" {
+			assert.Equal([]string{"A", "C"}, pathValues[*OrderedTree[string]](path))
+			return StopTraversal
+		}
+		return nil
+	}))
+	assert.Equal([]string{"A", "B", "/B", "C", "/C"}, tour)
+}
+
+var ancestorQueries = map[string]func(root *OrderedTree[int]) AncestorQueries[int]{
+	"naive": func(root *OrderedTree[int]) AncestorQueries[int] {
+		return NewNaiveLCA(root)
+	},
+	"binary lifting": func(root *OrderedTree[int]) AncestorQueries[int] {
+		return NewBinaryLiftingLCA(root)
+	},
+	"Euler tour": func(root *OrderedTree[int]) AncestorQueries[int] {
+		return NewEulerTourLCA(root)
+	},
+}
+
+// oracleLCA finds the lowest common ancestor straight from the parent links
+func oracleLCA[T any](u, v *OrderedTree[T]) *OrderedTree[T] {
+	ancestors := map[*OrderedTree[T]]bool{}
+	for a := u; a != nil; a = a.parent {
+		ancestors[a] = true
+	}
+	for a := v; a != nil; a = a.parent {
+		if ancestors[a] {
+			return a
+		}
+	}
+	return nil
+}
+
+func TestAncestorQueries(t *testing.T) {
+	for name, newQueries := range ancestorQueries {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// org chart:
+			//
+			//	      1
+			//	    / | \
+			//	   2  3  4
+			//	  / \    |
+			//	 5   6   7
+			//	    / \
+			//	   8   9
+			chart, err := ParseOrderedTree("1(2(5,6(8,9)),3,4(7))", func(s string) (int, error) {
+				return int(s[0] - '0'), nil
+			})
+			assert.Nil(err)
+			nodes := map[int]*OrderedTree[int]{}
+			for node := range chart.All() {
+				nodes[node.Value()] = node
+			}
+			queries := newQueries(chart)
+
+			for _, lca := range [][3]int{{5, 9, 2}, {8, 7, 1}, {6, 8, 6}, {3, 3, 3}, {1, 9, 1}, {9, 8, 6}} {
+				ancestor, err := queries.LCA(nodes[lca[0]], nodes[lca[1]])
+				assert.Nil(err)
+				assert.Equal(lca[2], ancestor.Value(), lca)
+			}
+
+			path, err := queries.Path(nodes[5], nodes[9])
+			assert.Nil(err)
+			assert.Equal([]int{5, 2, 6, 9}, pathValues[*OrderedTree[int]](path))
+			path, err = queries.Path(nodes[7], nodes[8])
+			assert.Nil(err)
+			assert.Equal([]int{7, 4, 1, 2, 6, 8}, pathValues[*OrderedTree[int]](path))
+			path, err = queries.Path(nodes[3], nodes[3])
+			assert.Nil(err)
+			assert.Equal([]int{3}, pathValues[*OrderedTree[int]](path))
+
+			distance, err := queries.Distance(nodes[7], nodes[8])
+			assert.Nil(err)
+			assert.Equal(5, distance)
+
+			for k, expected := range []int{9, 6, 2, 1} {
+				ancestor, err := queries.KthAncestor(nodes[9], k)
+				assert.Nil(err)
+				assert.Equal(expected, ancestor.Value())
+			}
+			_, err = queries.KthAncestor(nodes[9], 4)
+			assert.ErrorIs(err, AncestorOutOfRangeError{})
+			_, err = queries.KthAncestor(nodes[9], -1)
+			assert.ErrorIs(err, AncestorOutOfRangeError{})
+
+			_, err = queries.LCA(nodes[1], NewOrderedTree(1))
+			assert.ErrorIs(err, NodeNotInTreeError{})
+			_, err = queries.Distance(nil, nodes[1])
+			assert.ErrorIs(err, NilTreeError{})
+			_, err = queries.Path(nodes[1], NewOrderedTree(1))
+			assert.ErrorIs(err, NodeNotInTreeError{})
+			_, err = queries.KthAncestor(NewOrderedTree(1), 0)
+			assert.ErrorIs(err, NodeNotInTreeError{})
+
+			// trees built without parent links can be queried all the same
+			literal := &OrderedTree[int]{value: 1, children: []*OrderedTree[int]{
+				{value: 2}, {value: 3, children: []*OrderedTree[int]{{value: 4}}},
+			}}
+			ancestor, err := newQueries(literal).LCA(literal.children[0], literal.children[1].children[0])
+			assert.Nil(err)
+			assert.Equal(literal, ancestor)
+		})
+	}
+}
+
+func TestAncestorQueries_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	for i := 0; i < 20; i++ {
+		tree := RandomOrderedTree(r, 1+r.Intn(200), GeometricDegree(0.5))
+		var nodes []*OrderedTree[int]
+		for node := range tree.All() {
+			nodes = append(nodes, node)
+		}
+
+		for name, newQueries := range ancestorQueries {
+			queries := newQueries(tree)
+			for j := 0; j < 100; j++ {
+				u, v := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+				expected := oracleLCA(u, v)
+
+				ancestor, err := queries.LCA(u, v)
+				assert.Nil(t, err)
+				assert.Equal(t, expected, ancestor, name)
+
+				distance, err := queries.Distance(u, v)
+				assert.Nil(t, err)
+				assert.Equal(t, u.Depth()+v.Depth()-2*expected.Depth(), distance, name)
+
+				path, err := queries.Path(u, v)
+				assert.Nil(t, err)
+				assert.Len(t, path, distance+1)
+				assert.Equal(t, u, path[0])
+				assert.Equal(t, v, path[len(path)-1])
+				for k := 1; k < len(path); k++ {
+					assert.True(t, path[k].parent == path[k-1] || path[k-1].parent == path[k], name)
+				}
+
+				k := r.Intn(u.Depth() + 1)
+				kth, err := queries.KthAncestor(u, k)
+				assert.Nil(t, err)
+				depth, err := kth.DepthOf(u)
+				assert.Nil(t, err)
+				assert.Equal(t, k, depth, name)
+			}
+		}
+	}
+}
+
+func TestAncestorQueries_Deep(t *testing.T) {
+	const n = 10000
+	// build a path from the bottom up, with a second branch off the root
+	bottom := NewOrderedTree(n - 1)
+	top := bottom
+	for i := n - 2; i >= 0; i-- {
+		parent := NewOrderedTree(i)
+		assert.Nil(t, parent.AddChild(top))
+		top = parent
+	}
+	branch := NewOrderedTree(-1)
+	assert.Nil(t, top.AddChild(branch))
+
+	for name, newQueries := range ancestorQueries {
+		queries := newQueries(top)
+		ancestor, err := queries.LCA(bottom, branch)
+		assert.Nil(t, err)
+		assert.Equal(t, top, ancestor, name)
+		distance, err := queries.Distance(bottom, branch)
+		assert.Nil(t, err)
+		assert.Equal(t, n, distance, name)
+		kth, err := queries.KthAncestor(bottom, n/2)
+		assert.Nil(t, err)
+		assert.Equal(t, n/2-1, kth.Value(), name)
+	}
+}
@@ -0,0 +1,189 @@
+package trees
+
+// TreeMetrics describes the shape of a tree, all measured in a single post-order pass
+type TreeMetrics struct {
+	Size        int   // number of positions
+	Height      int   // number of levels, counting a single external position as height 1
+	Leaves      int   // number of external positions
+	MaxDegree   int   // most children of any one position
+	Diameter    int   // number of edges on the longest path between any two positions
+	LevelWidths []int // number of positions at each depth, starting with the root
+}
+
+// BinaryTreeMetrics adds the shape properties particular to binary trees to TreeMetrics
+type BinaryTreeMetrics struct {
+	TreeMetrics
+	Full     bool // every position has either zero or two children
+	Complete bool // every level is filled, except maybe the last, which is filled from the left
+	Perfect  bool // every level is filled
+	Balanced bool // the heights of the two subtrees of every position differ by at most 1
+}
+
+// count adds a position of the given depth and degree to the metrics
+func (m *TreeMetrics) count(depth, degree int) {
+	m.Size++
+	if depth == len(m.LevelWidths) {
+		m.LevelWidths = append(m.LevelWidths, 0)
+	}
+	m.LevelWidths[depth]++
+	m.MaxDegree = max(m.MaxDegree, degree)
+	if degree == 0 {
+		m.Leaves++
+	}
+}
+
+func (ot *OrderedTree[T]) Metrics() TreeMetrics {
+	var m TreeMetrics
+	m.Height = measureOrdered(ot, 0, &m)
+	return m
+}
+
+// measureOrdered adds a subtree to the metrics and returns its height.
+//
+// The longest path through a position runs down into its two tallest subtrees,
+// taking one edge per level of each, so the diameter is the largest sum of
+// the two greatest child heights found at any position.
+func measureOrdered[T any](ot *OrderedTree[T], depth int, m *TreeMetrics) int {
+	m.count(depth, len(ot.children))
+	tallest, secondTallest := 0, 0
+	for _, child := range ot.children {
+		height := measureOrdered(child, depth+1, m)
+		if height > tallest {
+			tallest, secondTallest = height, tallest
+		} else if height > secondTallest {
+			secondTallest = height
+		}
+	}
+	m.Diameter = max(m.Diameter, tallest+secondTallest)
+	return tallest + 1
+}
+
+func (ot *OrderedTree[T]) Size() int {
+	return ot.Metrics().Size
+}
+
+// Degree is the number of children of the position
+func (ot *OrderedTree[T]) Degree() int {
+	return len(ot.children)
+}
+
+func (ot *OrderedTree[T]) LeafCount() int {
+	return ot.Metrics().Leaves
+}
+
+func (ot *OrderedTree[T]) Diameter() int {
+	return ot.Metrics().Diameter
+}
+
+func (ot *OrderedTree[T]) LevelWidths() []int {
+	return ot.Metrics().LevelWidths
+}
+
+// DepthOf counts the edges from this position down to one of its descendants,
+// which is the depth of the descendant within the subtree rooted here
+func (ot *OrderedTree[T]) DepthOf(descendant *OrderedTree[T]) (int, error) {
+	depth := 0
+	for node := descendant; node != nil; node = node.parent {
+		if node == ot {
+			return depth, nil
+		}
+		depth++
+	}
+	return 0, NodeNotInTreeError{}
+}
+
+func (bt *BinaryTree[T]) Metrics() BinaryTreeMetrics {
+	m := BinaryTreeMetrics{Full: true, Balanced: true}
+	shape := measureBinary(bt, 0, &m)
+	m.Height, m.Complete, m.Perfect = shape.height, shape.complete, shape.perfect
+	return m
+}
+
+// binaryShape is what a parent needs to know about each subtree to work out its own shape
+type binaryShape struct {
+	height   int
+	perfect  bool
+	complete bool
+}
+
+// measureBinary adds a subtree to the metrics and returns its shape.
+//
+// A missing subtree counts as both perfect and complete, with height 0. A subtree is then
+// perfect when both of its subtrees are perfect with the same height, and complete when
+// either its left subtree is perfect and its right subtree complete at the same height,
+// with the last level ending on the right, or its left subtree is complete and its right
+// subtree perfect and one level shorter, with the last level ending on the left.
+func measureBinary[T any](bt *BinaryTree[T], depth int, m *BinaryTreeMetrics) binaryShape {
+	if bt == nil {
+		return binaryShape{height: 0, perfect: true, complete: true}
+	}
+	degree := len(binaryChildren(bt))
+	m.count(depth, degree)
+	if degree == 1 {
+		m.Full = false
+	}
+
+	left := measureBinary(bt.leftChild, depth+1, m)
+	right := measureBinary(bt.rightChild, depth+1, m)
+	if left.height-right.height > 1 || right.height-left.height > 1 {
+		m.Balanced = false
+	}
+	m.Diameter = max(m.Diameter, left.height+right.height)
+
+	return binaryShape{
+		height:  max(left.height, right.height) + 1,
+		perfect: left.perfect && right.perfect && left.height == right.height,
+		complete: (left.perfect && right.complete && left.height == right.height) ||
+			(left.complete && right.perfect && left.height == right.height+1),
+	}
+}
+
+func (bt *BinaryTree[T]) Size() int {
+	return bt.Metrics().Size
+}
+
+// Degree is the number of children of the position
+func (bt *BinaryTree[T]) Degree() int {
+	return len(binaryChildren(bt))
+}
+
+func (bt *BinaryTree[T]) LeafCount() int {
+	return bt.Metrics().Leaves
+}
+
+func (bt *BinaryTree[T]) Diameter() int {
+	return bt.Metrics().Diameter
+}
+
+func (bt *BinaryTree[T]) LevelWidths() []int {
+	return bt.Metrics().LevelWidths
+}
+
+func (bt *BinaryTree[T]) IsFull() bool {
+	return bt.Metrics().Full
+}
+
+func (bt *BinaryTree[T]) IsComplete() bool {
+	return bt.Metrics().Complete
+}
+
+func (bt *BinaryTree[T]) IsPerfect() bool {
+	return bt.Metrics().Perfect
+}
+
+func (bt *BinaryTree[T]) IsBalanced() bool {
+	return bt.Metrics().Balanced
+}
+
+// DepthOf counts the edges from this position down to one of its descendants,
+// which is the depth of the descendant within the subtree rooted here
+func (bt *BinaryTree[T]) DepthOf(descendant *BinaryTree[T]) (int, error) {
+	depth := 0
+	for node := descendant; node != nil; node = node.parent {
+		if node == bt {
+			return depth, nil
+		}
+		depth++
+	}
+	return 0, NodeNotInTreeError{}
+}
@@ -0,0 +1,186 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// naiveMetrics measures a tree one property at a time, with nothing shared between them
+func naiveMetrics[T any](t *testing.T, tree Tree[T]) TreeMetrics {
+	var nodes []Tree[T]
+	assert.Nil(t, TraversePreOrder(tree, func(node Tree[T]) error {
+		nodes = append(nodes, node)
+		return nil
+	}))
+
+	m := TreeMetrics{Size: len(nodes), Height: tree.Height()}
+	for _, node := range nodes {
+		if node.IsExternal() {
+			m.Leaves++
+		}
+		m.MaxDegree = max(m.MaxDegree, len(node.Children()))
+		depth := node.Depth() - tree.Depth()
+		for len(m.LevelWidths) <= depth {
+			m.LevelWidths = append(m.LevelWidths, 0)
+		}
+		m.LevelWidths[depth]++
+	}
+
+	// the distance between two positions runs up from each to their lowest common ancestor
+	for _, u := range nodes {
+		ancestors := map[Tree[T]]int{}
+		for a, d := u, 0; a != nil; a, d = a.Parent(), d+1 {
+			ancestors[a] = d
+		}
+		for _, v := range nodes {
+			for a, d := v, 0; a != nil; a, d = a.Parent(), d+1 {
+				if up, ok := ancestors[a]; ok {
+					m.Diameter = max(m.Diameter, up+d)
+					break
+				}
+			}
+		}
+	}
+	return m
+}
+
+func TestOrderedTree_Metrics(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newLetterTree()
+	m := tree.Metrics()
+	assert.Equal(TreeMetrics{
+		Size:        9,
+		Height:      4,
+		Leaves:      5,
+		MaxDegree:   3,
+		Diameter:    5,
+		LevelWidths: []int{1, 3, 3, 2},
+	}, m)
+	assert.Equal(m.Height, tree.Height())
+	assert.Equal(9, tree.Size())
+	assert.Equal(5, tree.LeafCount())
+	assert.Equal(5, tree.Diameter())
+	assert.Equal([]int{1, 3, 3, 2}, tree.LevelWidths())
+	assert.Equal(3, tree.Degree())
+
+	f := tree.ChildAt(0).ChildAt(1)
+	depth, err := tree.DepthOf(f.ChildAt(0))
+	assert.Nil(err)
+	assert.Equal(3, depth)
+	depth, err = f.DepthOf(f)
+	assert.Nil(err)
+	assert.Equal(0, depth)
+	_, err = f.DepthOf(tree)
+	assert.ErrorIs(err, NodeNotInTreeError{})
+
+	r := rand.New(rand.NewSource(37))
+	for i := 0; i < 100; i++ {
+		tree := RandomOrderedTree(r, 1+r.Intn(40), GeometricDegree(0.5))
+		assert.Equal(naiveMetrics[int](t, tree), tree.Metrics())
+	}
+}
+
+// naiveBinaryShape checks each binary tree property straight from its definition
+func naiveBinaryShape[T any](tree *BinaryTree[T]) (full, complete, perfect, balanced bool) {
+	full, balanced = true, true
+	size, maxIndex := 0, 0
+	var visit func(node *BinaryTree[T], index int)
+	visit = func(node *BinaryTree[T], index int) {
+		size++
+		maxIndex = max(maxIndex, index)
+		if (node.leftChild == nil) != (node.rightChild == nil) {
+			full = false
+		}
+		leftHeight, rightHeight := 0, 0
+		if node.leftChild != nil {
+			leftHeight = node.leftChild.Height()
+			visit(node.leftChild, 2*index+1)
+		}
+		if node.rightChild != nil {
+			rightHeight = node.rightChild.Height()
+			visit(node.rightChild, 2*index+2)
+		}
+		if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+			balanced = false
+		}
+	}
+	visit(tree, 0)
+	// numbering the positions like an array-based heap leaves no gaps exactly when the tree is complete
+	complete = maxIndex == size-1
+	perfect = size == 1<<tree.Height()-1
+	return full, complete, perfect, balanced
+}
+
+func TestBinaryTree_Metrics(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newNumberTree()
+	assert.Equal(BinaryTreeMetrics{
+		TreeMetrics: TreeMetrics{
+			Size:        8,
+			Height:      4,
+			Leaves:      3,
+			MaxDegree:   2,
+			Diameter:    6,
+			LevelWidths: []int{1, 2, 3, 2},
+		},
+	}, tree.Metrics())
+	assert.Equal(8, tree.Size())
+	assert.Equal(3, tree.LeafCount())
+	assert.Equal(6, tree.Diameter())
+	assert.Equal([]int{1, 2, 3, 2}, tree.LevelWidths())
+	assert.Equal(2, tree.Degree())
+	assert.Equal(1, tree.RightChild().Degree())
+
+	depth, err := tree.DepthOf(tree.LeftChild().RightChild())
+	assert.Nil(err)
+	assert.Equal(2, depth)
+	_, err = tree.LeftChild().DepthOf(tree.RightChild())
+	assert.ErrorIs(err, NodeNotInTreeError{})
+
+	shapes := []struct {
+		tree                              string
+		full, complete, perfect, balanced bool
+	}{
+		{"1", true, true, true, true},
+		{"1(2,)", false, true, false, true},
+		{"1(,2)", false, false, false, true},
+		{"1(2,3)", true, true, true, true},
+		{"1(2(4,5),3)", true, true, false, true},
+		{"1(2(4,),3)", false, true, false, true},
+		{"1(2,3(4,5))", true, false, false, true},
+		{"1(2(4,5),3(6,7))", true, true, true, true},
+		{"1(2(4(8,),5),3(6,7))", false, true, false, true},
+		{"1(2(4,5),3(,7))", false, false, false, true},
+		{"1(2(4(8,9),5),3)", true, false, false, false},
+		{"1(2(4,),)", false, false, false, false},
+	}
+	for _, shape := range shapes {
+		tree, err := ParseBinaryTree(shape.tree, parseString)
+		assert.Nil(err)
+		m := tree.Metrics()
+		assert.Equal(shape.full, m.Full, shape.tree)
+		assert.Equal(shape.complete, m.Complete, shape.tree)
+		assert.Equal(shape.perfect, m.Perfect, shape.tree)
+		assert.Equal(shape.balanced, m.Balanced, shape.tree)
+		assert.Equal(m.Full, tree.IsFull())
+		assert.Equal(m.Complete, tree.IsComplete())
+		assert.Equal(m.Perfect, tree.IsPerfect())
+		assert.Equal(m.Balanced, tree.IsBalanced())
+	}
+
+	r := rand.New(rand.NewSource(37))
+	for i := 0; i < 200; i++ {
+		tree := RandomBinaryTree(r, 1+r.Intn(20))
+		m := tree.Metrics()
+		assert.Equal(naiveMetrics[int](t, tree), m.TreeMetrics)
+		full, complete, perfect, balanced := naiveBinaryShape(tree)
+		assert.Equal(full, m.Full)
+		assert.Equal(complete, m.Complete)
+		assert.Equal(perfect, m.Perfect)
+		assert.Equal(balanced, m.Balanced)
+	}
+}
@@ -1,20 +1,114 @@
 package trees
 
-type OrderedTree struct {
-	value    any
-	children []*OrderedTree
+type OrderedTree[T any] struct {
+	value    T
+	parent   *OrderedTree[T]
+	children []*OrderedTree[T]
 }
-type OrderedTreeVisit func(tree *OrderedTree) error
+type OrderedTreeVisit[T any] func(tree *OrderedTree[T]) error
 
-func (ot *OrderedTree) Value() any {
+func NewOrderedTree[T any](v T) *OrderedTree[T] {
+	return &OrderedTree[T]{value: v}
+}
+
+func (ot *OrderedTree[T]) Value() T {
 	return ot.value
 }
 
-func (ot *OrderedTree) Children() []*OrderedTree {
-	return ot.children
+func (ot *OrderedTree[T]) SetValue(v T) {
+	ot.value = v
+}
+
+func (ot *OrderedTree[T]) Root() Tree[T] {
+	return ot.root()
+}
+
+func (ot *OrderedTree[T]) Parent() Tree[T] {
+	if ot.parent == nil {
+		return nil
+	}
+	return ot.parent
+}
+
+func (ot *OrderedTree[T]) Children() []Tree[T] {
+	children := make([]Tree[T], len(ot.children))
+	for i, child := range ot.children {
+		children[i] = child
+	}
+	return children
+}
+
+// ChildAt returns the child at index i, or nil if there is no such child
+func (ot *OrderedTree[T]) ChildAt(i int) *OrderedTree[T] {
+	if i < 0 || i >= len(ot.children) {
+		return nil
+	}
+	return ot.children[i]
+}
+
+func (ot *OrderedTree[T]) IsExternal() bool {
+	return len(ot.children) == 0
+}
+
+func (ot *OrderedTree[T]) Depth() int {
+	depth := 0
+	for ancestor := ot.parent; ancestor != nil; ancestor = ancestor.parent {
+		depth++
+	}
+	return depth
 }
 
-func (ot *OrderedTree) Height() int {
+// AddChild attaches a tree as the last child of this tree
+func (ot *OrderedTree[T]) AddChild(child *OrderedTree[T]) error {
+	return ot.InsertChildAt(len(ot.children), child)
+}
+
+// InsertChildAt attaches a tree as the child at index i, shifting later children to the right.
+//
+// The child must be the root of its own separate tree: it cannot already have a parent,
+// and it cannot be the root of this tree, which would create a cycle.
+func (ot *OrderedTree[T]) InsertChildAt(i int, child *OrderedTree[T]) error {
+	if i < 0 || i > len(ot.children) {
+		return ChildIndexOutOfRangeError{}
+	}
+	if child == nil {
+		return NilTreeError{}
+	}
+	if child.parent != nil {
+		return TreeHasParentError{}
+	}
+	if ot.root() == child {
+		return TreeCycleError{}
+	}
+
+	ot.children = append(ot.children, nil)
+	copy(ot.children[i+1:], ot.children[i:])
+	ot.children[i] = child
+	child.parent = ot
+	return nil
+}
+
+// RemoveChild detaches a child from this tree, leaving it as the root of its own tree
+func (ot *OrderedTree[T]) RemoveChild(child *OrderedTree[T]) error {
+	for i, c := range ot.children {
+		if c == child {
+			ot.children = append(ot.children[:i], ot.children[i+1:]...)
+			child.parent = nil
+			return nil
+		}
+	}
+	return ChildNotFoundError{}
+}
+
+func (ot *OrderedTree[T]) root() *OrderedTree[T] {
+	root := ot
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+func (ot *OrderedTree[T]) Height() int {
 	if len(ot.children) == 0 {
 		return 1
 	}
@@ -28,34 +122,26 @@ func (ot *OrderedTree) Height() int {
 	return maxHeight + 1
 }
 
-func (ot *OrderedTree) TraversePreOrder(visit OrderedTreeVisit) error {
-	if visit != nil {
-		err := visit(ot)
-		if err != nil {
-			return err
-		}
-	}
-	for _, child := range ot.Children() {
-		err := child.TraversePreOrder(visit)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+func (ot *OrderedTree[T]) TraversePreOrder(visit OrderedTreeVisit[T]) error {
+	return ot.WalkPreOrder(orderedWalk(visit))
 }
 
-func (ot *OrderedTree) TraversePostOrder(visit OrderedTreeVisit) error {
-	for _, child := range ot.Children() {
-		err := child.TraversePostOrder(visit)
-		if err != nil {
-			return err
-		}
+func (ot *OrderedTree[T]) TraversePostOrder(visit OrderedTreeVisit[T]) error {
+	return ot.WalkPostOrder(orderedWalk(visit))
+}
+
+// TraverseEuler visits each position before its children, after coming back up from each
+// of its children, and after all of its children, following an Euler tour around the tree
+func (ot *OrderedTree[T]) TraverseEuler(preVisit, returnVisit, postVisit OrderedTreeVisit[T]) error {
+	return ot.WalkEuler(orderedWalk(preVisit), orderedWalk(returnVisit), orderedWalk(postVisit))
+}
+
+// orderedWalk adapts a visitor which doesn't care about depth or path into a walker
+func orderedWalk[T any](visit OrderedTreeVisit[T]) OrderedTreeWalkFunc[T] {
+	if visit == nil {
+		return nil
 	}
-	if visit != nil {
-		err := visit(ot)
-		if err != nil {
-			return err
-		}
+	return func(tree *OrderedTree[T], _ int, _ []*OrderedTree[T]) error {
+		return visit(tree)
 	}
-	return nil
 }
@@ -0,0 +1,88 @@
+package trees
+
+import "iter"
+
+func orderedChildren[T any](ot *OrderedTree[T]) []*OrderedTree[T] {
+	return ot.children
+}
+
+// All iterates over the positions of the tree in pre-order
+func (ot *OrderedTree[T]) All() iter.Seq[*OrderedTree[T]] {
+	return ot.PreOrderCursor().All()
+}
+
+func (ot *OrderedTree[T]) PreOrderCursor() *Cursor[*OrderedTree[T]] {
+	return preOrderCursor(ot, orderedChildren[T])
+}
+
+func (ot *OrderedTree[T]) PostOrderCursor() *Cursor[*OrderedTree[T]] {
+	return postOrderCursor(ot, orderedChildren[T])
+}
+
+// InOrderCursor generalizes in-order traversal to trees with any number of children:
+// each position is visited after the subtree of its first child,
+// but before the subtrees of the rest of its children
+func (ot *OrderedTree[T]) InOrderCursor() *Cursor[*OrderedTree[T]] {
+	type frame struct {
+		node    *OrderedTree[T]
+		visited bool
+		next    int // index of the next child to descend into
+	}
+	stack := newGrowingStack()
+	stack.push(&frame{node: ot})
+	return &Cursor[*OrderedTree[T]]{next: func() (*OrderedTree[T], bool) {
+		for {
+			v, ok := stack.pop()
+			if !ok {
+				return nil, false
+			}
+			f := v.(*frame)
+			switch {
+			case f.next == 0 && len(f.node.children) > 0:
+				// descend into the first child before visiting the node
+				f.next++
+				stack.push(f)
+				stack.push(&frame{node: f.node.children[0]})
+			case !f.visited:
+				f.visited = true
+				if f.next < len(f.node.children) {
+					stack.push(f)
+				}
+				return f.node, true
+			case f.next < len(f.node.children):
+				child := f.node.children[f.next]
+				f.next++
+				stack.push(f)
+				stack.push(&frame{node: child})
+			}
+		}
+	}}
+}
+
+func (ot *OrderedTree[T]) LevelOrderCursor() *Cursor[*OrderedTree[T]] {
+	return levelOrderCursor(ot, orderedChildren[T])
+}
+
+func (ot *OrderedTree[T]) ReverseLevelOrderCursor() *Cursor[*OrderedTree[T]] {
+	return reverseLevelOrderCursor(ot, orderedChildren[T])
+}
+
+func (ot *OrderedTree[T]) ZigZagLevelOrderCursor() *Cursor[*OrderedTree[T]] {
+	return zigZagLevelOrderCursor(ot, orderedChildren[T])
+}
+
+func (ot *OrderedTree[T]) TraverseInOrder(visit OrderedTreeVisit[T]) error {
+	return traverse(ot.InOrderCursor(), visit)
+}
+
+func (ot *OrderedTree[T]) TraverseLevelOrder(visit OrderedTreeVisit[T]) error {
+	return traverse(ot.LevelOrderCursor(), visit)
+}
+
+func (ot *OrderedTree[T]) TraverseReverseLevelOrder(visit OrderedTreeVisit[T]) error {
+	return traverse(ot.ReverseLevelOrderCursor(), visit)
+}
+
+func (ot *OrderedTree[T]) TraverseZigZagLevelOrder(visit OrderedTreeVisit[T]) error {
+	return traverse(ot.ZigZagLevelOrderCursor(), visit)
+}
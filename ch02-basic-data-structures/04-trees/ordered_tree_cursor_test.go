@@ -0,0 +1,125 @@
+package trees
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newLetterTree builds the ordered tree
+//
+//	     A
+//	   / | \
+//	  B  C  D
+//	 / \    |
+//	E   F   G
+//	   / \
+//	  H   I
+func newLetterTree() *OrderedTree[string] {
+	nodes := map[string]*OrderedTree[string]{}
+	for _, v := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"} {
+		nodes[v] = NewOrderedTree(v)
+	}
+	edges := [][2]string{{"A", "B"}, {"A", "C"}, {"A", "D"}, {"B", "E"}, {"B", "F"}, {"D", "G"}, {"F", "H"}, {"F", "I"}}
+	for _, edge := range edges {
+		_ = nodes[edge[0]].AddChild(nodes[edge[1]])
+	}
+	return nodes["A"]
+}
+
+func TestOrderedTree_Cursors(t *testing.T) {
+	tree := newLetterTree()
+
+	tests := []struct {
+		name     string
+		cursor   *Cursor[*OrderedTree[string]]
+		expected []string
+	}{
+		{"pre-order", tree.PreOrderCursor(), []string{"A", "B", "E", "F", "H", "I", "C", "D", "G"}},
+		{"in-order", tree.InOrderCursor(), []string{"E", "B", "H", "F", "I", "A", "C", "G", "D"}},
+		{"post-order", tree.PostOrderCursor(), []string{"E", "H", "I", "F", "B", "C", "G", "D", "A"}},
+		{"level-order", tree.LevelOrderCursor(), []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}},
+		{"reverse level-order", tree.ReverseLevelOrderCursor(), []string{"H", "I", "E", "F", "G", "B", "C", "D", "A"}},
+		{"zig-zag level-order", tree.ZigZagLevelOrderCursor(), []string{"A", "D", "C", "B", "E", "F", "G", "I", "H"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, collect[*OrderedTree[string], string](test.cursor))
+			// an exhausted cursor stays exhausted
+			_, ok := test.cursor.Next()
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("All is pre-order", func(t *testing.T) {
+		var values []string
+		for node := range tree.All() {
+			values = append(values, node.Value())
+		}
+		assert.Equal(t, tests[0].expected, values)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		var values []string
+		for node := range tree.LevelOrderCursor().All() {
+			if node.Value() == "D" {
+				break
+			}
+			values = append(values, node.Value())
+		}
+		assert.Equal(t, []string{"A", "B", "C"}, values)
+
+		cursor := tree.InOrderCursor()
+		first, ok := cursor.Next()
+		assert.True(t, ok)
+		assert.Equal(t, "E", first.Value())
+	})
+}
+
+func TestOrderedTree_TraverseLevels(t *testing.T) {
+	tree := newLetterTree()
+	var values []string
+	visit := func(tree *OrderedTree[string]) error {
+		values = append(values, tree.Value())
+		return nil
+	}
+
+	assert.Nil(t, tree.TraverseInOrder(visit))
+	assert.Nil(t, tree.TraverseLevelOrder(visit))
+	assert.Nil(t, tree.TraverseReverseLevelOrder(visit))
+	assert.Nil(t, tree.TraverseZigZagLevelOrder(visit))
+	assert.Len(t, values, 4*9)
+
+	errStop := errors.New("stop")
+	err := tree.TraverseLevelOrder(func(tree *OrderedTree[string]) error {
+		if tree.Value() == "C" {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+}
+
+func TestOrderedTree_DeepCursors(t *testing.T) {
+	// a path of nodes far deeper than the chapter's stacks and queues hold by default
+	// built from the bottom up, so attaching each child doesn't walk up a long path
+	// to check for cycles
+	const depth = 100000
+	root := NewOrderedTree(depth - 1)
+	for i := depth - 2; i >= 0; i-- {
+		parent := NewOrderedTree(i)
+		_ = parent.AddChild(root)
+		root = parent
+	}
+
+	for _, cursor := range []*Cursor[*OrderedTree[int]]{
+		root.PreOrderCursor(), root.InOrderCursor(), root.PostOrderCursor(), root.ReverseLevelOrderCursor(),
+	} {
+		count := 0
+		for range cursor.All() {
+			count++
+		}
+		assert.Equal(t, depth, count)
+	}
+}
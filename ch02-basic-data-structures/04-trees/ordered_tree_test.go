@@ -8,38 +8,19 @@ import (
 )
 
 func TestOrderedTree_TraversePreOrder(t *testing.T) {
-	var tableOfContentsTree = &OrderedTree{
-		value: nil,
-		children: []*OrderedTree{
-			{
-				value: "Chapter 1",
-				children: []*OrderedTree{
-					{
-						value:    "1.1",
-						children: nil,
-					},
-					{
-						value:    "1.2",
-						children: nil,
-					},
-				},
-			},
-			{
-				value: "Chapter 2",
-				children: []*OrderedTree{
-					{
-						value:    "2.1",
-						children: nil,
-					},
-				},
-			},
-		},
-	}
+	// the root of the table of contents has no title, so its empty text parses to nil
+	tableOfContentsTree, err := ParseOrderedTree("(Chapter 1(1.1,1.2),Chapter 2(2.1))", func(s string) (any, error) {
+		if s == "" {
+			return nil, nil
+		}
+		return s, nil
+	})
+	assert.Nil(t, err)
 
 	assert.Equal(t, tableOfContentsTree.Height(), 3)
 
 	var tableOfContents []string
-	visit := func(tree *OrderedTree) error {
+	visit := func(tree *OrderedTree[any]) error {
 		val := tree.Value()
 		if val == nil {
 			return nil
@@ -52,7 +33,7 @@ func TestOrderedTree_TraversePreOrder(t *testing.T) {
 		return nil
 	}
 
-	err := tableOfContentsTree.TraversePreOrder(visit)
+	err = tableOfContentsTree.TraversePreOrder(visit)
 	assert.Nil(t, err)
 
 	expectedTableOfContents := []string{"Chapter 1", "1.1", "1.2", "Chapter 2", "2.1"}
@@ -60,57 +41,65 @@ func TestOrderedTree_TraversePreOrder(t *testing.T) {
 }
 
 func TestOrderedTree_TraversePostOrder(t *testing.T) {
-	var reversePolishNotationTree = &OrderedTree{
-		value: "-",
-		children: []*OrderedTree{
-			{
-				value: "*",
-				children: []*OrderedTree{
-					{
-						value: "+",
-						children: []*OrderedTree{
-							{
-								value:    "2",
-								children: nil,
-							},
-							{
-								value:    "3",
-								children: nil,
-							},
-						},
-					},
-					{
-						value:    "y",
-						children: nil,
-					},
-				},
-			},
-			{
-				value:    "2",
-				children: nil,
-			},
-		},
-	}
+	reversePolishNotationTree, err := ParseOrderedTree("-(*(+(2,3),y),2)", parseString)
+	assert.Nil(t, err)
 
 	assert.Equal(t, reversePolishNotationTree.Height(), 4)
 
 	var reversePolishNotation []string
-	visit := func(tree *OrderedTree) error {
-		val := tree.Value()
-		if val == nil {
-			return nil
-		}
-		str, ok := val.(string)
-		if !ok {
-			return errors.New("tree value is not a string")
-		}
-		reversePolishNotation = append(reversePolishNotation, str)
+	visit := func(tree *OrderedTree[string]) error {
+		reversePolishNotation = append(reversePolishNotation, tree.Value())
 		return nil
 	}
 
-	err := reversePolishNotationTree.TraversePostOrder(visit)
+	err = reversePolishNotationTree.TraversePostOrder(visit)
 	assert.Nil(t, err)
 
 	expectedReversePolishNotation := []string{"2", "3", "+", "y", "*", "2", "-"}
 	assert.Equal(t, expectedReversePolishNotation, reversePolishNotation)
 }
+
+func TestOrderedTree_Mutation(t *testing.T) {
+	root := NewOrderedTree("root")
+	a, b, c := NewOrderedTree("a"), NewOrderedTree("b"), NewOrderedTree("c")
+
+	assert.Nil(t, root.AddChild(a))
+	assert.Nil(t, root.AddChild(c))
+	assert.Nil(t, root.InsertChildAt(1, b))
+	assert.Equal(t, []Tree[string]{a, b, c}, root.Children())
+	for _, child := range root.Children() {
+		assert.Equal(t, root, child.Parent())
+	}
+	assert.Nil(t, root.Parent())
+
+	t.Run("invariant checks", func(t *testing.T) {
+		grandchild := NewOrderedTree("grandchild")
+		assert.Nil(t, a.AddChild(grandchild))
+
+		// a node cannot have two parents
+		assert.ErrorIs(t, b.AddChild(grandchild), TreeHasParentError{})
+		assert.ErrorIs(t, b.AddChild(a), TreeHasParentError{})
+		// a tree cannot be added below itself
+		assert.ErrorIs(t, grandchild.AddChild(root), TreeCycleError{})
+		assert.ErrorIs(t, root.AddChild(root), TreeCycleError{})
+
+		assert.ErrorIs(t, root.InsertChildAt(4, NewOrderedTree("d")), ChildIndexOutOfRangeError{})
+		assert.ErrorIs(t, root.InsertChildAt(-1, NewOrderedTree("d")), ChildIndexOutOfRangeError{})
+		assert.ErrorIs(t, root.AddChild(nil), NilTreeError{})
+		assert.ErrorIs(t, root.RemoveChild(grandchild), ChildNotFoundError{})
+		assert.Equal(t, 3, root.Height())
+	})
+
+	t.Run("remove and re-attach", func(t *testing.T) {
+		assert.Nil(t, root.RemoveChild(b))
+		assert.Nil(t, b.Parent())
+		assert.Equal(t, []Tree[string]{a, c}, root.Children())
+
+		// once removed, the subtree is free to be attached elsewhere
+		assert.Nil(t, c.AddChild(b))
+		assert.Equal(t, c, b.Parent())
+
+		b.SetValue("moved")
+		assert.Equal(t, "moved", c.Children()[0].Value())
+	})
+}
@@ -0,0 +1,192 @@
+package trees
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parenthetic returns the parenthetic string representation of the tree, in which
+// each position is written as its value, followed by the representations of its children
+// separated by commas and wrapped in parentheses, such as A(B(D,E),C).
+//
+// Values are written with fmt.Sprint and are not escaped in any way,
+// so values containing parentheses or commas cannot be parsed back.
+func (ot *OrderedTree[T]) Parenthetic() string {
+	var sb strings.Builder
+	ot.writeParenthetic(&sb)
+	return sb.String()
+}
+
+func (ot *OrderedTree[T]) writeParenthetic(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprint(ot.value))
+	if len(ot.children) == 0 {
+		return
+	}
+	sb.WriteByte('(')
+	for i, child := range ot.children {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		child.writeParenthetic(sb)
+	}
+	sb.WriteByte(')')
+}
+
+// Parenthetic returns the parenthetic string representation of the binary tree.
+//
+// An internal position always lists both of its child slots, leaving a missing child
+// empty, so a position with only a right child is written as A(,C).
+// Because of that, a child can't have a value which is written as an empty string.
+func (bt *BinaryTree[T]) Parenthetic() string {
+	var sb strings.Builder
+	bt.writeParenthetic(&sb)
+	return sb.String()
+}
+
+func (bt *BinaryTree[T]) writeParenthetic(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprint(bt.value))
+	if bt.leftChild == nil && bt.rightChild == nil {
+		return
+	}
+	sb.WriteByte('(')
+	if bt.leftChild != nil {
+		bt.leftChild.writeParenthetic(sb)
+	}
+	sb.WriteByte(',')
+	if bt.rightChild != nil {
+		bt.rightChild.writeParenthetic(sb)
+	}
+	sb.WriteByte(')')
+}
+
+// ParseOrderedTree builds an OrderedTree from its parenthetic string representation,
+// using parse to convert the text of each value back into a T
+func ParseOrderedTree[T any](s string, parse func(string) (T, error)) (*OrderedTree[T], error) {
+	p := &parentheticParser{s: s}
+	tree, err := parseOrderedTree(p, parse)
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, ParentheticSyntaxError{}
+	}
+	return tree, nil
+}
+
+func parseOrderedTree[T any](p *parentheticParser, parse func(string) (T, error)) (*OrderedTree[T], error) {
+	value, err := parse(p.token())
+	if err != nil {
+		return nil, err
+	}
+	tree := NewOrderedTree(value)
+	if !p.accept('(') {
+		return tree, nil
+	}
+	for {
+		child, err := parseOrderedTree(p, parse)
+		if err != nil {
+			return nil, err
+		}
+		child.parent = tree
+		tree.children = append(tree.children, child)
+
+		if p.accept(')') {
+			return tree, nil
+		}
+		if !p.accept(',') {
+			return nil, ParentheticSyntaxError{}
+		}
+	}
+}
+
+// ParseBinaryTree builds a BinaryTree from its parenthetic string representation,
+// using parse to convert the text of each value back into a T
+func ParseBinaryTree[T any](s string, parse func(string) (T, error)) (*BinaryTree[T], error) {
+	p := &parentheticParser{s: s}
+	tree, err := parseBinaryTree(p, parse)
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, ParentheticSyntaxError{}
+	}
+	return tree, nil
+}
+
+func parseBinaryTree[T any](p *parentheticParser, parse func(string) (T, error)) (*BinaryTree[T], error) {
+	value, err := parse(p.token())
+	if err != nil {
+		return nil, err
+	}
+	tree := NewBinaryTree(value)
+	if !p.accept('(') {
+		return tree, nil
+	}
+
+	left, err := parseBinaryChild(p, parse)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept(',') {
+		return nil, ParentheticSyntaxError{}
+	}
+	right, err := parseBinaryChild(p, parse)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept(')') {
+		return nil, ParentheticSyntaxError{}
+	}
+	if left == nil && right == nil {
+		// A(,) would be written back out as just A
+		return nil, ParentheticSyntaxError{}
+	}
+
+	tree.leftChild, tree.rightChild = left, right
+	for _, child := range binaryChildren(tree) {
+		child.parent = tree
+	}
+	return tree, nil
+}
+
+// parseBinaryChild parses a child slot, which is either empty or a whole subtree
+func parseBinaryChild[T any](p *parentheticParser, parse func(string) (T, error)) (*BinaryTree[T], error) {
+	if p.peek(',') || p.peek(')') {
+		return nil, nil
+	}
+	return parseBinaryTree(p, parse)
+}
+
+// parentheticParser reads through a parenthetic string representation one token at a time
+type parentheticParser struct {
+	s   string
+	pos int
+}
+
+// token consumes the text of a value, which runs up to the next parenthesis or comma
+func (p *parentheticParser) token() string {
+	end := strings.IndexAny(p.s[p.pos:], "(),")
+	if end < 0 {
+		end = len(p.s) - p.pos
+	}
+	token := p.s[p.pos : p.pos+end]
+	p.pos += end
+	return token
+}
+
+func (p *parentheticParser) peek(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+// accept consumes c if it is the next character
+func (p *parentheticParser) accept(c byte) bool {
+	if !p.peek(c) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *parentheticParser) done() bool {
+	return p.pos == len(p.s)
+}
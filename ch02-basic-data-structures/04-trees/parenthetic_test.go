@@ -0,0 +1,70 @@
+package trees
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func TestOrderedTree_Parenthetic(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newLetterTree()
+	s := tree.Parenthetic()
+	assert.Equal("A(B(E,F(H,I)),C,D(G))", s)
+
+	parsed, err := ParseOrderedTree(s, parseString)
+	assert.Nil(err)
+	assert.Equal(s, parsed.Parenthetic())
+	assert.Equal(collect(tree.PreOrderCursor()), collect(parsed.PreOrderCursor()))
+	for node := range parsed.All() {
+		for _, child := range node.children {
+			assert.Equal(node, child.parent)
+		}
+	}
+
+	numbers, err := ParseOrderedTree("10(20,30(40))", strconv.Atoi)
+	assert.Nil(err)
+	assert.Equal([]int{10, 20, 30, 40}, collect(numbers.PreOrderCursor()))
+
+	single, err := ParseOrderedTree("A", parseString)
+	assert.Nil(err)
+	assert.True(single.IsExternal())
+
+	_, err = ParseOrderedTree("1(x)", strconv.Atoi)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+
+	for _, invalid := range []string{"A(B", "A(B,C", "A(B)C", "A)", "A(B))", "A(B(C)"} {
+		_, err = ParseOrderedTree(invalid, parseString)
+		assert.ErrorIs(err, ParentheticSyntaxError{}, invalid)
+	}
+}
+
+func TestBinaryTree_Parenthetic(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newNumberTree()
+	s := tree.Parenthetic()
+	assert.Equal("1(2(4,5(7,)),3(,6(8,)))", s)
+
+	parsed, err := ParseBinaryTree(s, strconv.Atoi)
+	assert.Nil(err)
+	assert.Equal(s, parsed.Parenthetic())
+	assert.Equal(collect(tree.InOrderCursor()), collect(parsed.InOrderCursor()))
+	assert.Equal(collect(tree.PreOrderCursor()), collect(parsed.PreOrderCursor()))
+	for node := range parsed.All() {
+		for _, child := range binaryChildren(node) {
+			assert.Equal(node, child.parent)
+		}
+	}
+
+	for _, invalid := range []string{"A(B)", "A(,)", "A(B,C,D)", "A(B,C", "A(B,C)D", "A(,C))"} {
+		_, err = ParseBinaryTree(invalid, parseString)
+		assert.ErrorIs(err, ParentheticSyntaxError{}, invalid)
+	}
+}
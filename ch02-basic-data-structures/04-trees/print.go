@@ -0,0 +1,400 @@
+package trees
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultPrintWidth is the number of characters a printed line is cut off at
+// when no other width is given
+const DefaultPrintWidth = 120
+
+// printOptions limit how much of a tree gets printed; a limit of 0 means no limit
+type printOptions struct {
+	width    int // characters per line
+	depth    int // depth of the deepest positions printed
+	children int // children printed per position
+}
+
+type PrintOpt func(opts *printOptions)
+
+// WithPrintWidth cuts off printed lines at the given number of characters, replacing
+// the end of any longer line with an ellipsis; 0 prints lines of any width
+func WithPrintWidth(width int) PrintOpt {
+	return func(opts *printOptions) {
+		opts.width = width
+	}
+}
+
+// WithPrintDepth stops printing at positions of the given depth,
+// replacing any of their children with an ellipsis; 0 prints the whole tree
+func WithPrintDepth(depth int) PrintOpt {
+	return func(opts *printOptions) {
+		opts.depth = depth
+	}
+}
+
+// WithPrintChildren prints only the given number of children of each position,
+// followed by a count of how many more were left out; 0 prints all the children
+func WithPrintChildren(children int) PrintOpt {
+	return func(opts *printOptions) {
+		opts.children = children
+	}
+}
+
+func newPrintOptions(opts []PrintOpt) printOptions {
+	options := printOptions{width: DefaultPrintWidth}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// String draws the tree as an indented outline, like the output of the tree command
+func (ot *OrderedTree[T]) String() string {
+	if ot == nil {
+		return "<nil>"
+	}
+	var sb strings.Builder
+	_ = ot.Fprint(&sb)
+	return sb.String()
+}
+
+// Fprint draws the tree to w as an indented outline:
+//
+//	A
+//	├── B
+//	│   ├── E
+//	│   └── F
+//	└── C
+func (ot *OrderedTree[T]) Fprint(w io.Writer, opts ...PrintOpt) error {
+	options := newPrintOptions(opts)
+	return fprintOutline(w, orderedPrintable(ot, 0, options), options)
+}
+
+// FprintSideways draws the tree to w rotated a quarter turn, with the root on the left
+// and the children of each position fanning out to the right, the last child on top:
+//
+//	┌── C
+//	A
+//	│   ┌── F
+//	└── B
+//	    └── E
+func (ot *OrderedTree[T]) FprintSideways(w io.Writer, opts ...PrintOpt) error {
+	options := newPrintOptions(opts)
+	return fprintSideways(w, orderedPrintable(ot, 0, options), options)
+}
+
+// String draws the binary tree as an indented outline, like the output of the tree command
+func (bt *BinaryTree[T]) String() string {
+	if bt == nil {
+		return "<nil>"
+	}
+	var sb strings.Builder
+	_ = bt.Fprint(&sb)
+	return sb.String()
+}
+
+// Fprint draws the binary tree to w as an indented outline, left child first,
+// with ∅ standing in for the missing child of a position with only one child:
+//
+//	1
+//	├── 2
+//	│   ├── ∅
+//	│   └── 4
+//	└── 3
+func (bt *BinaryTree[T]) Fprint(w io.Writer, opts ...PrintOpt) error {
+	options := newPrintOptions(opts)
+	return fprintOutline(w, binaryPrintable(bt, 0, options), options)
+}
+
+// FprintSideways draws the binary tree to w rotated a quarter turn,
+// with the root on the left, the right subtree above it and the left subtree below it:
+//
+//	┌── 3
+//	1
+//	│   ┌── 4
+//	└── 2
+func (bt *BinaryTree[T]) FprintSideways(w io.Writer, opts ...PrintOpt) error {
+	options := newPrintOptions(opts)
+	return fprintSideways(w, binaryPrintable(bt, 0, options), options)
+}
+
+// FprintTopDown draws the binary tree to w the way it is drawn in a textbook,
+// with the root at the top and slashes connecting each position to its children:
+//
+//	 _1
+//	/  \
+//	2  3
+//	 \
+//	 4
+//
+// Wide trees get very wide very quickly, so any part of the drawing
+// past the width limit is cut off, and never drawn in the first place.
+func (bt *BinaryTree[T]) FprintTopDown(w io.Writer, opts ...PrintOpt) error {
+	options := newPrintOptions(opts)
+	box := topDownLayout(binaryPrintable(bt, 0, options))
+	canvas := &topDownCanvas{lines: make([][]rune, box.height), width: options.width}
+	canvas.paint(box, 0, 0)
+
+	lw := &lineWriter{w: w, width: options.width}
+	for _, line := range canvas.lines {
+		lw.writeLine(strings.TrimRight(string(line), " "))
+	}
+	return lw.err
+}
+
+// printable is a position of a tree as seen by the renderers, after truncation.
+// A nil child stands in for the missing child of a binary tree position.
+type printable struct {
+	label    string
+	children []*printable
+}
+
+func orderedPrintable[T any](ot *OrderedTree[T], depth int, options printOptions) *printable {
+	p := &printable{label: printLabel(ot.value)}
+	if len(ot.children) == 0 {
+		return p
+	}
+	if options.depth > 0 && depth == options.depth {
+		p.children = []*printable{{label: "…"}}
+		return p
+	}
+	for i, child := range ot.children {
+		if options.children > 0 && i == options.children {
+			p.children = append(p.children, &printable{label: fmt.Sprintf("… %d more", len(ot.children)-i)})
+			break
+		}
+		p.children = append(p.children, orderedPrintable(child, depth+1, options))
+	}
+	return p
+}
+
+func binaryPrintable[T any](bt *BinaryTree[T], depth int, options printOptions) *printable {
+	p := &printable{label: printLabel(bt.value)}
+	if bt.leftChild == nil && bt.rightChild == nil {
+		return p
+	}
+	for _, child := range []*BinaryTree[T]{bt.leftChild, bt.rightChild} {
+		switch {
+		case child == nil:
+			p.children = append(p.children, nil)
+		case options.depth > 0 && depth == options.depth:
+			p.children = append(p.children, &printable{label: "…"})
+		default:
+			p.children = append(p.children, binaryPrintable(child, depth+1, options))
+		}
+	}
+	return p
+}
+
+// printLabel writes a value with fmt.Sprint, so any fmt.Stringer prints with its String method,
+// keeping it on a single line
+func printLabel(value any) string {
+	return strings.ReplaceAll(fmt.Sprint(value), "\n", `\n`)
+}
+
+func fprintOutline(w io.Writer, root *printable, options printOptions) error {
+	lw := &lineWriter{w: w, width: options.width}
+	lw.writeLine(root.label)
+	writeOutline(lw, root, "")
+	return lw.err
+}
+
+func writeOutline(lw *lineWriter, p *printable, prefix string) {
+	for i, child := range p.children {
+		connector, indent := "├── ", "│   "
+		if i == len(p.children)-1 {
+			connector, indent = "└── ", "    "
+		}
+		if child == nil {
+			lw.writeLine(prefix + connector + "∅")
+			continue
+		}
+		lw.writeLine(prefix + connector + child.label)
+		writeOutline(lw, child, prefix+indent)
+	}
+}
+
+func fprintSideways(w io.Writer, root *printable, options printOptions) error {
+	lw := &lineWriter{w: w, width: options.width}
+	writeSideways(lw, root, "", "", "")
+	return lw.err
+}
+
+// writeSideways draws the children in the second half of the list above the position and
+// those in the first half below it, each group in reverse so that the first child ends up
+// at the bottom. The vertical line joining a group of children to their parent runs from
+// the parent's line to the connector of the child farthest away from it, so each line of
+// a child's subtree is prefixed with the line if it falls between the two.
+func writeSideways(lw *lineWriter, p *printable, abovePrefix, linePrefix, belowPrefix string) {
+	below, above := presentChildren(p.children[:len(p.children)/2]), presentChildren(p.children[len(p.children)/2:])
+
+	for i := len(above) - 1; i >= 0; i-- {
+		if i == len(above)-1 {
+			writeSideways(lw, above[i], abovePrefix+"    ", abovePrefix+"┌── ", abovePrefix+"│   ")
+		} else {
+			writeSideways(lw, above[i], abovePrefix+"│   ", abovePrefix+"├── ", abovePrefix+"│   ")
+		}
+	}
+	lw.writeLine(linePrefix + p.label)
+	for i := len(below) - 1; i >= 0; i-- {
+		if i == 0 {
+			writeSideways(lw, below[i], belowPrefix+"│   ", belowPrefix+"└── ", belowPrefix+"    ")
+		} else {
+			writeSideways(lw, below[i], belowPrefix+"│   ", belowPrefix+"├── ", belowPrefix+"│   ")
+		}
+	}
+}
+
+func presentChildren(children []*printable) []*printable {
+	var present []*printable
+	for _, child := range children {
+		if child != nil {
+			present = append(present, child)
+		}
+	}
+	return present
+}
+
+// topDownBox is the layout of a binary subtree drawn top-down, worked out from the bottom up
+// before anything is drawn: the subtrees of a position are drawn side by side, with its
+// label centered over the gap between them and underscores running out to slashes over
+// the middles of the two subtree roots.
+// An empty label is drawn as a space, so every box has a middle column to hang a slash over.
+type topDownBox struct {
+	label       string
+	labelWidth  int
+	width       int // columns taken by the drawing of the subtree
+	middle      int // column of the middle of the subtree's root label
+	height      int // lines taken by the drawing of the subtree
+	left, right *topDownBox
+}
+
+func topDownLayout(p *printable) *topDownBox {
+	box := &topDownBox{label: p.label}
+	if box.label == "" {
+		box.label = " "
+	}
+	u := utf8.RuneCountInString(box.label)
+	box.labelWidth = u
+	if len(p.children) == 0 {
+		box.width, box.middle, box.height = u, u/2, 1
+		return box
+	}
+
+	if p.children[0] != nil {
+		box.left = topDownLayout(p.children[0])
+	}
+	if p.children[1] != nil {
+		box.right = topDownLayout(p.children[1])
+	}
+	n, m, below := 0, 0, 0
+	if box.left != nil {
+		n, below = box.left.width, box.left.height
+	}
+	if box.right != nil {
+		m, below = box.right.width, max(below, box.right.height)
+	}
+	box.width, box.middle, box.height = n+u+m, n+u/2, below+2
+	return box
+}
+
+// topDownCanvas holds the lines of a top-down drawing being painted, each cut off one
+// column past the width limit. That last column is only ever painted with a marker that
+// something was cut off, so the lineWriter still ends the line with an ellipsis, which
+// means no more than the width limit plus one columns of any line are ever painted.
+type topDownCanvas struct {
+	lines [][]rune
+	width int // the width limit, or 0 for no limit
+}
+
+// put paints r at a column of a line, or marks the line as cut off if the column is past the limit
+func (c *topDownCanvas) put(line, column int, r rune) {
+	if c.width > 0 && column >= c.width {
+		c.cutOff(line)
+		return
+	}
+	c.set(line, column, r)
+}
+
+// cutOff marks a line as running past the width limit
+func (c *topDownCanvas) cutOff(line int) {
+	c.set(line, c.width, '…')
+}
+
+func (c *topDownCanvas) set(line, column int, r rune) {
+	for len(c.lines[line]) <= column {
+		c.lines[line] = append(c.lines[line], ' ')
+	}
+	c.lines[line][column] = r
+}
+
+// write paints s from a column of a line, stopping at the limit
+func (c *topDownCanvas) write(line, column int, s string) {
+	for _, r := range s {
+		c.put(line, column, r)
+		if c.width > 0 && column >= c.width {
+			return
+		}
+		column++
+	}
+}
+
+// repeat paints n copies of r from a column of a line, stopping at the limit
+func (c *topDownCanvas) repeat(line, column, n int, r rune) {
+	for i := range n {
+		c.put(line, column+i, r)
+		if c.width > 0 && column+i >= c.width {
+			return
+		}
+	}
+}
+
+// paint draws the subtree laid out in box with its top left corner at a line and column.
+// A subtree starting past the width limit would be cut off entirely, so it isn't drawn at all,
+// and each of its lines is just marked as cut off.
+func (c *topDownCanvas) paint(box *topDownBox, line, column int) {
+	if c.width > 0 && column >= c.width {
+		for i := range box.height {
+			c.cutOff(line + i)
+		}
+		return
+	}
+
+	n, u := 0, box.labelWidth
+	if box.left != nil {
+		n = box.left.width
+		x := box.left.middle
+		c.repeat(line, column+x+1, n-x-1, '_')
+		c.put(line+1, column+x, '/')
+		c.paint(box.left, line+2, column)
+	}
+	c.write(line, column+n, box.label)
+	if box.right != nil {
+		y := box.right.middle
+		c.repeat(line, column+n+u, y, '_')
+		c.put(line+1, column+n+u+y, '\\')
+		c.paint(box.right, line+2, column+n+u)
+	}
+}
+
+// lineWriter writes lines cut off at a width, holding on to the first error
+// so the renderers don't need to check after every line
+type lineWriter struct {
+	w     io.Writer
+	width int
+	err   error
+}
+
+func (lw *lineWriter) writeLine(line string) {
+	if lw.err != nil {
+		return
+	}
+	if lw.width > 0 && utf8.RuneCountInString(line) > lw.width {
+		line = string([]rune(line)[:lw.width-1]) + "…"
+	}
+	_, lw.err = io.WriteString(lw.w, line+"\n")
+}
@@ -0,0 +1,226 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	x, y int
+}
+
+func (p point) String() string {
+	return fmt.Sprintf("(%d %d)", p.x, p.y)
+}
+
+type failingWriter struct{}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestOrderedTree_Print(t *testing.T) {
+	assert := assert.New(t)
+	tree := newLetterTree()
+
+	assert.Equal(`A
+├── B
+│   ├── E
+│   └── F
+│       ├── H
+│       └── I
+├── C
+└── D
+    └── G
+`, tree.String())
+
+	var sb strings.Builder
+	assert.Nil(tree.FprintSideways(&sb))
+	assert.Equal(`    ┌── G
+┌── D
+├── C
+A
+│       ┌── I
+│   ┌── F
+│   │   └── H
+└── B
+    └── E
+`, sb.String())
+
+	sb.Reset()
+	assert.Nil(tree.Fprint(&sb, WithPrintDepth(1), WithPrintChildren(2)))
+	assert.Equal(`A
+├── B
+│   └── …
+├── C
+└── … 1 more
+`, sb.String())
+
+	sb.Reset()
+	assert.Nil(tree.Fprint(&sb, WithPrintWidth(9)))
+	assert.Equal(`A
+├── B
+│   ├── E
+│   └── F
+│       …
+│       …
+├── C
+└── D
+    └── G
+`, sb.String())
+
+	assert.NotNil(tree.Fprint(failingWriter{}))
+	assert.Equal("<nil>", fmt.Sprint((*OrderedTree[string])(nil)))
+}
+
+func TestBinaryTree_Print(t *testing.T) {
+	assert := assert.New(t)
+	tree := newNumberTree()
+
+	assert.Equal(`1
+├── 2
+│   ├── 4
+│   └── 5
+│       ├── 7
+│       └── ∅
+└── 3
+    ├── ∅
+    └── 6
+        ├── 8
+        └── ∅
+`, tree.String())
+
+	var sb strings.Builder
+	assert.Nil(tree.FprintSideways(&sb))
+	assert.Equal(`    ┌── 6
+    │   └── 8
+┌── 3
+1
+│   ┌── 5
+│   │   └── 7
+└── 2
+    └── 4
+`, sb.String())
+
+	sb.Reset()
+	assert.Nil(tree.FprintTopDown(&sb))
+	assert.Equal(`  __1
+ /   \
+ 2_  3_
+/  \   \
+4  5   6
+  /   /
+  7   8
+`, sb.String())
+
+	sb.Reset()
+	assert.Nil(tree.FprintTopDown(&sb, WithPrintDepth(1)))
+	assert.Equal(`  _1
+ /  \
+ 2  3
+/ \  \
+… …  …
+`, sb.String())
+
+	sb.Reset()
+	assert.Nil(tree.FprintTopDown(&sb, WithPrintWidth(4)))
+	assert.Equal(`  _…
+ / …
+ 2_…
+/  …
+4  …
+  /…
+  7…
+`, sb.String())
+
+	assert.NotNil(tree.FprintTopDown(failingWriter{}))
+	assert.Equal("<nil>", fmt.Sprint((*BinaryTree[int])(nil)))
+}
+
+func TestPrint_EmptyLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewBinaryTree("")
+	assert.Nil(tree.SetLeft(NewBinaryTree("")))
+	assert.Nil(tree.SetRight(NewBinaryTree("a")))
+	assert.Nil(tree.LeftChild().SetRight(NewBinaryTree("")))
+
+	var sb strings.Builder
+	assert.Nil(tree.FprintTopDown(&sb))
+	// empty labels are drawn a space wide, which the trimming of each line hides
+	assert.Equal(` _
+/  \
+   a
+ \
+
+`, sb.String())
+}
+
+func TestPrint_Stringer(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewBinaryTree(point{0, 0})
+	assert.Nil(tree.SetLeft(NewBinaryTree(point{-1, 1})))
+	assert.Nil(tree.SetRight(NewBinaryTree(point{1, 1})))
+
+	var sb strings.Builder
+	assert.Nil(tree.FprintTopDown(&sb))
+	assert.Equal(`    __(0 0)__
+   /         \
+(-1 1)     (1 1)
+`, sb.String())
+
+	ordered := NewOrderedTree[fmt.Stringer](point{0, 0})
+	assert.Nil(ordered.AddChild(NewOrderedTree[fmt.Stringer](point{1, 2})))
+	assert.Equal("(0 0)\n└── (1 2)\n", ordered.String())
+
+	multiline := NewOrderedTree("a\nb")
+	assert.Equal("a\\nb\n", multiline.String())
+}
+
+func TestPrint_TopDownWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	// cut off the lines of a drawing of any width in the same way as a lineWriter
+	cutOff := func(s string, width int) []string {
+		lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+		for i, line := range lines {
+			if utf8.RuneCountInString(line) > width {
+				lines[i] = string([]rune(line)[:width-1]) + "…"
+			}
+		}
+		return lines
+	}
+
+	r := rand.New(rand.NewSource(36))
+	for name, shape := range binaryTreeShapes {
+		for _, n := range []int{1, 2, 10, 100} {
+			tree := shape(r, n)
+			var full strings.Builder
+			assert.Nil(tree.FprintTopDown(&full, WithPrintWidth(0)))
+			for _, width := range []int{1, 5, 20, 80} {
+				var limited strings.Builder
+				assert.Nil(tree.FprintTopDown(&limited, WithPrintWidth(width)))
+				assert.Equal(cutOff(full.String(), width), cutOff(limited.String(), width), name, n, width)
+			}
+		}
+	}
+
+	// a path leaves most of a deep tree past the width limit, where it isn't drawn at all
+	for _, shape := range []PathShape{LeftPath, RightPath, ZigZagPath} {
+		tree := PathBinaryTree(10000, shape)
+		var sb strings.Builder
+		assert.Nil(tree.FprintTopDown(&sb))
+		lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+		assert.Equal(2*10000-1, len(lines))
+		for _, line := range lines {
+			assert.LessOrEqual(utf8.RuneCountInString(line), DefaultPrintWidth)
+		}
+	}
+}
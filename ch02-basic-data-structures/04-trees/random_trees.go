@@ -0,0 +1,202 @@
+package trees
+
+import "math/rand"
+
+// RandomBinaryTree generates a binary tree of n positions, chosen uniformly at random
+// from all the possible shapes, using Rémy's algorithm. The positions hold the values
+// 0 to n-1 in in-order, which makes the tree a binary search tree as well.
+// A size of 0 generates an empty, nil, tree.
+//
+// Rémy's algorithm grows a full binary tree, where every position has either zero or two
+// children, one internal position at a time. Each step picks any one of the positions at
+// random and splices a new internal position in above it, with the picked position on one
+// side and a new external position on the other. Every full binary tree with i internal
+// positions can be grown in exactly the same number of ways, so each is equally likely.
+// Dropping the external positions from a full binary tree with n internal positions then
+// leaves a binary tree with n positions, and each shape comes from exactly one full tree.
+//
+// Generating a tree by attaching positions one at a time below random existing positions
+// favours short, bushy trees, while under the uniform distribution the height of a random
+// tree grows with the square root of its size.
+func RandomBinaryTree(r *rand.Rand, n int) *BinaryTree[int] {
+	if n <= 0 {
+		return nil
+	}
+
+	// the full binary tree, with -1 standing in for no position,
+	// so external positions are the ones with no children
+	size := 2*n + 1
+	left, right, parent := make([]int, size), make([]int, size), make([]int, size)
+	left[0], right[0], parent[0] = -1, -1, -1
+	root := 0
+	for i := 1; i <= n; i++ {
+		picked := r.Intn(2*i - 1)
+		internal, external := 2*i-1, 2*i
+		left[external], right[external] = -1, -1
+
+		// splice the internal position in where the picked position was
+		parent[internal] = parent[picked]
+		switch {
+		case parent[picked] == -1:
+			root = internal
+		case left[parent[picked]] == picked:
+			left[parent[picked]] = internal
+		default:
+			right[parent[picked]] = internal
+		}
+		if r.Intn(2) == 0 {
+			left[internal], right[internal] = picked, external
+		} else {
+			left[internal], right[internal] = external, picked
+		}
+		parent[picked], parent[external] = internal, internal
+	}
+
+	// keep only the internal positions
+	nodes := make([]*BinaryTree[int], size)
+	for i := range nodes {
+		if left[i] != -1 {
+			nodes[i] = &BinaryTree[int]{}
+		}
+	}
+	for i, node := range nodes {
+		if node == nil {
+			continue
+		}
+		node.leftChild, node.rightChild = nodes[left[i]], nodes[right[i]]
+		if parent[i] != -1 {
+			node.parent = nodes[parent[i]]
+		}
+	}
+
+	tree := nodes[root]
+	value := 0
+	for node := range tree.InOrder() {
+		node.value = value
+		value++
+	}
+	return tree
+}
+
+// DegreeDistribution draws the number of children of a position in a random ordered tree
+type DegreeDistribution func(r *rand.Rand) int
+
+// UniformDegree gives each position any number of children from low to high,
+// each equally likely
+func UniformDegree(low, high int) DegreeDistribution {
+	return func(r *rand.Rand) int {
+		return low + r.Intn(high-low+1)
+	}
+}
+
+// GeometricDegree gives each position k children with probability (1-p)^k * p,
+// the number of failed coin flips before the first success, for an average of (1-p)/p
+func GeometricDegree(p float64) DegreeDistribution {
+	return func(r *rand.Rand) int {
+		k := 0
+		for r.Float64() >= p {
+			k++
+		}
+		return k
+	}
+}
+
+// RandomOrderedTree generates an ordered tree of n positions, drawing the number of
+// children of each position from the degree distribution. The positions hold the values
+// 0 to n-1 in level order. A size of 0 generates an empty, nil, tree.
+//
+// The tree is grown one level at a time, handing out children to each position in level
+// order until all n positions have been created. The last position to get children may get
+// fewer than it drew, and if every position so far has drawn zero children before the tree
+// is big enough, the last of them is given one child anyway to keep the tree growing.
+func RandomOrderedTree(r *rand.Rand, n int, degree DegreeDistribution) *OrderedTree[int] {
+	if n <= 0 {
+		return nil
+	}
+	root := NewOrderedTree(0)
+	created := 1
+	// parents holds each position in level order, waiting to be given its children
+	parents := newGrowingQueue()
+	parents.enQueue(root)
+	for created < n {
+		v, _ := parents.deQueue()
+		parent := v.(*OrderedTree[int])
+		children := min(max(degree(r), 0), n-created)
+		if children == 0 && parents.len() == 0 {
+			children = 1
+		}
+		for range children {
+			child := NewOrderedTree(created)
+			child.parent = parent
+			parent.children = append(parent.children, child)
+			parents.enQueue(child)
+			created++
+		}
+	}
+	return root
+}
+
+// PathShape is the way a path-shaped binary tree turns at each position
+type PathShape int
+
+const (
+	LeftPath   PathShape = iota // every position is the left child of its parent
+	RightPath                   // every position is the right child of its parent
+	ZigZagPath                  // positions alternate between left and right children
+)
+
+// PathBinaryTree builds a degenerate binary tree of n positions, each with at most one
+// child, holding the values 0 to n-1 from the root down. A size of 0 builds an empty,
+// nil, tree.
+//
+// These are the worst case shapes for anything whose cost depends on the height of
+// the tree, such as an unbalanced binary search tree built from sorted values.
+func PathBinaryTree(n int, shape PathShape) *BinaryTree[int] {
+	var tree *BinaryTree[int]
+	// build from the bottom up, so no attachment ever needs to walk up a long path
+	for depth := n - 1; depth >= 0; depth-- {
+		parent := NewBinaryTree(depth)
+		if tree != nil {
+			tree.parent = parent
+			if shape == LeftPath || (shape == ZigZagPath && depth%2 == 0) {
+				parent.leftChild = tree
+			} else {
+				parent.rightChild = tree
+			}
+		}
+		tree = parent
+	}
+	return tree
+}
+
+// PathOrderedTree builds a degenerate ordered tree of n positions, each with at most one
+// child, holding the values 0 to n-1 from the root down. A size of 0 builds an empty,
+// nil, tree.
+func PathOrderedTree(n int) *OrderedTree[int] {
+	var tree *OrderedTree[int]
+	for depth := n - 1; depth >= 0; depth-- {
+		parent := NewOrderedTree(depth)
+		if tree != nil {
+			tree.parent = parent
+			parent.children = []*OrderedTree[int]{tree}
+		}
+		tree = parent
+	}
+	return tree
+}
+
+// StarOrderedTree builds an ordered tree of n positions, with the root, holding 0,
+// as the parent of every other position, holding the values 1 to n-1 in order.
+// A size of 0 builds an empty, nil, tree.
+func StarOrderedTree(n int) *OrderedTree[int] {
+	if n <= 0 {
+		return nil
+	}
+	root := NewOrderedTree(0)
+	for i := 1; i < n; i++ {
+		child := NewOrderedTree(i)
+		child.parent = root
+		root.children = append(root.children, child)
+	}
+	return root
+}
@@ -0,0 +1,277 @@
+package trees
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// binaryTreeShapes generate binary trees of n positions holding the values 0 to n-1
+var binaryTreeShapes = map[string]func(r *rand.Rand, n int) *BinaryTree[int]{
+	"random":     RandomBinaryTree,
+	"left path":  func(_ *rand.Rand, n int) *BinaryTree[int] { return PathBinaryTree(n, LeftPath) },
+	"right path": func(_ *rand.Rand, n int) *BinaryTree[int] { return PathBinaryTree(n, RightPath) },
+	"zigzag":     func(_ *rand.Rand, n int) *BinaryTree[int] { return PathBinaryTree(n, ZigZagPath) },
+	"complete":   func(_ *rand.Rand, n int) *BinaryTree[int] { return CompleteBinaryTree(sequence(n)) },
+	"sorted":     func(_ *rand.Rand, n int) *BinaryTree[int] { return BinaryTreeFromSorted(sequence(n)) },
+}
+
+// orderedTreeShapes generate ordered trees of n positions holding the values 0 to n-1
+var orderedTreeShapes = map[string]func(r *rand.Rand, n int) *OrderedTree[int]{
+	"uniform degree": func(r *rand.Rand, n int) *OrderedTree[int] {
+		return RandomOrderedTree(r, n, UniformDegree(0, 4))
+	},
+	"geometric degree": func(r *rand.Rand, n int) *OrderedTree[int] {
+		return RandomOrderedTree(r, n, GeometricDegree(0.5))
+	},
+	"path": func(_ *rand.Rand, n int) *OrderedTree[int] { return PathOrderedTree(n) },
+	"star": func(_ *rand.Rand, n int) *OrderedTree[int] { return StarOrderedTree(n) },
+}
+
+func TestRandomBinaryTree(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(40))
+
+	assert.Nil(RandomBinaryTree(r, 0))
+
+	for n := 1; n <= 100; n++ {
+		tree := RandomBinaryTree(r, n)
+		assert.Equal(n, tree.Size())
+		assert.Equal(sequence(n), collect(tree.InOrderCursor()))
+		assert.Nil(tree.parent)
+		for node := range tree.All() {
+			for _, child := range binaryChildren(node) {
+				assert.Equal(node, child.parent)
+			}
+		}
+	}
+
+	// there are 5 binary trees of 3 positions and 14 of 4, the Catalan numbers,
+	// and each should turn up about equally often
+	for _, shapes := range []struct{ n, count int }{{3, 5}, {4, 14}} {
+		const samples = 14000
+		counts := map[string]int{}
+		for i := 0; i < samples; i++ {
+			counts[RandomBinaryTree(r, shapes.n).Parenthetic()]++
+		}
+		assert.Len(counts, shapes.count)
+		expected := samples / shapes.count
+		for shape, count := range counts {
+			assert.InDelta(expected, count, float64(expected)/5, shape)
+		}
+	}
+}
+
+func TestRandomOrderedTree(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(40))
+
+	assert.Nil(RandomOrderedTree(r, 0, UniformDegree(0, 3)))
+
+	for n := 1; n <= 100; n++ {
+		tree := RandomOrderedTree(r, n, UniformDegree(1, 3))
+		assert.Equal(n, tree.Size())
+		assert.Equal(sequence(n), collect(tree.LevelOrderCursor()))
+		assert.LessOrEqual(tree.Metrics().MaxDegree, 3)
+		for node := range tree.All() {
+			for _, child := range node.children {
+				assert.Equal(node, child.parent)
+			}
+		}
+	}
+
+	// a distribution that never gives any children still grows a tree of the right size
+	tree := RandomOrderedTree(r, 5, UniformDegree(0, 0))
+	assert.Equal("0(1(2(3(4))))", tree.Parenthetic())
+
+	// a distribution that always gives the same number of children fills each level in turn
+	tree = RandomOrderedTree(r, 10, UniformDegree(3, 3))
+	assert.Equal([]int{1, 3, 6}, tree.LevelWidths())
+
+	// the degrees drawn from the geometric distribution should average out to (1-p)/p
+	degrees := 0
+	geometric := GeometricDegree(0.25)
+	for i := 0; i < 10000; i++ {
+		degrees += geometric(r)
+	}
+	assert.InDelta(3, float64(degrees)/10000, 0.2)
+}
+
+func TestPathBinaryTree(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(PathBinaryTree(0, LeftPath))
+	assert.Equal("0(1(2(3,),),)", PathBinaryTree(4, LeftPath).Parenthetic())
+	assert.Equal("0(,1(,2(,3)))", PathBinaryTree(4, RightPath).Parenthetic())
+	assert.Equal("0(1(,2(3,)),)", PathBinaryTree(4, ZigZagPath).Parenthetic())
+
+	// a tree this deep would take quadratic time to build by attaching from the top down
+	tree := PathBinaryTree(100000, ZigZagPath)
+	assert.Equal(100000, tree.Height())
+	deepest := tree
+	for deepest.Degree() > 0 {
+		deepest = binaryChildren(deepest)[0]
+	}
+	assert.Equal(99999, deepest.Value())
+	assert.Equal(99999, deepest.Depth())
+}
+
+func TestPathOrderedTree(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(PathOrderedTree(0))
+	assert.Equal("0(1(2(3)))", PathOrderedTree(4).Parenthetic())
+
+	tree := PathOrderedTree(100000)
+	assert.Equal(100000, tree.Height())
+	assert.Equal(1, tree.Metrics().MaxDegree)
+}
+
+func TestStarOrderedTree(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(StarOrderedTree(0))
+	assert.Equal("0", StarOrderedTree(1).Parenthetic())
+	assert.Equal("0(1,2,3)", StarOrderedTree(4).Parenthetic())
+	assert.Equal(2, StarOrderedTree(4).Height())
+}
+
+// TestBinaryTreeShapes checks properties which should hold for trees of any shape
+func TestBinaryTreeShapes(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	for name, shape := range binaryTreeShapes {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			for _, n := range []int{1, 2, 3, 8, 33} {
+				tree := shape(r, n)
+				msg := fmt.Sprintf("%d positions: %s", n, tree.Parenthetic())
+
+				m := tree.Metrics()
+				assert.Equal(naiveMetrics(t, tree), m.TreeMetrics, msg)
+				full, complete, perfect, balanced := naiveBinaryShape(tree)
+				assert.Equal(full, m.Full, msg)
+				assert.Equal(complete, m.Complete, msg)
+				assert.Equal(perfect, m.Perfect, msg)
+				assert.Equal(balanced, m.Balanced, msg)
+
+				values := collect(tree.PreOrderCursor())
+				slices.Sort(values)
+				assert.Equal(sequence(n), values, msg)
+
+				rebuilt, err := BinaryTreeFromPreInOrder(collect(tree.PreOrderCursor()), collect(tree.InOrderCursor()))
+				assert.Nil(err)
+				assert.True(EqualBinaryTrees(tree, rebuilt), msg)
+
+				rebuilt, err = BinaryTreeFromPostInOrder(collect(tree.PostOrderCursor()), collect(tree.InOrderCursor()))
+				assert.Nil(err)
+				assert.True(EqualBinaryTrees(tree, rebuilt), msg)
+
+				rebuilt, err = BinaryTreeFromLevelOrderArray(tree.LevelOrderArray())
+				assert.Nil(err)
+				assert.True(EqualBinaryTrees(tree, rebuilt), msg)
+
+				rebuilt, err = ParseBinaryTree(tree.Parenthetic(), strconv.Atoi)
+				assert.Nil(err)
+				assert.True(EqualBinaryTrees(tree, rebuilt), msg)
+
+				data, err := json.Marshal(tree)
+				assert.Nil(err)
+				rebuilt = &BinaryTree[int]{}
+				assert.Nil(json.Unmarshal(data, rebuilt))
+				assert.True(EqualBinaryTrees(tree, rebuilt), msg)
+			}
+		})
+	}
+}
+
+// TestOrderedTreeShapes checks properties which should hold for trees of any shape
+func TestOrderedTreeShapes(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	for name, shape := range orderedTreeShapes {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			for _, n := range []int{1, 2, 3, 8, 33} {
+				tree := shape(r, n)
+				msg := fmt.Sprintf("%d positions: %s", n, tree.Parenthetic())
+
+				assert.Equal(naiveMetrics(t, tree), tree.Metrics(), msg)
+
+				values := collect(tree.PreOrderCursor())
+				slices.Sort(values)
+				assert.Equal(sequence(n), values, msg)
+
+				rebuilt, err := OrderedTreeFromLevelOrderArray(tree.LevelOrderArray())
+				assert.Nil(err)
+				assert.True(EqualOrderedTrees(tree, rebuilt), msg)
+
+				rebuilt, err = ParseOrderedTree(tree.Parenthetic(), strconv.Atoi)
+				assert.Nil(err)
+				assert.True(EqualOrderedTrees(tree, rebuilt), msg)
+
+				data, err := json.Marshal(tree)
+				assert.Nil(err)
+				rebuilt = &OrderedTree[int]{}
+				assert.Nil(json.Unmarshal(data, rebuilt))
+				assert.True(EqualOrderedTrees(tree, rebuilt), msg)
+
+				queries := NewBinaryLiftingLCA(tree)
+				for node := range tree.All() {
+					lca, err := queries.LCA(tree, node)
+					assert.Nil(err)
+					assert.Equal(tree, lca, msg)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRandomBinaryTree(b *testing.B) {
+	r := rand.New(rand.NewSource(40))
+	for i := 0; i < b.N; i++ {
+		RandomBinaryTree(r, 1<<10)
+	}
+}
+
+func BenchmarkRandomOrderedTree(b *testing.B) {
+	r := rand.New(rand.NewSource(40))
+	degree := GeometricDegree(0.5)
+	for i := 0; i < b.N; i++ {
+		RandomOrderedTree(r, 1<<10, degree)
+	}
+}
+
+// BenchmarkBinaryTreeShapes compares how the shape of a tree affects the cost of walking
+// it in-order recursively, with WalkEuler, and with the stack-based in-order cursor
+func BenchmarkBinaryTreeShapes(b *testing.B) {
+	r := rand.New(rand.NewSource(40))
+	names := make([]string, 0, len(binaryTreeShapes))
+	for name := range binaryTreeShapes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		tree := binaryTreeShapes[name](r, 1<<12)
+		b.Run(name+"/metrics", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree.Metrics()
+			}
+		})
+		b.Run(name+"/in-order cursor", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for range tree.InOrderCursor().All() {
+				}
+			}
+		})
+		b.Run(name+"/in-order walk", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = tree.WalkEuler(nil, func(*BinaryTree[int], int, []*BinaryTree[int]) error { return nil }, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package trees
+
+import "slices"
+
+// BinaryTreeFromPreInOrder rebuilds a binary tree from the values of its pre-order and
+// in-order traversals, which together pin down its shape exactly as long as no value
+// appears more than once. Empty traversals rebuild an empty, nil, tree.
+//
+// The first value of the pre-order traversal is the root, and everything before the root
+// in the in-order traversal belongs to the left subtree, while everything after it
+// belongs to the right subtree. The pre-order traversal then lists the whole left subtree
+// before the right subtree, so each subtree can be rebuilt recursively in turn.
+func BinaryTreeFromPreInOrder[T comparable](preOrder, inOrder []T) (*BinaryTree[T], error) {
+	return binaryTreeFromTraversals(preOrder, inOrder, false)
+}
+
+// BinaryTreeFromPostInOrder rebuilds a binary tree from the values of its post-order and
+// in-order traversals, which together pin down its shape exactly as long as no value
+// appears more than once. Empty traversals rebuild an empty, nil, tree.
+//
+// Read backwards, the post-order traversal lists each root before its right subtree and its
+// right subtree before its left subtree, so the tree can be rebuilt just like it is from a
+// pre-order traversal, only building the right subtree of each position first.
+func BinaryTreeFromPostInOrder[T comparable](postOrder, inOrder []T) (*BinaryTree[T], error) {
+	roots := slices.Clone(postOrder)
+	slices.Reverse(roots)
+	return binaryTreeFromTraversals(roots, inOrder, true)
+}
+
+// binaryTreeFromTraversals rebuilds a binary tree from the in-order traversal and a list
+// of the roots of its subtrees, in the order they are built: either left subtrees first,
+// as in a pre-order traversal, or right subtrees first, as in a reversed post-order traversal
+func binaryTreeFromTraversals[T comparable](roots, inOrder []T, rightFirst bool) (*BinaryTree[T], error) {
+	if len(roots) != len(inOrder) {
+		return nil, TraversalMismatchError{}
+	}
+
+	// inOrderIndex lets us find the root of each subtree in the in-order traversal in O(1)
+	inOrderIndex := make(map[T]int, len(inOrder))
+	for i, v := range inOrder {
+		if _, ok := inOrderIndex[v]; ok {
+			return nil, DuplicateValueError{}
+		}
+		inOrderIndex[v] = i
+	}
+
+	next := 0 // index of the next root
+	var build func(low, high int) (*BinaryTree[T], error)
+	build = func(low, high int) (*BinaryTree[T], error) {
+		// build the subtree made up of inOrder[low:high]
+		if low == high {
+			return nil, nil
+		}
+		value := roots[next]
+		next++
+		i, ok := inOrderIndex[value]
+		if !ok || i < low || i >= high {
+			return nil, TraversalMismatchError{}
+		}
+
+		tree := NewBinaryTree(value)
+		var left, right *BinaryTree[T]
+		var err error
+		if rightFirst {
+			if right, err = build(i+1, high); err == nil {
+				left, err = build(low, i)
+			}
+		} else {
+			if left, err = build(low, i); err == nil {
+				right, err = build(i+1, high)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		tree.leftChild, tree.rightChild = left, right
+		for _, child := range binaryChildren(tree) {
+			child.parent = tree
+		}
+		return tree, nil
+	}
+	return build(0, len(inOrder))
+}
+
+// LevelOrderArray returns the values of the binary tree in level order, with a nil for
+// each missing child of a position in the tree, and any trailing nils trimmed off,
+// in the style used by LeetCode:
+//
+//	  1
+//	 / \
+//	2   3    =>  [1, 2, 3, nil, nil, 4]
+//	   /
+//	  4
+func (bt *BinaryTree[T]) LevelOrderArray() []*T {
+	var values []*T
+	queue := newGrowingQueue()
+	queue.enQueue(bt)
+	for v, ok := queue.deQueue(); ok; v, ok = queue.deQueue() {
+		tree := v.(*BinaryTree[T])
+		if tree == nil {
+			values = append(values, nil)
+			continue
+		}
+		value := tree.value
+		values = append(values, &value)
+		queue.enQueue(tree.leftChild)
+		queue.enQueue(tree.rightChild)
+	}
+	return trimNils(values)
+}
+
+// BinaryTreeFromLevelOrderArray rebuilds a binary tree from its LevelOrderArray.
+// An empty array rebuilds an empty, nil, tree.
+func BinaryTreeFromLevelOrderArray[T any](values []*T) (*BinaryTree[T], error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if values[0] == nil {
+		return nil, MalformedLevelOrderError{}
+	}
+
+	root := NewBinaryTree(*values[0])
+	// parents holds each position in level order, waiting to be given its children
+	parents := newGrowingQueue()
+	parents.enQueue(root)
+	for i := 1; i < len(values); {
+		v, ok := parents.deQueue()
+		if !ok {
+			// more children than there are positions to give them to
+			return nil, MalformedLevelOrderError{}
+		}
+		parent := v.(*BinaryTree[T])
+		parent.leftChild = levelOrderChild(parent, values[i], parents)
+		i++
+		if i < len(values) {
+			parent.rightChild = levelOrderChild(parent, values[i], parents)
+			i++
+		}
+	}
+	return root, nil
+}
+
+// levelOrderChild creates a child of parent for a non-nil value,
+// queueing it up to be given its own children later
+func levelOrderChild[T any](parent *BinaryTree[T], value *T, parents *growingQueue) *BinaryTree[T] {
+	if value == nil {
+		return nil
+	}
+	child := NewBinaryTree(*value)
+	child.parent = parent
+	parents.enQueue(child)
+	return child
+}
+
+// LevelOrderArray returns the values of the ordered tree in level order, with the
+// children of each position followed by a nil to mark the end of the group, and any
+// trailing nils trimmed off, in the style used by LeetCode for n-ary trees.
+// The root is also followed by a nil, as if it were the only child of an imaginary parent:
+//
+//	    1
+//	  / | \
+//	 3  2  4    =>  [1, nil, 3, 2, 4, nil, 5, 6]
+//	/ \
+//	5   6
+func (ot *OrderedTree[T]) LevelOrderArray() []*T {
+	value := ot.value
+	values := []*T{&value, nil}
+	queue := newGrowingQueue()
+	queue.enQueue(ot)
+	for v, ok := queue.deQueue(); ok; v, ok = queue.deQueue() {
+		for _, child := range v.(*OrderedTree[T]).children {
+			value := child.value
+			values = append(values, &value)
+			queue.enQueue(child)
+		}
+		values = append(values, nil)
+	}
+	return trimNils(values)
+}
+
+// OrderedTreeFromLevelOrderArray rebuilds an ordered tree from its LevelOrderArray.
+// An empty array rebuilds an empty, nil, tree.
+func OrderedTreeFromLevelOrderArray[T any](values []*T) (*OrderedTree[T], error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if values[0] == nil || (len(values) > 1 && values[1] != nil) {
+		return nil, MalformedLevelOrderError{}
+	}
+
+	root := NewOrderedTree(*values[0])
+	// parents holds each position in level order, waiting to be given its children
+	parents := newGrowingQueue()
+	parents.enQueue(root)
+	for i := 2; i < len(values); i++ {
+		v, ok := parents.deQueue()
+		if !ok {
+			// more groups of children than there are positions to give them to
+			return nil, MalformedLevelOrderError{}
+		}
+		parent := v.(*OrderedTree[T])
+		for ; i < len(values) && values[i] != nil; i++ {
+			child := NewOrderedTree(*values[i])
+			child.parent = parent
+			parent.children = append(parent.children, child)
+			parents.enQueue(child)
+		}
+	}
+	return root, nil
+}
+
+func trimNils[T any](values []*T) []*T {
+	for len(values) > 0 && values[len(values)-1] == nil {
+		values = values[:len(values)-1]
+	}
+	return values
+}
@@ -0,0 +1,154 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestBinaryTreeFromPreInOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newNumberTree()
+	rebuilt, err := BinaryTreeFromPreInOrder(collect(tree.PreOrderCursor()), collect(tree.InOrderCursor()))
+	assert.Nil(err)
+	assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+
+	r := rand.New(rand.NewSource(35))
+	for i := 0; i < 100; i++ {
+		tree := RandomBinaryTree(r, 1+r.Intn(50))
+		rebuilt, err := BinaryTreeFromPreInOrder(collect(tree.PreOrderCursor()), collect(tree.InOrderCursor()))
+		assert.Nil(err)
+		assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+		for node := range rebuilt.All() {
+			for _, child := range binaryChildren(node) {
+				assert.Equal(node, child.parent)
+			}
+		}
+	}
+
+	empty, err := BinaryTreeFromPreInOrder[int](nil, nil)
+	assert.Nil(err)
+	assert.Nil(empty)
+
+	_, err = BinaryTreeFromPreInOrder([]int{1, 2}, []int{1})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPreInOrder([]int{1, 2, 3}, []int{1, 2, 4})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPreInOrder([]int{1, 1, 2}, []int{1, 2, 3})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPreInOrder([]int{1, 2, 1}, []int{1, 2, 1})
+	assert.ErrorIs(err, DuplicateValueError{})
+}
+
+func TestBinaryTreeFromPostInOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newNumberTree()
+	rebuilt, err := BinaryTreeFromPostInOrder(collect(tree.PostOrderCursor()), collect(tree.InOrderCursor()))
+	assert.Nil(err)
+	assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+
+	r := rand.New(rand.NewSource(40))
+	for i := 0; i < 100; i++ {
+		tree := RandomBinaryTree(r, 1+r.Intn(50))
+		postOrder := collect(tree.PostOrderCursor())
+		rebuilt, err := BinaryTreeFromPostInOrder(postOrder, collect(tree.InOrderCursor()))
+		assert.Nil(err)
+		assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+		assert.Equal(postOrder, collect(tree.PostOrderCursor()), "the post-order traversal should be left untouched")
+		for node := range rebuilt.All() {
+			for _, child := range binaryChildren(node) {
+				assert.Equal(node, child.parent)
+			}
+		}
+	}
+
+	empty, err := BinaryTreeFromPostInOrder[int](nil, nil)
+	assert.Nil(err)
+	assert.Nil(empty)
+
+	_, err = BinaryTreeFromPostInOrder([]int{1, 2}, []int{1})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPostInOrder([]int{1, 2, 3}, []int{1, 2, 4})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPostInOrder([]int{2, 1, 1}, []int{1, 2, 3})
+	assert.ErrorIs(err, TraversalMismatchError{})
+	_, err = BinaryTreeFromPostInOrder([]int{1, 2, 1}, []int{1, 2, 1})
+	assert.ErrorIs(err, DuplicateValueError{})
+}
+
+func TestBinaryTree_LevelOrderArray(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := newNumberTree()
+	values := tree.LevelOrderArray()
+	assert.Equal([]*int{ptr(1), ptr(2), ptr(3), ptr(4), ptr(5), nil, ptr(6), nil, nil, ptr(7), nil, ptr(8)}, values)
+	rebuilt, err := BinaryTreeFromLevelOrderArray(values)
+	assert.Nil(err)
+	assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+
+	r := rand.New(rand.NewSource(35))
+	for i := 0; i < 100; i++ {
+		tree := RandomBinaryTree(r, 1+r.Intn(50))
+		values := tree.LevelOrderArray()
+		assert.NotNil(values[len(values)-1])
+		rebuilt, err := BinaryTreeFromLevelOrderArray(values)
+		assert.Nil(err)
+		assert.Equal(tree.Parenthetic(), rebuilt.Parenthetic())
+		for node := range rebuilt.All() {
+			for _, child := range binaryChildren(node) {
+				assert.Equal(node, child.parent)
+			}
+		}
+	}
+
+	empty, err := BinaryTreeFromLevelOrderArray[int](nil)
+	assert.Nil(err)
+	assert.Nil(empty)
+
+	_, err = BinaryTreeFromLevelOrderArray([]*int{nil, ptr(1)})
+	assert.ErrorIs(err, MalformedLevelOrderError{})
+	_, err = BinaryTreeFromLevelOrderArray([]*int{ptr(1), nil, nil, ptr(2)})
+	assert.ErrorIs(err, MalformedLevelOrderError{})
+}
+
+func TestOrderedTree_LevelOrderArray(t *testing.T) {
+	assert := assert.New(t)
+
+	leetCode := []*int{ptr(1), nil, ptr(3), ptr(2), ptr(4), nil, ptr(5), ptr(6)}
+	tree, err := OrderedTreeFromLevelOrderArray(leetCode)
+	assert.Nil(err)
+	assert.Equal("1(3(5,6),2,4)", tree.Parenthetic())
+	assert.Equal(leetCode, tree.LevelOrderArray())
+	for node := range tree.All() {
+		for _, child := range node.children {
+			assert.Equal(node, child.parent)
+		}
+	}
+
+	letters := newLetterTree()
+	rebuilt, err := OrderedTreeFromLevelOrderArray(letters.LevelOrderArray())
+	assert.Nil(err)
+	assert.Equal(letters.Parenthetic(), rebuilt.Parenthetic())
+
+	single, err := OrderedTreeFromLevelOrderArray([]*string{ptr("A")})
+	assert.Nil(err)
+	assert.Equal([]*string{ptr("A")}, single.LevelOrderArray())
+
+	empty, err := OrderedTreeFromLevelOrderArray[int](nil)
+	assert.Nil(err)
+	assert.Nil(empty)
+
+	_, err = OrderedTreeFromLevelOrderArray([]*int{nil})
+	assert.ErrorIs(err, MalformedLevelOrderError{})
+	_, err = OrderedTreeFromLevelOrderArray([]*int{ptr(1), ptr(2)})
+	assert.ErrorIs(err, MalformedLevelOrderError{})
+	_, err = OrderedTreeFromLevelOrderArray([]*int{ptr(1), nil, ptr(2), nil, nil, ptr(3)})
+	assert.ErrorIs(err, MalformedLevelOrderError{})
+}
@@ -0,0 +1,114 @@
+package trees
+
+// Tree is the position-based tree ADT implemented by both OrderedTree and BinaryTree.
+//
+// Each node of a tree is a position, holding a value and knowing its place relative to
+// the other positions around it. There is no separate tree container, so every position
+// can also be treated as the root of the subtree below it.
+//
+// Parent returns nil for the root, and Children returns the children in order,
+// skipping any missing children of a BinaryTree.
+// Height counts the levels of the subtree, so a single external position has height 1,
+// while Depth counts the ancestors of the position, so the root has depth 0.
+type Tree[T any] interface {
+	Value() T
+	Root() Tree[T]
+	Parent() Tree[T]
+	Children() []Tree[T]
+	IsExternal() bool
+	Depth() int
+	Height() int
+}
+
+var _ Tree[any] = (*OrderedTree[any])(nil)
+var _ Tree[any] = (*BinaryTree[any])(nil)
+
+type TreeVisit[T any] func(tree Tree[T]) error
+
+// TraversePreOrder visits a position before all of its descendants,
+// for any implementation of Tree
+func TraversePreOrder[T any](tree Tree[T], visit TreeVisit[T]) error {
+	return WalkPreOrder(tree, func(tree Tree[T], _ int, _ []Tree[T]) error {
+		return visit(tree)
+	})
+}
+
+// TraversePostOrder visits a position after all of its descendants,
+// for any implementation of Tree
+func TraversePostOrder[T any](tree Tree[T], visit TreeVisit[T]) error {
+	return WalkPostOrder(tree, func(tree Tree[T], _ int, _ []Tree[T]) error {
+		return visit(tree)
+	})
+}
+
+type TreeHasParentError struct{}
+
+func (e TreeHasParentError) Error() string {
+	return "tree already has a parent"
+}
+
+type TreeCycleError struct{}
+
+func (e TreeCycleError) Error() string {
+	return "tree cannot be a descendant of itself"
+}
+
+type TreeRootError struct{}
+
+func (e TreeRootError) Error() string {
+	return "tree is a root"
+}
+
+type ChildNotFoundError struct{}
+
+func (e ChildNotFoundError) Error() string {
+	return "tree is not a child"
+}
+
+type ChildIndexOutOfRangeError struct{}
+
+func (e ChildIndexOutOfRangeError) Error() string {
+	return "child index out of range"
+}
+
+type NilTreeError struct{}
+
+func (e NilTreeError) Error() string {
+	return "tree is nil"
+}
+
+type ParentheticSyntaxError struct{}
+
+func (e ParentheticSyntaxError) Error() string {
+	return "invalid parenthetic tree representation"
+}
+
+type TraversalMismatchError struct{}
+
+func (e TraversalMismatchError) Error() string {
+	return "traversals do not describe the same tree"
+}
+
+type DuplicateValueError struct{}
+
+func (e DuplicateValueError) Error() string {
+	return "tree values are not unique"
+}
+
+type MalformedLevelOrderError struct{}
+
+func (e MalformedLevelOrderError) Error() string {
+	return "invalid level order array"
+}
+
+type NodeNotInTreeError struct{}
+
+func (e NodeNotInTreeError) Error() string {
+	return "node is not in the tree"
+}
+
+type AncestorOutOfRangeError struct{}
+
+func (e AncestorOutOfRangeError) Error() string {
+	return "ancestor is above the root"
+}
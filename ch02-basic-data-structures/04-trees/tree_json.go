@@ -0,0 +1,73 @@
+package trees
+
+import "encoding/json"
+
+// orderedTreeJSON is the nested JSON representation of an OrderedTree,
+// with the children left out entirely for external positions:
+//
+//	{"value": "A", "children": [{"value": "B"}, {"value": "C"}]}
+type orderedTreeJSON[T any] struct {
+	Value    T                 `json:"value"`
+	Children []*OrderedTree[T] `json:"children,omitempty"`
+}
+
+func (ot *OrderedTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(orderedTreeJSON[T]{Value: ot.value, Children: ot.children})
+}
+
+// UnmarshalJSON replaces the value and children of the tree,
+// detaching any children it had before
+func (ot *OrderedTree[T]) UnmarshalJSON(data []byte) error {
+	var decoded orderedTreeJSON[T]
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+	for _, child := range decoded.Children {
+		if child == nil {
+			return NilTreeError{}
+		}
+	}
+
+	for _, child := range ot.children {
+		child.parent = nil
+	}
+	ot.value, ot.children = decoded.Value, decoded.Children
+	for _, child := range ot.children {
+		child.parent = ot
+	}
+	return nil
+}
+
+// binaryTreeJSON is the nested JSON representation of a BinaryTree,
+// with any missing children left out:
+//
+//	{"value": "A", "left": {"value": "B"}, "right": {"value": "C"}}
+type binaryTreeJSON[T any] struct {
+	Value T              `json:"value"`
+	Left  *BinaryTree[T] `json:"left,omitempty"`
+	Right *BinaryTree[T] `json:"right,omitempty"`
+}
+
+func (bt *BinaryTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(binaryTreeJSON[T]{Value: bt.value, Left: bt.leftChild, Right: bt.rightChild})
+}
+
+// UnmarshalJSON replaces the value and children of the tree,
+// detaching any children it had before
+func (bt *BinaryTree[T]) UnmarshalJSON(data []byte) error {
+	var decoded binaryTreeJSON[T]
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range binaryChildren(bt) {
+		child.parent = nil
+	}
+	bt.value, bt.leftChild, bt.rightChild = decoded.Value, decoded.Left, decoded.Right
+	for _, child := range binaryChildren(bt) {
+		child.parent = bt
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package trees
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedTree_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, err := ParseOrderedTree("A(B(D,E),C)", parseString)
+	assert.Nil(err)
+	data, err := json.Marshal(tree)
+	assert.Nil(err)
+	assert.JSONEq(`{
+		"value": "A",
+		"children": [
+			{"value": "B", "children": [{"value": "D"}, {"value": "E"}]},
+			{"value": "C"}
+		]
+	}`, string(data))
+
+	// unmarshalling replaces the existing children of the tree
+	decoded := newLetterTree()
+	oldChild := decoded.ChildAt(0)
+	assert.Nil(json.Unmarshal(data, decoded))
+	assert.Equal(tree.Parenthetic(), decoded.Parenthetic())
+	assert.Nil(oldChild.Parent())
+	for node := range decoded.All() {
+		for _, child := range node.children {
+			assert.Equal(node, child.parent)
+		}
+	}
+
+	round := newLetterTree()
+	data, err = json.Marshal(round)
+	assert.Nil(err)
+	var roundTrip *OrderedTree[string]
+	assert.Nil(json.Unmarshal(data, &roundTrip))
+	assert.Equal(round.Parenthetic(), roundTrip.Parenthetic())
+
+	assert.ErrorIs(json.Unmarshal([]byte(`{"value": "A", "children": [null]}`), &roundTrip), NilTreeError{})
+	assert.NotNil(json.Unmarshal([]byte(`{"value": 1}`), &roundTrip))
+}
+
+func TestBinaryTree_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, err := ParseBinaryTree("A(B(,D),C)", parseString)
+	assert.Nil(err)
+	data, err := json.Marshal(tree)
+	assert.Nil(err)
+	assert.JSONEq(`{
+		"value": "A",
+		"left": {"value": "B", "right": {"value": "D"}},
+		"right": {"value": "C"}
+	}`, string(data))
+
+	var decoded *BinaryTree[string]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(tree.Parenthetic(), decoded.Parenthetic())
+	for node := range decoded.All() {
+		for _, child := range binaryChildren(node) {
+			assert.Equal(node, child.parent)
+		}
+	}
+
+	numbers := newNumberTree()
+	data, err = json.Marshal(numbers)
+	assert.Nil(err)
+	var roundTrip BinaryTree[int]
+	assert.Nil(json.Unmarshal(data, &roundTrip))
+	assert.Equal(numbers.Parenthetic(), roundTrip.Parenthetic())
+}
@@ -0,0 +1,74 @@
+package trees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTrees builds the same shape of tree as both an OrderedTree and a BinaryTree:
+//
+//	  1
+//	 / \
+//	2   3
+//	   /
+//	  4
+func newTestTrees(t *testing.T) map[string]Tree[int] {
+	ordered := NewOrderedTree(1)
+	ordered2, ordered3, ordered4 := NewOrderedTree(2), NewOrderedTree(3), NewOrderedTree(4)
+	assert.Nil(t, ordered.AddChild(ordered2))
+	assert.Nil(t, ordered.AddChild(ordered3))
+	assert.Nil(t, ordered3.AddChild(ordered4))
+
+	binary := NewBinaryTree(1)
+	binary3 := NewBinaryTree(3)
+	assert.Nil(t, binary.SetLeft(NewBinaryTree(2)))
+	assert.Nil(t, binary.SetRight(binary3))
+	assert.Nil(t, binary3.SetLeft(NewBinaryTree(4)))
+
+	return map[string]Tree[int]{"ordered": ordered, "binary": binary}
+}
+
+func TestTree(t *testing.T) {
+	for name, tree := range newTestTrees(t) {
+		t.Run(name, func(t *testing.T) {
+			assert.Nil(t, tree.Parent())
+			assert.Equal(t, tree, tree.Root())
+			assert.Equal(t, 0, tree.Depth())
+			assert.Equal(t, 3, tree.Height())
+			assert.False(t, tree.IsExternal())
+
+			children := tree.Children()
+			assert.Len(t, children, 2)
+			assert.True(t, children[0].IsExternal())
+			assert.Equal(t, 1, children[0].Height())
+
+			grandchild := children[1].Children()[0]
+			assert.Equal(t, 4, grandchild.Value())
+			assert.Equal(t, 2, grandchild.Depth())
+			assert.Equal(t, children[1], grandchild.Parent())
+			assert.Equal(t, tree, grandchild.Root())
+		})
+	}
+}
+
+func TestTree_Traversals(t *testing.T) {
+	for name, tree := range newTestTrees(t) {
+		t.Run(name, func(t *testing.T) {
+			var preOrder, postOrder []int
+			err := TraversePreOrder(tree, func(tree Tree[int]) error {
+				preOrder = append(preOrder, tree.Value())
+				return nil
+			})
+			assert.Nil(t, err)
+			err = TraversePostOrder(tree, func(tree Tree[int]) error {
+				postOrder = append(postOrder, tree.Value())
+				return nil
+			})
+			assert.Nil(t, err)
+
+			assert.Equal(t, []int{1, 2, 3, 4}, preOrder)
+			assert.Equal(t, []int{2, 4, 3, 1}, postOrder)
+		})
+	}
+}
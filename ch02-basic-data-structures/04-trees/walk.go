@@ -0,0 +1,234 @@
+package trees
+
+import "errors"
+
+// SkipChildren can be returned by a visitor to prune the traversal,
+// in the same way filepath.SkipDir prunes a filepath.Walk.
+//
+// A pre-order visitor, or the pre visitor of an OrderedTree Euler tour, returning SkipChildren
+// skips the descendants of the position it was called on, and the traversal carries on
+// with the next sibling.
+// A BinaryTree Euler tour left visitor returning SkipChildren skips both subtrees of the position,
+// which is still visited from below and from the right, while a below visitor returning
+// SkipChildren skips only the right subtree.
+// Anywhere else the descendants have already been visited, so SkipChildren is ignored.
+// SkipChildren is never returned as an error by a traversal.
+var SkipChildren = errors.New("skip children")
+
+// StopTraversal can be returned by any visitor to end the traversal immediately,
+// in the same way fs.SkipAll ends a fs.WalkDir.
+// StopTraversal is never returned as an error by a traversal.
+var StopTraversal = errors.New("stop traversal")
+
+// TreeWalkFunc is a visitor which also receives the depth of the position it was called
+// on, and the path of positions leading down to it from the root where the walk started.
+// The first position of the path is that root, and the last is the position itself,
+// so the depth is always len(path)-1.
+//
+// The path is reused as the walk moves around the tree, so a visitor which wants to
+// keep it around after it returns must make its own copy.
+type TreeWalkFunc[T any] func(tree Tree[T], depth int, path []Tree[T]) error
+
+// WalkPreOrder walks a position before all of its descendants,
+// for any implementation of Tree
+func WalkPreOrder[T any](tree Tree[T], walk TreeWalkFunc[T]) error {
+	return endWalk(walkPreOrder(tree, walk, nil))
+}
+
+func walkPreOrder[T any](tree Tree[T], walk TreeWalkFunc[T], path []Tree[T]) error {
+	path = append(path, tree)
+	if walk != nil {
+		err := walk(tree, len(path)-1, path)
+		if err == SkipChildren {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, child := range tree.Children() {
+		err := walkPreOrder(child, walk, path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkPostOrder walks a position after all of its descendants,
+// for any implementation of Tree
+func WalkPostOrder[T any](tree Tree[T], walk TreeWalkFunc[T]) error {
+	return endWalk(walkPostOrder(tree, walk, nil))
+}
+
+func walkPostOrder[T any](tree Tree[T], walk TreeWalkFunc[T], path []Tree[T]) error {
+	path = append(path, tree)
+	for _, child := range tree.Children() {
+		err := walkPostOrder(child, walk, path)
+		if err != nil {
+			return err
+		}
+	}
+	if walk != nil {
+		return skipped(walk(tree, len(path)-1, path))
+	}
+	return nil
+}
+
+// OrderedTreeWalkFunc is the OrderedTree version of TreeWalkFunc
+type OrderedTreeWalkFunc[T any] func(tree *OrderedTree[T], depth int, path []*OrderedTree[T]) error
+
+func (ot *OrderedTree[T]) WalkPreOrder(walk OrderedTreeWalkFunc[T]) error {
+	return endWalk(ot.walkPreOrder(walk, nil))
+}
+
+func (ot *OrderedTree[T]) walkPreOrder(walk OrderedTreeWalkFunc[T], path []*OrderedTree[T]) error {
+	path = append(path, ot)
+	if walk != nil {
+		err := walk(ot, len(path)-1, path)
+		if err == SkipChildren {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, child := range ot.children {
+		err := child.walkPreOrder(walk, path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ot *OrderedTree[T]) WalkPostOrder(walk OrderedTreeWalkFunc[T]) error {
+	return endWalk(ot.walkPostOrder(walk, nil))
+}
+
+func (ot *OrderedTree[T]) walkPostOrder(walk OrderedTreeWalkFunc[T], path []*OrderedTree[T]) error {
+	path = append(path, ot)
+	for _, child := range ot.children {
+		err := child.walkPostOrder(walk, path)
+		if err != nil {
+			return err
+		}
+	}
+	if walk != nil {
+		return skipped(walk(ot, len(path)-1, path))
+	}
+	return nil
+}
+
+// WalkEuler walks around the tree like an Euler tour: each position is walked by preWalk
+// before its children, by returnWalk each time the tour comes back up from one of its
+// children, and by postWalk after all of its children.
+// A preWalk returning SkipChildren skips the children of the position,
+// which is still walked by postWalk.
+func (ot *OrderedTree[T]) WalkEuler(preWalk, returnWalk, postWalk OrderedTreeWalkFunc[T]) error {
+	return endWalk(ot.walkEuler(preWalk, returnWalk, postWalk, nil))
+}
+
+func (ot *OrderedTree[T]) walkEuler(preWalk, returnWalk, postWalk OrderedTreeWalkFunc[T], path []*OrderedTree[T]) error {
+	path = append(path, ot)
+	depth := len(path) - 1
+
+	skip := false
+	if preWalk != nil {
+		err := preWalk(ot, depth, path)
+		if err == SkipChildren {
+			skip = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range ot.children {
+		if skip {
+			break
+		}
+		err := child.walkEuler(preWalk, returnWalk, postWalk, path)
+		if err != nil {
+			return err
+		}
+		if returnWalk != nil {
+			err = skipped(returnWalk(ot, depth, path))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if postWalk != nil {
+		return skipped(postWalk(ot, depth, path))
+	}
+	return nil
+}
+
+// BinaryTreeWalkFunc is the BinaryTree version of TreeWalkFunc
+type BinaryTreeWalkFunc[T any] func(tree *BinaryTree[T], depth int, path []*BinaryTree[T]) error
+
+// WalkEuler walks each position three times: from the left before its left subtree,
+// from below between its two subtrees, and from the right after its right subtree
+func (bt *BinaryTree[T]) WalkEuler(leftWalk, belowWalk, rightWalk BinaryTreeWalkFunc[T]) error {
+	return endWalk(bt.walkEuler(leftWalk, belowWalk, rightWalk, nil))
+}
+
+func (bt *BinaryTree[T]) walkEuler(leftWalk, belowWalk, rightWalk BinaryTreeWalkFunc[T], path []*BinaryTree[T]) error {
+	path = append(path, bt)
+	depth := len(path) - 1
+
+	skipLeft, skipRight := false, false
+	if leftWalk != nil {
+		err := leftWalk(bt, depth, path)
+		if err == SkipChildren {
+			skipLeft, skipRight = true, true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if leftChild := bt.LeftChild(); leftChild != nil && !skipLeft {
+		err := leftChild.walkEuler(leftWalk, belowWalk, rightWalk, path)
+		if err != nil {
+			return err
+		}
+	}
+	if belowWalk != nil {
+		err := belowWalk(bt, depth, path)
+		if err == SkipChildren {
+			skipRight = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if rightChild := bt.RightChild(); rightChild != nil && !skipRight {
+		err := rightChild.walkEuler(leftWalk, belowWalk, rightWalk, path)
+		if err != nil {
+			return err
+		}
+	}
+	if rightWalk != nil {
+		return skipped(rightWalk(bt, depth, path))
+	}
+
+	return nil
+}
+
+// skipped ignores a SkipChildren returned after the children have already been visited
+func skipped(err error) error {
+	if err == SkipChildren {
+		return nil
+	}
+	return err
+}
+
+// endWalk swallows the StopTraversal which unwound a walk ended early by a visitor,
+// leaving any other error to be returned to the caller
+func endWalk(err error) error {
+	if err == StopTraversal {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,257 @@
+package trees
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pathValues[N interface{ Value() T }, T any](path []N) []T {
+	var values []T
+	for _, node := range path {
+		values = append(values, node.Value())
+	}
+	return values
+}
+
+func TestOrderedTree_WalkPreOrder(t *testing.T) {
+	assert := assert.New(t)
+	tree := newLetterTree()
+
+	t.Run("depth and path from the root", func(t *testing.T) {
+		var lines []string
+		err := tree.WalkPreOrder(func(tree *OrderedTree[string], depth int, path []*OrderedTree[string]) error {
+			assert.Equal(len(path)-1, depth)
+			assert.Equal(tree, path[len(path)-1])
+			lines = append(lines, strings.Repeat("  ", depth)+strings.Join(pathValues[*OrderedTree[string]](path), "/"))
+			return nil
+		})
+		assert.Nil(err)
+		assert.Equal([]string{
+			"A",
+			"  A/B",
+			"    A/B/E",
+			"    A/B/F",
+			"      A/B/F/H",
+			"      A/B/F/I",
+			"  A/C",
+			"  A/D",
+			"    A/D/G",
+		}, lines)
+	})
+
+	t.Run("walk starts from a subtree", func(t *testing.T) {
+		f := tree.ChildAt(0).ChildAt(1)
+		var depths []int
+		err := f.WalkPreOrder(func(_ *OrderedTree[string], depth int, _ []*OrderedTree[string]) error {
+			depths = append(depths, depth)
+			return nil
+		})
+		assert.Nil(err)
+		assert.Equal([]int{0, 1, 1}, depths)
+	})
+
+	t.Run("SkipChildren prunes a subtree", func(t *testing.T) {
+		var visited []string
+		err := tree.WalkPreOrder(func(tree *OrderedTree[string], depth int, _ []*OrderedTree[string]) error {
+			visited = append(visited, tree.Value())
+			if tree.Value() == "B" {
+				return SkipChildren
+			}
+			return nil
+		})
+		assert.Nil(err)
+		assert.Equal([]string{"A", "B", "C", "D", "G"}, visited)
+	})
+
+	t.Run("SkipChildren limits the depth", func(t *testing.T) {
+		var visited []string
+		err := tree.WalkPreOrder(func(tree *OrderedTree[string], depth int, _ []*OrderedTree[string]) error {
+			visited = append(visited, tree.Value())
+			if depth == 1 {
+				return SkipChildren
+			}
+			return nil
+		})
+		assert.Nil(err)
+		assert.Equal([]string{"A", "B", "C", "D"}, visited)
+	})
+
+	t.Run("StopTraversal ends the walk without an error", func(t *testing.T) {
+		var found []string
+		err := tree.WalkPreOrder(func(tree *OrderedTree[string], _ int, path []*OrderedTree[string]) error {
+			if tree.Value() == "H" {
+				found = pathValues[*OrderedTree[string]](path)
+				return StopTraversal
+			}
+			return nil
+		})
+		assert.Nil(err)
+		assert.Equal([]string{"A", "B", "F", "H"}, found)
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		errTest := errors.New("test")
+		var visited []string
+		err := tree.WalkPreOrder(func(tree *OrderedTree[string], _ int, _ []*OrderedTree[string]) error {
+			visited = append(visited, tree.Value())
+			if tree.Value() == "F" {
+				return errTest
+			}
+			return nil
+		})
+		assert.ErrorIs(err, errTest)
+		assert.Equal([]string{"A", "B", "E", "F"}, visited)
+	})
+}
+
+func TestOrderedTree_WalkPostOrder(t *testing.T) {
+	assert := assert.New(t)
+	tree := newLetterTree()
+
+	var visited []string
+	var depths []int
+	err := tree.WalkPostOrder(func(tree *OrderedTree[string], depth int, _ []*OrderedTree[string]) error {
+		visited = append(visited, tree.Value())
+		depths = append(depths, depth)
+		// the children have already been visited, so this is ignored
+		return SkipChildren
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"E", "H", "I", "F", "B", "C", "G", "D", "A"}, visited)
+	assert.Equal([]int{2, 3, 3, 2, 1, 1, 2, 1, 0}, depths)
+
+	visited = nil
+	err = tree.TraversePostOrder(func(tree *OrderedTree[string]) error {
+		visited = append(visited, tree.Value())
+		if tree.Value() == "B" {
+			return StopTraversal
+		}
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"E", "H", "I", "F", "B"}, visited)
+}
+
+func TestBinaryTree_WalkEuler(t *testing.T) {
+	assert := assert.New(t)
+	tree := newNumberTree()
+
+	record := func(visited *[]string, prefix string, skip int, result error) BinaryTreeWalkFunc[int] {
+		return func(tree *BinaryTree[int], depth int, path []*BinaryTree[int]) error {
+			assert.Equal(len(path)-1, depth)
+			assert.Equal(tree, path[depth])
+			*visited = append(*visited, prefix+string(rune('0'+tree.Value())))
+			if tree.Value() == skip {
+				return result
+			}
+			return nil
+		}
+	}
+
+	t.Run("SkipChildren from the left skips both subtrees", func(t *testing.T) {
+		var visited []string
+		err := tree.WalkEuler(
+			record(&visited, "L", 2, SkipChildren),
+			record(&visited, "B", 0, nil),
+			record(&visited, "R", 0, nil),
+		)
+		assert.Nil(err)
+		assert.Equal([]string{
+			"L1", "L2", "B2", "R2", "B1",
+			"L3", "B3", "L6", "L8", "B8", "R8", "B6", "R6", "R3", "R1",
+		}, visited)
+	})
+
+	t.Run("SkipChildren from below skips the right subtree", func(t *testing.T) {
+		var visited []string
+		err := tree.WalkEuler(nil, record(&visited, "B", 1, SkipChildren), nil)
+		assert.Nil(err)
+		assert.Equal([]string{"B4", "B2", "B7", "B5", "B1"}, visited)
+	})
+
+	t.Run("SkipChildren from the right is ignored", func(t *testing.T) {
+		var visited []string
+		err := tree.WalkEuler(nil, nil, record(&visited, "R", 2, SkipChildren))
+		assert.Nil(err)
+		assert.Equal([]string{"R4", "R7", "R5", "R2", "R8", "R6", "R3", "R1"}, visited)
+	})
+
+	t.Run("StopTraversal ends the walk without an error", func(t *testing.T) {
+		var visited []string
+		err := tree.TraverseEuler(nil, func(tree *BinaryTree[int]) error {
+			visited = append(visited, string(rune('0'+tree.Value())))
+			if tree.Value() == 5 {
+				return StopTraversal
+			}
+			return nil
+		}, nil)
+		assert.Nil(err)
+		assert.Equal([]string{"4", "2", "7", "5"}, visited)
+	})
+}
+
+func TestTree_Walk(t *testing.T) {
+	for name, tree := range newTestTrees(t) {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var visited, depths []int
+			err := WalkPreOrder(tree, func(tree Tree[int], depth int, path []Tree[int]) error {
+				assert.Equal(tree.Depth(), depth)
+				assert.Equal(tree.Root(), path[0])
+				visited = append(visited, tree.Value())
+				depths = append(depths, depth)
+				if tree.Value() == 3 {
+					return SkipChildren
+				}
+				return nil
+			})
+			assert.Nil(err)
+			assert.Equal([]int{1, 2, 3}, visited)
+			assert.Equal([]int{0, 1, 1}, depths)
+
+			visited = nil
+			err = WalkPostOrder(tree, func(tree Tree[int], _ int, path []Tree[int]) error {
+				visited = append(visited, tree.Value())
+				if tree.Value() == 4 {
+					assert.Equal([]int{1, 3, 4}, pathValues[Tree[int]](path))
+					return StopTraversal
+				}
+				return nil
+			})
+			assert.Nil(err)
+			assert.Equal([]int{2, 4}, visited)
+
+			// a nil walk visits nothing, as it does for the OrderedTree methods
+			assert.Nil(WalkPreOrder(tree, nil))
+			assert.Nil(WalkPostOrder(tree, nil))
+		})
+	}
+
+	tree := newLetterTree()
+	assert.Nil(t, tree.WalkPreOrder(nil))
+	assert.Nil(t, tree.WalkPostOrder(nil))
+}
+
+func TestTraverse_Sentinels(t *testing.T) {
+	assert := assert.New(t)
+	tree := newLetterTree()
+
+	var visited []string
+	err := tree.TraverseLevelOrder(func(tree *OrderedTree[string]) error {
+		visited = append(visited, tree.Value())
+		switch tree.Value() {
+		case "B":
+			// the children are already queued up, so this is ignored
+			return SkipChildren
+		case "E":
+			return StopTraversal
+		}
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"A", "B", "C", "D", "E"}, visited)
+}
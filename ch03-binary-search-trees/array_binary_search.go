@@ -1,30 +1,76 @@
 package binarysearchtrees
 
-const BinarySearchNotfound = -1
+import "cmp"
 
-func BinarySearch(a []int, k int) int {
-	return binarySearch(a, k, 0, len(a))
+// BinarySearch looks for k in the sorted slice a. If k is there, it returns the index of
+// its first occurrence and true. If not, it returns the index k would need to be inserted
+// at to keep the slice sorted, and false.
+func BinarySearch[T cmp.Ordered](a []T, k T) (int, bool) {
+	return BinarySearchFunc(a, k, cmp.Compare[T])
 }
 
-func binarySearch(a []int, k, low, high int) int {
-	length := high - low
+// BinarySearchFunc is BinarySearch for a slice sorted in the order given by compare,
+// which returns a negative number when an element comes before the target,
+// zero when it matches the target and a positive number when it comes after the target.
+// The target does not need to be the same type as the elements, so a slice of records
+// sorted by key can be searched for a key.
+func BinarySearchFunc[E, K any](a []E, k K, compare func(E, K) int) (int, bool) {
+	i := LowerBoundFunc(a, k, compare)
+	return i, i < len(a) && compare(a[i], k) == 0
+}
 
-	if length == 0 {
-		return BinarySearchNotfound
-	}
-	if length == 1 {
-		if a[low] == k {
-			return low
+// LowerBound returns the index of the first element of the sorted slice a which is not
+// less than k, or len(a) if every element is less than k
+func LowerBound[T cmp.Ordered](a []T, k T) int {
+	return LowerBoundFunc(a, k, cmp.Compare[T])
+}
+
+// LowerBoundFunc is LowerBound for a slice sorted in the order given by compare
+func LowerBoundFunc[E, K any](a []E, k K, compare func(E, K) int) int {
+	return partitionPoint(len(a), func(i int) bool { return compare(a[i], k) >= 0 })
+}
+
+// UpperBound returns the index of the first element of the sorted slice a which is greater
+// than k, or len(a) if no element is greater than k
+func UpperBound[T cmp.Ordered](a []T, k T) int {
+	return UpperBoundFunc(a, k, cmp.Compare[T])
+}
+
+// UpperBoundFunc is UpperBound for a slice sorted in the order given by compare
+func UpperBoundFunc[E, K any](a []E, k K, compare func(E, K) int) int {
+	return partitionPoint(len(a), func(i int) bool { return compare(a[i], k) > 0 })
+}
+
+// EqualRange returns the bounds of the run of elements equal to k in the sorted slice a,
+// so that a[low:high] holds every occurrence of k. When k is not there, the run is empty,
+// with both bounds at the index k would need to be inserted at.
+func EqualRange[T cmp.Ordered](a []T, k T) (low, high int) {
+	return EqualRangeFunc(a, k, cmp.Compare[T])
+}
+
+// EqualRangeFunc is EqualRange for a slice sorted in the order given by compare
+func EqualRangeFunc[E, K any](a []E, k K, compare func(E, K) int) (low, high int) {
+	low = LowerBoundFunc(a, k, compare)
+	// the run can only start at low, so the upper bound only needs to be searched for past it
+	high = low + UpperBoundFunc(a[low:], k, compare)
+	return low, high
+}
+
+// partitionPoint returns the first index in [0, n) at which past is true, or n if there is
+// none, where past is false for every index before some point and true for every index after.
+//
+// Each step halves the range [low, high) still holding the point, so it takes O(log n) steps.
+// Looping rather than recursing into the half that is left keeps the stack the same size
+// no matter how big the slice is.
+func partitionPoint(n int, past func(i int) bool) int {
+	low, high := 0, n
+	for low < high {
+		midIdx := low + (high-low)/2
+		if past(midIdx) {
+			high = midIdx
 		} else {
-			return BinarySearchNotfound
+			low = midIdx + 1
 		}
 	}
-
-	midIdx := low + (length / 2)
-	if a[midIdx] < k {
-		return binarySearch(a, k, midIdx+1, high)
-	} else if a[midIdx] > k {
-		return binarySearch(a, k, low, midIdx)
-	}
-	return midIdx
+	return low
 }
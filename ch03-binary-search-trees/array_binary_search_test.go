@@ -1,6 +1,10 @@
 package binarysearchtrees
 
 import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,56 +14,284 @@ var tests = []struct {
 	a        []int
 	k        int
 	expected int
+	found    bool
 }{
 	{
 		a:        []int{},
 		k:        1,
-		expected: BinarySearchNotfound,
+		expected: 0,
+		found:    false,
 	},
 	{
 		a:        []int{0},
 		k:        1,
-		expected: BinarySearchNotfound,
+		expected: 1,
+		found:    false,
 	},
 	{
 		a:        []int{1},
 		k:        1,
 		expected: 0,
+		found:    true,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        1,
-		expected: BinarySearchNotfound,
+		expected: 0,
+		found:    false,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        9,
 		expected: 5,
+		found:    true,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        7,
 		expected: 2,
+		found:    true,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        20,
 		expected: 9,
+		found:    true,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        2,
 		expected: 0,
+		found:    true,
 	},
 	{
 		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
 		k:        25,
 		expected: 10,
+		found:    true,
+	},
+	{
+		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
+		k:        8,
+		expected: 3,
+		found:    true,
+	},
+	{
+		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
+		k:        14,
+		expected: 7,
+		found:    true,
+	},
+	{
+		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
+		k:        10,
+		expected: 6,
+		found:    false,
+	},
+	{
+		a:        []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 20, 25},
+		k:        26,
+		expected: 11,
+		found:    false,
+	},
+	{
+		a:        []int{3, 3, 3, 3},
+		k:        3,
+		expected: 0,
+		found:    true,
 	},
 }
 
 func TestBinarySearch(t *testing.T) {
 	for _, test := range tests {
-		assert.Equal(t, test.expected, BinarySearch(test.a, test.k))
+		i, found := BinarySearch(test.a, test.k)
+		assert.Equal(t, test.expected, i)
+		assert.Equal(t, test.found, found)
+	}
+
+	i, found := BinarySearch([]string{"ant", "bee", "cat", "dog"}, "cow")
+	assert.Equal(t, 3, i)
+	assert.False(t, found)
+	i, found = BinarySearch([]float64{-1.5, 0, 2.25, 2.25}, 2.25)
+	assert.Equal(t, 2, i)
+	assert.True(t, found)
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"Ana", 19}, {"Bo", 25}, {"Cy", 25}, {"Di", 31}}
+	byAge := func(p person, age int) int { return cmp.Compare(p.age, age) }
+
+	i, found := BinarySearchFunc(people, 25, byAge)
+	assert.Equal(t, 1, i)
+	assert.True(t, found)
+	i, found = BinarySearchFunc(people, 30, byAge)
+	assert.Equal(t, 3, i)
+	assert.False(t, found)
+
+	// the slice only needs to be sorted in the order given by the comparator
+	words := []string{"Apple", "banana", "CHERRY", "date"}
+	i, found = BinarySearchFunc(words, "Cherry", func(word, target string) int {
+		return strings.Compare(strings.ToLower(word), strings.ToLower(target))
+	})
+	assert.Equal(t, 2, i)
+	assert.True(t, found)
+
+	descending := []int{9, 7, 7, 4, 1}
+	reversed := func(x, k int) int { return cmp.Compare(k, x) }
+	i, found = BinarySearchFunc(descending, 7, reversed)
+	assert.Equal(t, 1, i)
+	assert.True(t, found)
+	low, high := EqualRangeFunc(descending, 7, reversed)
+	assert.Equal(t, []int{7, 7}, descending[low:high])
+}
+
+func TestBounds(t *testing.T) {
+	a := []int{2, 4, 7, 8, 8, 9, 13, 14, 14, 14, 20, 25}
+	bounds := []struct {
+		k, lower, upper int
+	}{
+		{k: 0, lower: 0, upper: 0},
+		{k: 2, lower: 0, upper: 1},
+		{k: 8, lower: 3, upper: 5},
+		{k: 10, lower: 6, upper: 6},
+		{k: 14, lower: 7, upper: 10},
+		{k: 25, lower: 11, upper: 12},
+		{k: 30, lower: 12, upper: 12},
+	}
+	for _, bound := range bounds {
+		assert.Equal(t, bound.lower, LowerBound(a, bound.k), bound.k)
+		assert.Equal(t, bound.upper, UpperBound(a, bound.k), bound.k)
+		low, high := EqualRange(a, bound.k)
+		assert.Equal(t, bound.lower, low, bound.k)
+		assert.Equal(t, bound.upper, high, bound.k)
+	}
+
+	assert.Equal(t, 0, LowerBound([]int{}, 1))
+	assert.Equal(t, 0, UpperBound([]int{}, 1))
+	low, high := EqualRange([]int{}, 1)
+	assert.Equal(t, 0, low)
+	assert.Equal(t, 0, high)
+}
+
+func TestBinarySearch_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for i := 0; i < 200; i++ {
+		a := make([]int, r.Intn(100))
+		for j := range a {
+			a[j] = r.Intn(30)
+		}
+		slices.Sort(a)
+
+		for k := -1; k <= 31; k++ {
+			// count the elements less than and not greater than k one at a time
+			lower, upper := 0, 0
+			for _, x := range a {
+				if x < k {
+					lower++
+				}
+				if x <= k {
+					upper++
+				}
+			}
+			assert.Equal(t, lower, LowerBound(a, k))
+			assert.Equal(t, upper, UpperBound(a, k))
+
+			index, found := BinarySearch(a, k)
+			expectedIndex, expectedFound := slices.BinarySearch(a, k)
+			assert.Equal(t, expectedIndex, index)
+			assert.Equal(t, expectedFound, found)
+			assert.Equal(t, slices.Contains(a, k), found)
+		}
+	}
+}
+
+// keyDistributions generate n sorted keys spread out in different ways,
+// which suit some search algorithms better than others
+var keyDistributions = []struct {
+	name string
+	keys func(r *rand.Rand, n int) []int
+}{
+	{
+		name: "uniform",
+		keys: func(r *rand.Rand, n int) []int {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = r.Intn(1 << 30)
+			}
+			slices.Sort(a)
+			return a
+		},
+	},
+	{
+		name: "exponential",
+		keys: func(r *rand.Rand, n int) []int {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = int(r.ExpFloat64() * 1e6)
+			}
+			slices.Sort(a)
+			return a
+		},
+	},
+	{
+		name: "clustered",
+		keys: func(r *rand.Rand, n int) []int {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = r.Intn(8)<<40 + r.Intn(1000)
+			}
+			slices.Sort(a)
+			return a
+		},
+	},
+}
+
+var searches = []struct {
+	name   string
+	search func(a []int, k int) (int, bool)
+}{
+	{name: "binary", search: BinarySearch[int]},
+	{name: "exponential", search: ExponentialSearch[int]},
+	{name: "interpolation", search: InterpolationSearch[int]},
+}
+
+func BenchmarkSearch(b *testing.B) {
+	const n = 1 << 16
+	for _, distribution := range keyDistributions {
+		r := rand.New(rand.NewSource(41))
+		a := distribution.keys(r, n)
+		// look for keys which are there as well as keys which are not
+		targets := make([]int, 1024)
+		for i := range targets {
+			if i%2 == 0 {
+				targets[i] = a[r.Intn(n)]
+			} else {
+				targets[i] = a[r.Intn(n)] + 1
+			}
+		}
+
+		for _, search := range searches {
+			b.Run(distribution.name+"/"+search.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					search.search(a, targets[i%len(targets)])
+				}
+			})
+		}
+
+		// exponential search is at its best looking near the start
+		b.Run(distribution.name+"/binary near start", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BinarySearch(a, a[i%64])
+			}
+		})
+		b.Run(distribution.name+"/exponential near start", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ExponentialSearch(a, a[i%64])
+			}
+		})
 	}
 }
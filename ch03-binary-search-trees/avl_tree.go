@@ -0,0 +1,116 @@
+package binarysearchtrees
+
+import "cmp"
+
+// AVLTree is a BinarySearchTree which keeps itself balanced, so that every operation
+// takes O(log n) time however the keys are inserted and deleted.
+//
+// The heights of the two subtrees of every position differ by at most one, which is
+// enough to keep the height of the whole tree below about 1.44 log n: the fewest positions
+// an AVL tree of height h can have grows like the Fibonacci numbers, and so exponentially.
+//
+// Inserting or deleting a position only changes the heights of its ancestors, so after
+// each change the tree walks back up to the root, updating heights as it goes. Any position
+// found with subtrees differing in height by two is restructured with either a single or a
+// double rotation, depending on whether its taller child leans the same way it does.
+type AVLTree[K cmp.Ordered, V any] struct {
+	treeMap[K, V]
+}
+
+func NewAVLTree[K cmp.Ordered, V any](opts ...TreeMapOpt) *AVLTree[K, V] {
+	return &AVLTree[K, V]{treeMap[K, V]{options: newTreeMapOptions(opts)}}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there
+func (avl *AVLTree[K, V]) Put(k K, v V) {
+	n, added := avl.insert(k, v)
+	if added {
+		avl.rebalance(n.parent)
+	}
+}
+
+func (avl *AVLTree[K, V]) Delete(k K) error {
+	r, err := avl.remove(k)
+	if err != nil {
+		return err
+	}
+	avl.rebalance(r.parent)
+	return nil
+}
+
+// rebalance walks up from n to the root, updating the height of each position
+// and restructuring any position which has fallen out of balance.
+//
+// After an insertion, a single restructuring is enough to bring the subtree back to the
+// height it had before, so everything above it is balanced already. After a deletion
+// though, restructuring can leave the subtree a level shorter than it was, which can
+// unbalance its ancestors in turn, so it can take a restructuring at every level.
+func (avl *AVLTree[K, V]) rebalance(n *node[K, V]) {
+	for ; n != nil; n = n.parent {
+		updateHeight(n)
+		if b := balance(n); b > 1 || b < -1 {
+			n = avl.restructure(n)
+		}
+	}
+}
+
+// restructure rebalances the subtree at z, whose subtrees differ in height by two,
+// returning the new root of the subtree.
+//
+// Taking y as the taller child of z and x as the taller child of y, the three of them are
+// rearranged so that the middle key of the three ends up on top with the other two as its
+// children. When x and y are on the same side, that is y, which takes a single rotation
+// up over z. When they are on opposite sides, that is x, which takes a double rotation,
+// first up over y and then up over z.
+//
+// If y's subtrees are the same height, which can only happen after a deletion,
+// x is taken from the same side as y so that a single rotation is enough.
+func (avl *AVLTree[K, V]) restructure(z *node[K, V]) *node[K, V] {
+	y := tallerChild(z, true)
+	x := tallerChild(y, y == z.leftChild)
+
+	var top *node[K, V]
+	if (x == y.leftChild) == (y == z.leftChild) {
+		avl.rotate(y)
+		top = y
+	} else {
+		avl.rotate(x)
+		avl.rotate(x)
+		top = x
+	}
+	updateHeight(top.leftChild)
+	updateHeight(top.rightChild)
+	updateHeight(top)
+	return top
+}
+
+func height[K cmp.Ordered, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func updateHeight[K cmp.Ordered, V any](n *node[K, V]) {
+	n.height = max(height(n.leftChild), height(n.rightChild)) + 1
+}
+
+// balance is how much taller the left subtree of n is than its right subtree
+func balance[K cmp.Ordered, V any](n *node[K, V]) int {
+	return height(n.leftChild) - height(n.rightChild)
+}
+
+// tallerChild returns the child of n with the taller subtree,
+// breaking ties towards the left if preferLeft is true, or to the right if not
+func tallerChild[K cmp.Ordered, V any](n *node[K, V], preferLeft bool) *node[K, V] {
+	switch b := balance(n); {
+	case b > 0:
+		return n.leftChild
+	case b < 0:
+		return n.rightChild
+	case preferLeft:
+		return n.leftChild
+	default:
+		return n.rightChild
+	}
+}
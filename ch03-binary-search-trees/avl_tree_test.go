@@ -0,0 +1,112 @@
+package binarysearchtrees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkAVLTree verifies the binary search tree invariants, then that every position
+// holds the right height and is balanced
+func checkAVLTree(t *testing.T, avl *AVLTree[int, int]) {
+	checkTreeMap(t, &avl.treeMap)
+	checkAVLSubtree(t, avl.root)
+}
+
+func checkAVLSubtree(t *testing.T, n *node[int, int]) int {
+	if n == nil {
+		return 0
+	}
+	left, right := checkAVLSubtree(t, n.leftChild), checkAVLSubtree(t, n.rightChild)
+	assert.Equal(t, max(left, right)+1, n.height)
+	assert.LessOrEqual(t, left-right, 1, n.key)
+	assert.GreaterOrEqual(t, left-right, -1, n.key)
+	return n.height
+}
+
+func TestAVLTree(t *testing.T) {
+	testOrderedMap(t, func() *AVLTree[int, int] { return NewAVLTree[int, int]() }, checkAVLTree)
+}
+
+func newAVLTree(keys ...int) *AVLTree[int, int] {
+	avl := NewAVLTree[int, int]()
+	for _, k := range keys {
+		avl.Put(k, -k)
+	}
+	return avl
+}
+
+func TestAVLTree_Rotations(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		keys []int
+	}{
+		{name: "single rotation right", keys: []int{3, 2, 1}},
+		{name: "single rotation left", keys: []int{1, 2, 3}},
+		{name: "double rotation left-right", keys: []int{3, 1, 2}},
+		{name: "double rotation right-left", keys: []int{1, 3, 2}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			avl := newAVLTree(test.keys...)
+			assert.Equal(t, "2(1,3)", avl.Tree().Parenthetic())
+			checkAVLTree(t, avl)
+		})
+	}
+
+	// deleting from the short side of a position whose other child is evenly balanced
+	// only needs a single rotation
+	avl := newAVLTree(2, 1, 4, 3, 5)
+	assert.Nil(t, avl.Delete(1))
+	assert.Equal(t, "4(2(,3),5)", avl.Tree().Parenthetic())
+	checkAVLTree(t, avl)
+}
+
+// TestAVLTree_DeleteCascade deletes from a Fibonacci tree, the AVL tree with the fewest
+// positions for its height, where every position leans the same way. Deleting the
+// position that keeps the short side of the root as tall as it is unbalances the tree
+// at one level after another all the way up.
+func TestAVLTree_DeleteCascade(t *testing.T) {
+	avl := NewAVLTree[int, int]()
+	// build the Fibonacci tree of height h from the Fibonacci trees of heights h-1 and h-2,
+	// numbering the keys in order so it is also a binary search tree
+	var fibonacci func(h, low int) (*node[int, int], int)
+	fibonacci = func(h, low int) (*node[int, int], int) {
+		if h <= 0 {
+			return nil, low
+		}
+		left, k := fibonacci(h-1, low)
+		n := &node[int, int]{key: k, value: -k, leftChild: left, height: h}
+		right, next := fibonacci(h-2, k+1)
+		n.rightChild = right
+		for _, child := range []*node[int, int]{left, right} {
+			if child != nil {
+				child.parent = n
+			}
+		}
+		return n, next
+	}
+	var size int
+	avl.root, size = fibonacci(8, 0)
+	avl.size = size
+	checkAVLTree(t, avl)
+
+	// the largest key is at the bottom of the chain of right children which
+	// are each the shorter side of their parent
+	assert.Nil(t, avl.Delete(size-1))
+	checkAVLTree(t, avl)
+	assert.Equal(t, 7, avl.root.height)
+}
+
+func TestAVLTree_Height(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000, 10000} {
+		// sorted keys, which would build a path in an unbalanced tree
+		avl := NewAVLTree[int, int]()
+		for k := range n {
+			avl.Put(k, k)
+		}
+		bound := 1.44 * math.Log2(float64(n+2))
+		assert.LessOrEqual(t, float64(avl.root.height), bound, n)
+		assert.True(t, avl.Tree().IsBalanced())
+	}
+}
@@ -0,0 +1,447 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// BPlusTree is a BTree which keeps every entry in its leaves, with the positions above them
+// holding only copies of keys, to route searches down to the right leaf. Each leaf links to the
+// next, so a range scan only searches down the tree once, to find where the range starts, then
+// walks along the leaves, reading their entries as contiguous runs of memory.
+//
+// Key i of a position above the leaves separates its children, every key under child i
+// being smaller than it, and every key under child i+1 at least as big. Positions hold at most
+// m children, and leaves at most m-1 entries, where m is the order of the tree, and other than
+// the root, every position holds at least half as many as it can.
+//
+// Splitting a leaf which overflows copies the first key of the new right half up into the
+// parent, where splitting a position above the leaves moves its middle key up, as in a BTree.
+// A separator can outlive the entry it was copied from, since it only needs to stay between the
+// keys on either side of it, so deleting an entry only touches the positions above the leaf if
+// the leaf underflows, and has to borrow from, or merge with, a sibling.
+type BPlusTree[K cmp.Ordered, V any] struct {
+	root    *bPlusNode[K, V]
+	size    int
+	options bTreeOptions
+}
+
+// bPlusNode is a position in a B+ tree, either a leaf, holding entries as a pair of
+// sorted slices and a link to the next leaf, or a position holding separator keys
+// and the children they separate
+type bPlusNode[K cmp.Ordered, V any] struct {
+	keys     []K
+	values   []V
+	children []*bPlusNode[K, V]
+	next     *bPlusNode[K, V]
+}
+
+func (n *bPlusNode[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+func NewBPlusTree[K cmp.Ordered, V any](opts ...BTreeOpt) *BPlusTree[K, V] {
+	return &BPlusTree[K, V]{options: newBTreeOptions(opts)}
+}
+
+// BPlusTreeFromSorted builds a BPlusTree from keys in strictly increasing order and their
+// values, such as the sorted slices searched with BinarySearch, in O(n) time, dealing the
+// entries out evenly into as few leaves as will hold them, then the leaves between as few
+// parents as will hold them, and so on up to the root, as BTreeFromSorted does
+func BPlusTreeFromSorted[K cmp.Ordered, V any](keys []K, values []V, opts ...BTreeOpt) (*BPlusTree[K, V], error) {
+	if err := checkSorted(keys, values); err != nil {
+		return nil, err
+	}
+	bpt := NewBPlusTree[K, V](opts...)
+	bpt.size = len(keys)
+	if len(keys) == 0 {
+		return bpt, nil
+	}
+
+	var nodes []*bPlusNode[K, V]
+	// lowest holds the smallest key under each position, which separates it from the one before
+	var lowest []K
+	i := 0
+	for _, group := range evenGroups(len(keys), bpt.maxEntries()) {
+		leaf := &bPlusNode[K, V]{
+			keys:   slices.Clone(keys[i : i+group]),
+			values: slices.Clone(values[i : i+group]),
+		}
+		if len(nodes) > 0 {
+			nodes[len(nodes)-1].next = leaf
+		}
+		nodes, lowest = append(nodes, leaf), append(lowest, keys[i])
+		i += group
+	}
+
+	for len(nodes) > 1 {
+		var parents []*bPlusNode[K, V]
+		var parentLowest []K
+		i := 0
+		for _, group := range evenGroups(len(nodes), bpt.options.order) {
+			parents = append(parents, &bPlusNode[K, V]{
+				keys:     slices.Clone(lowest[i+1 : i+group]),
+				children: slices.Clone(nodes[i : i+group]),
+			})
+			parentLowest = append(parentLowest, lowest[i])
+			i += group
+		}
+		nodes, lowest = parents, parentLowest
+	}
+	bpt.root = nodes[0]
+	return bpt, nil
+}
+
+func (bpt *BPlusTree[K, V]) Len() int {
+	return bpt.size
+}
+
+// Height is the number of levels in the tree, where an empty tree has none
+func (bpt *BPlusTree[K, V]) Height() int {
+	height := 0
+	for n := bpt.root; n != nil; n = n.child(0) {
+		height++
+	}
+	return height
+}
+
+// child returns the ith child of the position, or nil if it is a leaf
+func (n *bPlusNode[K, V]) child(i int) *bPlusNode[K, V] {
+	if n.leaf() {
+		return nil
+	}
+	return n.children[i]
+}
+
+func (bpt *BPlusTree[K, V]) maxEntries() int {
+	return bpt.options.order - 1
+}
+
+func (bpt *BPlusTree[K, V]) minEntries() int {
+	return bpt.options.order / 2
+}
+
+func (bpt *BPlusTree[K, V]) minChildren() int {
+	return (bpt.options.order + 1) / 2
+}
+
+// route returns the child of a position above the leaves whose subtree could hold k
+func (n *bPlusNode[K, V]) route(k K) int {
+	return UpperBound(n.keys, k)
+}
+
+// leafFor searches down to the leaf which holds k, if it is anywhere, also returning the
+// closest subtree to the left of the path, which holds the entries just before the leaf
+func (bpt *BPlusTree[K, V]) leafFor(k K) (leaf, before *bPlusNode[K, V]) {
+	leaf = bpt.root
+	for !leaf.leaf() {
+		i := leaf.route(k)
+		if i > 0 {
+			before = leaf.children[i-1]
+		}
+		leaf = leaf.children[i]
+	}
+	return leaf, before
+}
+
+func (bpt *BPlusTree[K, V]) Get(k K) (V, error) {
+	if bpt.root != nil {
+		leaf, _ := bpt.leafFor(k)
+		if i, found := BinarySearch(leaf.keys, k); found {
+			return leaf.values[i], nil
+		}
+	}
+	var zero V
+	return zero, KeyNotFoundError{}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there,
+// splitting any position which overflows on the way back up from the leaf
+func (bpt *BPlusTree[K, V]) Put(k K, v V) {
+	if bpt.root == nil {
+		bpt.root = &bPlusNode[K, V]{}
+	}
+	right, separator := bpt.insert(bpt.root, k, v)
+	if right != nil {
+		bpt.root = &bPlusNode[K, V]{
+			keys:     []K{separator},
+			children: []*bPlusNode[K, V]{bpt.root, right},
+		}
+	}
+}
+
+// insert adds the entry to the subtree at n. If n overflows, it is split in two, returning
+// the new right half, and the key which separates the halves, for the parent to take.
+func (bpt *BPlusTree[K, V]) insert(n *bPlusNode[K, V], k K, v V) (*bPlusNode[K, V], K) {
+	var zero K
+	if n.leaf() {
+		i, found := BinarySearch(n.keys, k)
+		if found {
+			n.values[i] = v
+			return nil, zero
+		}
+		n.keys, n.values = slices.Insert(n.keys, i, k), slices.Insert(n.values, i, v)
+		bpt.size++
+		if len(n.keys) <= bpt.maxEntries() {
+			return nil, zero
+		}
+
+		// the right half keeps the middle entry, and a copy of its key goes up
+		mid := len(n.keys) / 2
+		right := &bPlusNode[K, V]{
+			keys:   slices.Clone(n.keys[mid:]),
+			values: slices.Clone(n.values[mid:]),
+			next:   n.next,
+		}
+		clear(n.keys[mid:])
+		clear(n.values[mid:])
+		n.keys, n.values, n.next = n.keys[:mid], n.values[:mid], right
+		return right, right.keys[0]
+	}
+
+	i := n.route(k)
+	right, separator := bpt.insert(n.children[i], k, v)
+	if right == nil {
+		return nil, zero
+	}
+	n.keys = slices.Insert(n.keys, i, separator)
+	n.children = slices.Insert(n.children, i+1, right)
+	if len(n.children) <= bpt.options.order {
+		return nil, zero
+	}
+
+	// the middle key moves up, with the children either side of it going either way
+	mid := len(n.keys) / 2
+	separator = n.keys[mid]
+	right = &bPlusNode[K, V]{
+		keys:     slices.Clone(n.keys[mid+1:]),
+		children: slices.Clone(n.children[mid+1:]),
+	}
+	clear(n.keys[mid:])
+	clear(n.children[mid+1:])
+	n.keys, n.children = n.keys[:mid], n.children[:mid+1]
+	return right, separator
+}
+
+// Delete removes the entry for the key, fixing any position which underflows
+// on the way back up from the leaf
+func (bpt *BPlusTree[K, V]) Delete(k K) error {
+	if bpt.root == nil || !bpt.delete(bpt.root, k) {
+		return KeyNotFoundError{}
+	}
+	bpt.size--
+	switch {
+	case bpt.root.leaf() && len(bpt.root.keys) == 0:
+		bpt.root = nil
+	case !bpt.root.leaf() && len(bpt.root.children) == 1:
+		bpt.root = bpt.root.children[0]
+	}
+	return nil
+}
+
+// delete removes the entry from the subtree at n, returning whether it was there
+func (bpt *BPlusTree[K, V]) delete(n *bPlusNode[K, V], k K) bool {
+	if n.leaf() {
+		i, found := BinarySearch(n.keys, k)
+		if found {
+			n.keys, n.values = slices.Delete(n.keys, i, i+1), slices.Delete(n.values, i, i+1)
+		}
+		return found
+	}
+	i := n.route(k)
+	if !bpt.delete(n.children[i], k) {
+		return false
+	}
+	bpt.fixUnderflow(n, i)
+	return true
+}
+
+// fixUnderflow tops up the ith child of n if it is less than half full, by borrowing from a
+// sibling which can spare an entry, or a child, or failing that, merging it with a sibling
+func (bpt *BPlusTree[K, V]) fixUnderflow(n *bPlusNode[K, V], i int) {
+	c := n.children[i]
+	spare := func(sibling *bPlusNode[K, V]) bool {
+		if sibling.leaf() {
+			return len(sibling.keys) > bpt.minEntries()
+		}
+		return len(sibling.children) > bpt.minChildren()
+	}
+	if (c.leaf() && len(c.keys) >= bpt.minEntries()) || (!c.leaf() && len(c.children) >= bpt.minChildren()) {
+		return
+	}
+
+	switch {
+	case i > 0 && spare(n.children[i-1]):
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		if c.leaf() {
+			// the last entry of the left sibling moves over, and becomes the new separator
+			c.keys = slices.Insert(c.keys, 0, left.keys[last])
+			c.values = slices.Insert(c.values, 0, left.values[last])
+			left.keys, left.values = left.keys[:last], left.values[:last]
+			n.keys[i-1] = c.keys[0]
+		} else {
+			// the separator moves down in front of the last child of the left sibling,
+			// and the last key of the left sibling moves up to take its place
+			c.keys = slices.Insert(c.keys, 0, n.keys[i-1])
+			c.children = slices.Insert(c.children, 0, left.children[last+1])
+			n.keys[i-1] = left.keys[last]
+			left.keys, left.children = left.keys[:last], left.children[:last+1]
+		}
+	case i < len(n.children)-1 && spare(n.children[i+1]):
+		right := n.children[i+1]
+		if c.leaf() {
+			c.keys, c.values = append(c.keys, right.keys[0]), append(c.values, right.values[0])
+			right.keys, right.values = slices.Delete(right.keys, 0, 1), slices.Delete(right.values, 0, 1)
+			n.keys[i] = right.keys[0]
+		} else {
+			c.keys, c.children = append(c.keys, n.keys[i]), append(c.children, right.children[0])
+			n.keys[i] = right.keys[0]
+			right.keys, right.children = slices.Delete(right.keys, 0, 1), slices.Delete(right.children, 0, 1)
+		}
+	case i > 0:
+		n.merge(i - 1)
+	default:
+		n.merge(i)
+	}
+}
+
+// merge joins the ith and i+1th children of n into the ith child. Leaves just put their
+// entries together, dropping the separator between them, where positions above the leaves
+// take the separator down between their keys, as in a BTree.
+func (n *bPlusNode[K, V]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	if left.leaf() {
+		left.keys, left.values = append(left.keys, right.keys...), append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// first returns the leftmost leaf of the subtree
+func (n *bPlusNode[K, V]) first() *bPlusNode[K, V] {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+// last returns the rightmost leaf of the subtree
+func (n *bPlusNode[K, V]) last() *bPlusNode[K, V] {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
+
+// at returns the ith entry of a leaf
+func (n *bPlusNode[K, V]) at(i int) Entry[K, V] {
+	return entry[K, V]{key: n.keys[i], value: n.values[i]}
+}
+
+func (bpt *BPlusTree[K, V]) Min() (Entry[K, V], error) {
+	if bpt.root == nil {
+		return nil, MapEmptyError{}
+	}
+	return bpt.root.first().at(0), nil
+}
+
+func (bpt *BPlusTree[K, V]) Max() (Entry[K, V], error) {
+	if bpt.root == nil {
+		return nil, MapEmptyError{}
+	}
+	leaf := bpt.root.last()
+	return leaf.at(len(leaf.keys) - 1), nil
+}
+
+func (bpt *BPlusTree[K, V]) Floor(k K) (Entry[K, V], error) {
+	return bpt.before(k, UpperBound[K])
+}
+
+func (bpt *BPlusTree[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return bpt.before(k, LowerBound[K])
+}
+
+func (bpt *BPlusTree[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return bpt.after(k, LowerBound[K])
+}
+
+func (bpt *BPlusTree[K, V]) Successor(k K) (Entry[K, V], error) {
+	return bpt.after(k, UpperBound[K])
+}
+
+// before finds the entry just before the index bound gives in the leaf for k. If that is the
+// first entry of the leaf, the entry wanted is the last one of the closest subtree to the left.
+func (bpt *BPlusTree[K, V]) before(k K, bound func(keys []K, k K) int) (Entry[K, V], error) {
+	if bpt.root == nil {
+		return nil, KeyNotFoundError{}
+	}
+	leaf, before := bpt.leafFor(k)
+	if i := bound(leaf.keys, k); i > 0 {
+		return leaf.at(i - 1), nil
+	}
+	if before == nil {
+		return nil, KeyNotFoundError{}
+	}
+	leaf = before.last()
+	return leaf.at(len(leaf.keys) - 1), nil
+}
+
+// after finds the entry at the index bound gives in the leaf for k,
+// or the first entry of the next leaf if that is past the end of the leaf
+func (bpt *BPlusTree[K, V]) after(k K, bound func(keys []K, k K) int) (Entry[K, V], error) {
+	if bpt.root == nil {
+		return nil, KeyNotFoundError{}
+	}
+	leaf, _ := bpt.leafFor(k)
+	i := bound(leaf.keys, k)
+	if i == len(leaf.keys) {
+		leaf, i = leaf.next, 0
+	}
+	if leaf == nil {
+		return nil, KeyNotFoundError{}
+	}
+	return leaf.at(i), nil
+}
+
+// All iterates over the entries in order of their keys, along the linked leaves
+func (bpt *BPlusTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bpt.root == nil {
+			return
+		}
+		bpt.scan(bpt.root.first(), 0, nil, yield)
+	}
+}
+
+// Range iterates in order over the entries with keys from low up to but not including high,
+// searching down to the leaf for low, then walking along the leaves from there
+func (bpt *BPlusTree[K, V]) Range(low, high K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bpt.root == nil {
+			return
+		}
+		leaf, _ := bpt.leafFor(low)
+		bpt.scan(leaf, LowerBound(leaf.keys, low), &high, yield)
+	}
+}
+
+// scan yields the entries from the ith entry of the leaf onwards,
+// up to but not including high, where nil means unbounded
+func (bpt *BPlusTree[K, V]) scan(leaf *bPlusNode[K, V], i int, high *K, yield func(K, V) bool) {
+	for ; leaf != nil; leaf, i = leaf.next, 0 {
+		for ; i < len(leaf.keys); i++ {
+			if high != nil && leaf.keys[i] >= *high {
+				return
+			}
+			if !yield(leaf.keys[i], leaf.values[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package binarysearchtrees
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkBPlusTree verifies every separator falls between the keys of the children either side
+// of it, with every position other than the root at least half full, and every leaf at the
+// same depth, then that linking the leaves together visits every entry in order
+func checkBPlusTree(t *testing.T, bpt *BPlusTree[int, int]) {
+	if bpt.root == nil {
+		assert.Equal(t, 0, bpt.size)
+		return
+	}
+	var leaves []*bPlusNode[int, int]
+	checkBPlusSubtree(t, bpt, bpt.root, nil, nil, &leaves)
+
+	size := 0
+	for i, leaf := range leaves {
+		size += len(leaf.keys)
+		if i < len(leaves)-1 {
+			assert.Equal(t, leaves[i+1], leaf.next)
+		} else {
+			assert.Nil(t, leaf.next)
+		}
+	}
+	assert.Equal(t, bpt.size, size)
+	assert.NotEmpty(t, bpt.root.keys, "a root with no keys should have been collapsed or dropped")
+}
+
+func checkBPlusSubtree(t *testing.T, bpt *BPlusTree[int, int], n *bPlusNode[int, int], low, high *int, leaves *[]*bPlusNode[int, int]) int {
+	assert.True(t, slices.IsSorted(n.keys))
+	// the keys are sorted, so only the first and last need checking against the bounds
+	if low != nil && len(n.keys) > 0 {
+		assert.GreaterOrEqual(t, n.keys[0], *low)
+	}
+	if high != nil && len(n.keys) > 0 {
+		assert.Less(t, n.keys[len(n.keys)-1], *high)
+	}
+	if n.leaf() {
+		assert.Len(t, n.values, len(n.keys))
+		assert.LessOrEqual(t, len(n.keys), bpt.options.order-1)
+		if n != bpt.root {
+			assert.GreaterOrEqual(t, len(n.keys), bpt.options.order/2)
+		}
+		*leaves = append(*leaves, n)
+		return 1
+	}
+
+	assert.Empty(t, n.values)
+	assert.Len(t, n.children, len(n.keys)+1)
+	assert.LessOrEqual(t, len(n.children), bpt.options.order)
+	if n != bpt.root {
+		assert.GreaterOrEqual(t, len(n.children), (bpt.options.order+1)/2)
+	}
+	height := 0
+	for i, child := range n.children {
+		childLow, childHigh := low, high
+		if i > 0 {
+			childLow = &n.keys[i-1]
+		}
+		if i < len(n.keys) {
+			childHigh = &n.keys[i]
+		}
+		childHeight := checkBPlusSubtree(t, bpt, child, childLow, childHigh, leaves)
+		if i > 0 {
+			assert.Equal(t, height, childHeight+1, "every leaf is at the same depth")
+		}
+		height = childHeight + 1
+	}
+	return height
+}
+
+func TestBPlusTree(t *testing.T) {
+	for _, order := range orders {
+		t.Run(fmt.Sprintf("order %d", order), func(t *testing.T) {
+			testOrderedMap(t, func() *BPlusTree[int, int] { return NewBPlusTree[int, int](WithOrder(order)) }, checkBPlusTree)
+		})
+	}
+}
+
+func TestBPlusTree_SplitMerge(t *testing.T) {
+	assert := assert.New(t)
+	bpt := NewBPlusTree[int, int](WithOrder(3))
+	for k := 1; k <= 5; k++ {
+		bpt.Put(k, -k)
+	}
+	// splitting a leaf copies the first key of the right half up, which stays in the leaf
+	assert.Equal([]int{3}, bpt.root.keys)
+	assert.Equal([]int{2}, bpt.root.children[0].keys)
+	assert.Equal([]int{4}, bpt.root.children[1].keys)
+	assert.Equal([]int{3}, bpt.root.children[1].children[0].keys)
+	assert.Equal(3, bpt.Height())
+	checkBPlusTree(t, bpt)
+
+	// deleting the entry a separator was copied from leaves the separator where it is
+	assert.Nil(bpt.Delete(3))
+	checkBPlusTree(t, bpt)
+	assert.Equal([]int{3}, bpt.root.keys)
+	_, err := bpt.Get(3)
+	assert.ErrorIs(err, KeyNotFoundError{})
+	e, err := bpt.Floor(3)
+	assert.Nil(err)
+	assert.Equal(2, e.Key())
+	e, err = bpt.Ceiling(3)
+	assert.Nil(err)
+	assert.Equal(4, e.Key())
+
+	for _, k := range []int{1, 2, 4, 5} {
+		assert.Nil(bpt.Delete(k))
+		checkBPlusTree(t, bpt)
+	}
+	assert.Nil(bpt.root)
+	assert.Equal(0, bpt.Height())
+}
+
+func TestBPlusTreeFromSorted(t *testing.T) {
+	for _, order := range orders {
+		for n := 0; n <= 300; n++ {
+			keys := sequence(n)
+			values := make([]int, n)
+			for i, k := range keys {
+				values[i] = -k
+			}
+			bpt, err := BPlusTreeFromSorted(keys, values, WithOrder(order))
+			assert.Nil(t, err)
+			checkBPlusTree(t, bpt)
+			model := &modelMap{keys: keys, values: values}
+			assertMatchesModelMap(t, model, bpt)
+
+			bpt.Put(n, -n)
+			model.put(n, -n)
+			if n > 0 {
+				assert.Nil(t, bpt.Delete(n/2))
+				model.delete(n / 2)
+			}
+			checkBPlusTree(t, bpt)
+			assertMatchesModelMap(t, model, bpt)
+		}
+	}
+
+	_, err := BPlusTreeFromSorted([]int{2, 1}, []int{1, 2})
+	assert.ErrorIs(t, err, UnsortedKeysError{})
+	_, err = BPlusTreeFromSorted([]int{1}, []int{1, 2})
+	assert.ErrorIs(t, err, KeyValueMismatchError{})
+}
+
+func TestBPlusTree_Range(t *testing.T) {
+	for _, order := range orders {
+		testRange(t, func() *BPlusTree[int, int] { return NewBPlusTree[int, int](WithOrder(order)) })
+	}
+}
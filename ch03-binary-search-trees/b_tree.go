@@ -0,0 +1,488 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// BTree is an OrderedMap kept as a multiway search tree, where each position holds up to
+// m-1 sorted keys, and, unless it is a leaf, one child more than it has keys, with the keys
+// of child i falling between keys i-1 and i of the position. m is the order of the tree.
+//
+// Every position other than the root holds at least ceil(m/2)-1 keys, and every leaf is at
+// the same depth, so the height of a B-tree of n keys is at most about log_{m/2}(n). With an
+// order in the tens or hundreds, that is a handful of levels even for millions of keys.
+// Each position is a pair of sorted slices, searched with BinarySearch, so a search reads a
+// few contiguous runs of memory instead of following a pointer to a new, likely uncached,
+// position at every one of the roughly log2(n) levels of a binary search tree.
+//
+// A B-tree grows upwards rather than downwards. A new key goes into the leaf where a search
+// for it ends, and a position which overflows, with m keys, is split around its middle key,
+// which moves up into the parent, possibly overflowing it in turn. Only splitting the root
+// adds a level, above every leaf at once. Deleting runs the other way: a position which
+// underflows borrows a key through its parent from a sibling which can spare one, or if
+// neither can, is merged with a sibling and the key between them in the parent, possibly
+// underflowing the parent in turn. Only merging the last two children of the root
+// removes a level.
+type BTree[K cmp.Ordered, V any] struct {
+	root    *bTreeNode[K, V]
+	size    int
+	options bTreeOptions
+}
+
+// bTreeNode is a position in a B-tree, holding its entries as a pair of sorted slices,
+// and its children, if it is not a leaf
+type bTreeNode[K cmp.Ordered, V any] struct {
+	keys     []K
+	values   []V
+	children []*bTreeNode[K, V]
+}
+
+func (n *bTreeNode[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// DefaultBTreeOrder gives positions of a few cache lines for small keys and values
+const DefaultBTreeOrder = 32
+
+type bTreeOptions struct {
+	order int
+}
+
+type BTreeOpt func(opts *bTreeOptions)
+
+// WithOrder sets the order m of a BTree or BPlusTree, the most children a position can have.
+// Orders below 3 are ignored, since a position must be able to split into two.
+func WithOrder(m int) BTreeOpt {
+	return func(opts *bTreeOptions) {
+		if m >= 3 {
+			opts.order = m
+		}
+	}
+}
+
+func newBTreeOptions(opts []BTreeOpt) bTreeOptions {
+	options := bTreeOptions{order: DefaultBTreeOrder}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func NewBTree[K cmp.Ordered, V any](opts ...BTreeOpt) *BTree[K, V] {
+	return &BTree[K, V]{options: newBTreeOptions(opts)}
+}
+
+// BTreeFromSorted builds a BTree from keys in strictly increasing order and their values,
+// such as the sorted slices searched with BinarySearch, in O(n) time.
+//
+// Building a tree bottom-up beats adding the keys one at a time, which searches the tree
+// and sometimes splits positions for every key. The keys are dealt out into as few leaves as
+// will hold them, with a key held back between each pair of leaves to separate them. Those
+// keys are then dealt out between as few parents as will hold the leaves, with a key held
+// back between each pair of parents, and so on up to the root. Spreading the keys evenly
+// rather than filling each position in turn keeps every position at least half full.
+func BTreeFromSorted[K cmp.Ordered, V any](keys []K, values []V, opts ...BTreeOpt) (*BTree[K, V], error) {
+	if err := checkSorted(keys, values); err != nil {
+		return nil, err
+	}
+	bt := NewBTree[K, V](opts...)
+	bt.size = len(keys)
+	if len(keys) == 0 {
+		return bt, nil
+	}
+
+	// a leaf with k keys takes up k+1 of the keys, counting the key held back after it,
+	// and the last leaf takes up an extra key that isn't there
+	var nodes []*bTreeNode[K, V]
+	var sepKeys []K
+	var sepValues []V
+	i := 0
+	for _, group := range evenGroups(len(keys)+1, bt.options.order) {
+		nodes = append(nodes, &bTreeNode[K, V]{
+			keys:   slices.Clone(keys[i : i+group-1]),
+			values: slices.Clone(values[i : i+group-1]),
+		})
+		i += group - 1
+		if i < len(keys) {
+			sepKeys, sepValues = append(sepKeys, keys[i]), append(sepValues, values[i])
+			i++
+		}
+	}
+
+	// then each level up groups the positions of the level below, taking the keys between
+	// the positions of each group, and holding back the key after the group
+	for len(nodes) > 1 {
+		var parents []*bTreeNode[K, V]
+		var parentKeys []K
+		var parentValues []V
+		i := 0
+		for _, group := range evenGroups(len(nodes), bt.options.order) {
+			parents = append(parents, &bTreeNode[K, V]{
+				keys:     slices.Clone(sepKeys[i : i+group-1]),
+				values:   slices.Clone(sepValues[i : i+group-1]),
+				children: slices.Clone(nodes[i : i+group]),
+			})
+			if i+group-1 < len(sepKeys) {
+				parentKeys = append(parentKeys, sepKeys[i+group-1])
+				parentValues = append(parentValues, sepValues[i+group-1])
+			}
+			i += group
+		}
+		nodes, sepKeys, sepValues = parents, parentKeys, parentValues
+	}
+	bt.root = nodes[0]
+	return bt, nil
+}
+
+// evenGroups splits n units into as few groups of at most capacity units as possible,
+// with the group sizes as even as possible, so each group holds at least half the capacity,
+// rounded up, unless there is only one group
+func evenGroups(n, capacity int) []int {
+	count := (n + capacity - 1) / capacity
+	groups := make([]int, count)
+	for i := range groups {
+		groups[i] = n / count
+		if i < n%count {
+			groups[i]++
+		}
+	}
+	return groups
+}
+
+// checkSorted checks the keys and values can be bulk loaded into a tree
+func checkSorted[K cmp.Ordered, V any](keys []K, values []V) error {
+	if len(keys) != len(values) {
+		return KeyValueMismatchError{}
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			return UnsortedKeysError{}
+		}
+	}
+	return nil
+}
+
+func (bt *BTree[K, V]) Len() int {
+	return bt.size
+}
+
+// Height is the number of levels in the tree, where an empty tree has none
+func (bt *BTree[K, V]) Height() int {
+	height := 0
+	for n := bt.root; n != nil; n = n.child(0) {
+		height++
+	}
+	return height
+}
+
+// child returns the ith child of the position, or nil if it is a leaf
+func (n *bTreeNode[K, V]) child(i int) *bTreeNode[K, V] {
+	if n.leaf() {
+		return nil
+	}
+	return n.children[i]
+}
+
+func (bt *BTree[K, V]) maxKeys() int {
+	return bt.options.order - 1
+}
+
+func (bt *BTree[K, V]) minKeys() int {
+	return (bt.options.order+1)/2 - 1
+}
+
+func (bt *BTree[K, V]) Get(k K) (V, error) {
+	for n := bt.root; n != nil; {
+		i, found := BinarySearch(n.keys, k)
+		if found {
+			return n.values[i], nil
+		}
+		n = n.child(i)
+	}
+	var zero V
+	return zero, KeyNotFoundError{}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there,
+// splitting any position which overflows on the way back up from the leaf
+func (bt *BTree[K, V]) Put(k K, v V) {
+	if bt.root == nil {
+		bt.root = &bTreeNode[K, V]{}
+	}
+	right, middleKey, middleValue := bt.insert(bt.root, k, v)
+	if right != nil {
+		bt.root = &bTreeNode[K, V]{
+			keys:     []K{middleKey},
+			values:   []V{middleValue},
+			children: []*bTreeNode[K, V]{bt.root, right},
+		}
+	}
+}
+
+// insert adds the entry to the subtree at n. If n overflows, it is split in two, returning
+// the new right half, and the middle entry which separates the halves, for the parent to take.
+func (bt *BTree[K, V]) insert(n *bTreeNode[K, V], k K, v V) (*bTreeNode[K, V], K, V) {
+	i, found := BinarySearch(n.keys, k)
+	if found {
+		n.values[i] = v
+		var zeroK K
+		var zeroV V
+		return nil, zeroK, zeroV
+	}
+	if n.leaf() {
+		n.keys, n.values = slices.Insert(n.keys, i, k), slices.Insert(n.values, i, v)
+		bt.size++
+	} else {
+		right, middleKey, middleValue := bt.insert(n.children[i], k, v)
+		if right != nil {
+			n.keys = slices.Insert(n.keys, i, middleKey)
+			n.values = slices.Insert(n.values, i, middleValue)
+			n.children = slices.Insert(n.children, i+1, right)
+		}
+	}
+	if len(n.keys) <= bt.maxKeys() {
+		var zeroK K
+		var zeroV V
+		return nil, zeroK, zeroV
+	}
+	return n.split()
+}
+
+// split moves the keys after the middle key, and the children after it, into a new position,
+// leaving the left half, of at least as many keys as the right half, in n
+func (n *bTreeNode[K, V]) split() (*bTreeNode[K, V], K, V) {
+	mid := len(n.keys) / 2
+	middleKey, middleValue := n.keys[mid], n.values[mid]
+	right := &bTreeNode[K, V]{
+		keys:   slices.Clone(n.keys[mid+1:]),
+		values: slices.Clone(n.values[mid+1:]),
+	}
+	// clear out what moved, so the tail of the slices doesn't hold on to it
+	clear(n.keys[mid:])
+	clear(n.values[mid:])
+	n.keys, n.values = n.keys[:mid], n.values[:mid]
+	if !n.leaf() {
+		right.children = slices.Clone(n.children[mid+1:])
+		clear(n.children[mid+1:])
+		n.children = n.children[:mid+1]
+	}
+	return right, middleKey, middleValue
+}
+
+// Delete removes the entry for the key, fixing any position which underflows
+// on the way back up from the leaf
+func (bt *BTree[K, V]) Delete(k K) error {
+	if bt.root == nil || !bt.delete(bt.root, k) {
+		return KeyNotFoundError{}
+	}
+	bt.size--
+	if len(bt.root.keys) == 0 {
+		// the root has either run out of keys altogether, or merged its last two children
+		bt.root = bt.root.child(0)
+	}
+	return nil
+}
+
+// delete removes the entry from the subtree at n, returning whether it was there.
+// An entry in a position with children is replaced by its predecessor, the largest entry
+// in the child to its left, which is always in a leaf, and is deleted from there instead.
+func (bt *BTree[K, V]) delete(n *bTreeNode[K, V], k K) bool {
+	i, found := BinarySearch(n.keys, k)
+	if n.leaf() {
+		if found {
+			n.keys, n.values = slices.Delete(n.keys, i, i+1), slices.Delete(n.values, i, i+1)
+		}
+		return found
+	}
+
+	if found {
+		predecessor := n.children[i]
+		for !predecessor.leaf() {
+			predecessor = predecessor.children[len(predecessor.children)-1]
+		}
+		last := len(predecessor.keys) - 1
+		n.keys[i], n.values[i] = predecessor.keys[last], predecessor.values[last]
+		k = n.keys[i]
+	}
+	if !bt.delete(n.children[i], k) {
+		return false
+	}
+	bt.fixUnderflow(n, i)
+	return true
+}
+
+// fixUnderflow tops up the ith child of n if it has too few keys, by rotating a key through
+// n from a sibling which can spare one, or failing that, merging it with a sibling
+func (bt *BTree[K, V]) fixUnderflow(n *bTreeNode[K, V], i int) {
+	c := n.children[i]
+	if len(c.keys) >= bt.minKeys() {
+		return
+	}
+	switch {
+	case i > 0 && len(n.children[i-1].keys) > bt.minKeys():
+		// the separating key moves down to the front of c,
+		// and the last key of the left sibling moves up to take its place
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		c.keys = slices.Insert(c.keys, 0, n.keys[i-1])
+		c.values = slices.Insert(c.values, 0, n.values[i-1])
+		n.keys[i-1], n.values[i-1] = left.keys[last], left.values[last]
+		left.keys, left.values = left.keys[:last], left.values[:last]
+		if !left.leaf() {
+			c.children = slices.Insert(c.children, 0, left.children[last+1])
+			left.children = left.children[:last+1]
+		}
+	case i < len(n.children)-1 && len(n.children[i+1].keys) > bt.minKeys():
+		right := n.children[i+1]
+		c.keys, c.values = append(c.keys, n.keys[i]), append(c.values, n.values[i])
+		n.keys[i], n.values[i] = right.keys[0], right.values[0]
+		right.keys, right.values = slices.Delete(right.keys, 0, 1), slices.Delete(right.values, 0, 1)
+		if !right.leaf() {
+			c.children = append(c.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+	case i > 0:
+		n.merge(i - 1)
+	default:
+		n.merge(i)
+	}
+}
+
+// merge joins the ith and i+1th children of n, along with the key between them,
+// into the ith child, which together hold at most m-1 keys when one has underflowed
+func (n *bTreeNode[K, V]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.values = append(append(left.values, n.values[i]), right.values...)
+	left.children = append(left.children, right.children...)
+	n.keys, n.values = slices.Delete(n.keys, i, i+1), slices.Delete(n.values, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+func (bt *BTree[K, V]) Min() (Entry[K, V], error) {
+	if bt.root == nil {
+		return nil, MapEmptyError{}
+	}
+	n := bt.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return entry[K, V]{key: n.keys[0], value: n.values[0]}, nil
+}
+
+func (bt *BTree[K, V]) Max() (Entry[K, V], error) {
+	if bt.root == nil {
+		return nil, MapEmptyError{}
+	}
+	n := bt.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	last := len(n.keys) - 1
+	return entry[K, V]{key: n.keys[last], value: n.values[last]}, nil
+}
+
+// Floor searches down from the root, where the last key smaller than or equal to k in each
+// position passed is a candidate, and any candidate further down is closer to k
+func (bt *BTree[K, V]) Floor(k K) (Entry[K, V], error) {
+	return bt.before(func(keys []K) int { return UpperBound(keys, k) })
+}
+
+func (bt *BTree[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return bt.before(func(keys []K) int { return LowerBound(keys, k) })
+}
+
+func (bt *BTree[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return bt.after(func(keys []K) int { return LowerBound(keys, k) })
+}
+
+func (bt *BTree[K, V]) Successor(k K) (Entry[K, V], error) {
+	return bt.after(func(keys []K) int { return UpperBound(keys, k) })
+}
+
+// before finds the entry just before the index bound gives in each position,
+// which is also the child the search carries on into
+func (bt *BTree[K, V]) before(bound func(keys []K) int) (Entry[K, V], error) {
+	var candidate Entry[K, V]
+	for n := bt.root; n != nil; {
+		i := bound(n.keys)
+		if i > 0 {
+			candidate = entry[K, V]{key: n.keys[i-1], value: n.values[i-1]}
+		}
+		n = n.child(i)
+	}
+	if candidate == nil {
+		return nil, KeyNotFoundError{}
+	}
+	return candidate, nil
+}
+
+// after finds the entry at the index bound gives in each position
+func (bt *BTree[K, V]) after(bound func(keys []K) int) (Entry[K, V], error) {
+	var candidate Entry[K, V]
+	for n := bt.root; n != nil; {
+		i := bound(n.keys)
+		if i < len(n.keys) {
+			candidate = entry[K, V]{key: n.keys[i], value: n.values[i]}
+		}
+		n = n.child(i)
+	}
+	if candidate == nil {
+		return nil, KeyNotFoundError{}
+	}
+	return candidate, nil
+}
+
+// All iterates over the entries in order of their keys
+func (bt *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.root.inOrder(nil, nil, yield)
+		}
+	}
+}
+
+// Range iterates in order over the entries with keys from low up to but not including high,
+// only visiting the positions which can hold them
+func (bt *BTree[K, V]) Range(low, high K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.root.inOrder(&low, &high, yield)
+		}
+	}
+}
+
+// inOrder yields the entries of the subtree from low up to high, where nil means unbounded,
+// returning false once yield asks to stop
+func (n *bTreeNode[K, V]) inOrder(low, high *K, yield func(K, V) bool) bool {
+	i := 0
+	if low != nil {
+		i = LowerBound(n.keys, *low)
+	}
+	for ; i <= len(n.keys); i++ {
+		if !n.leaf() && !n.children[i].inOrder(low, high, yield) {
+			return false
+		}
+		if i == len(n.keys) || (high != nil && n.keys[i] >= *high) {
+			return true
+		}
+		if !yield(n.keys[i], n.values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type KeyValueMismatchError struct{}
+
+func (e KeyValueMismatchError) Error() string {
+	return "keys and values must be the same length"
+}
+
+type UnsortedKeysError struct{}
+
+func (e UnsortedKeysError) Error() string {
+	return "keys must be in strictly increasing order"
+}
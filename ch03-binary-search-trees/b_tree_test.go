@@ -0,0 +1,335 @@
+package binarysearchtrees
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orders covers the smallest orders, where positions split and merge all the time,
+// odd and even orders, which split unevenly and evenly, and a more typical order
+var orders = []int{3, 4, 5, 64}
+
+// checkBTree verifies every position holds a sorted run of keys, falling between the keys
+// either side of it in its parent, with one more child than keys, and between ceil(m/2)-1 and
+// m-1 keys unless it is the root, then that every leaf is at the same depth and the size is right
+func checkBTree(t *testing.T, bt *BTree[int, int]) {
+	if bt.root == nil {
+		assert.Equal(t, 0, bt.size)
+		return
+	}
+	size, _ := checkBTreeSubtree(t, bt, bt.root, nil, nil)
+	assert.Equal(t, bt.size, size)
+	assert.NotEmpty(t, bt.root.keys)
+}
+
+func checkBTreeSubtree(t *testing.T, bt *BTree[int, int], n *bTreeNode[int, int], low, high *int) (size, height int) {
+	assert.Len(t, n.values, len(n.keys))
+	assert.LessOrEqual(t, len(n.keys), bt.options.order-1)
+	if n != bt.root {
+		assert.GreaterOrEqual(t, len(n.keys), (bt.options.order+1)/2-1)
+	}
+	assert.True(t, slices.IsSorted(n.keys))
+	// the keys are sorted, so only the first and last need checking against the bounds
+	if low != nil && len(n.keys) > 0 {
+		assert.Greater(t, n.keys[0], *low)
+	}
+	if high != nil && len(n.keys) > 0 {
+		assert.Less(t, n.keys[len(n.keys)-1], *high)
+	}
+	if n.leaf() {
+		return len(n.keys), 1
+	}
+
+	assert.Len(t, n.children, len(n.keys)+1)
+	size = len(n.keys)
+	for i, child := range n.children {
+		childLow, childHigh := low, high
+		if i > 0 {
+			childLow = &n.keys[i-1]
+		}
+		if i < len(n.keys) {
+			childHigh = &n.keys[i]
+		}
+		childSize, childHeight := checkBTreeSubtree(t, bt, child, childLow, childHigh)
+		if i > 0 {
+			assert.Equal(t, height, childHeight+1, "every leaf is at the same depth")
+		}
+		size, height = size+childSize, childHeight+1
+	}
+	return size, height
+}
+
+func TestBTree(t *testing.T) {
+	for _, order := range orders {
+		t.Run(fmt.Sprintf("order %d", order), func(t *testing.T) {
+			testOrderedMap(t, func() *BTree[int, int] { return NewBTree[int, int](WithOrder(order)) }, checkBTree)
+		})
+	}
+
+	// orders too small to split are ignored
+	assert.Equal(t, DefaultBTreeOrder, NewBTree[int, int](WithOrder(2)).options.order)
+}
+
+func TestBTree_SplitMerge(t *testing.T) {
+	assert := assert.New(t)
+	bt := NewBTree[int, int](WithOrder(3))
+	for k := 1; k <= 7; k++ {
+		bt.Put(k, -k)
+	}
+	// adding keys in order to a 2-3 tree splits the rightmost leaf each time it gets 3 keys
+	assert.Equal([]int{4}, bt.root.keys)
+	assert.Equal([]int{2}, bt.root.children[0].keys)
+	assert.Equal([]int{6}, bt.root.children[1].keys)
+	assert.Equal(3, bt.Height())
+	checkBTree(t, bt)
+
+	// every position has as few keys as it can, so deleting a leaf key merges all the way up
+	assert.Nil(bt.Delete(1))
+	assert.Equal(2, bt.Height())
+	assert.Equal([]int{4, 6}, bt.root.keys)
+	assert.Equal([]int{2, 3}, bt.root.children[0].keys)
+	checkBTree(t, bt)
+
+	// a leaf whose sibling can spare a key borrows one through the parent
+	assert.Nil(bt.Delete(5))
+	assert.Equal([]int{3, 6}, bt.root.keys)
+	assert.Equal([]int{4}, bt.root.children[1].keys)
+	checkBTree(t, bt)
+
+	// a key in a position above the leaves is replaced by its predecessor,
+	// which empties the leaf it came from, leaving it to merge with its left sibling
+	assert.Nil(bt.Delete(6))
+	assert.Equal([]int{4}, bt.root.keys)
+	assert.Equal([]int{2, 3}, bt.root.children[0].keys)
+	assert.Equal([]int{7}, bt.root.children[1].keys)
+	checkBTree(t, bt)
+
+	for _, k := range []int{2, 3, 4, 7} {
+		assert.Nil(bt.Delete(k))
+		checkBTree(t, bt)
+	}
+	assert.Nil(bt.root)
+	assert.Equal(0, bt.Height())
+}
+
+func TestBTreeFromSorted(t *testing.T) {
+	for _, order := range orders {
+		for n := 0; n <= 300; n++ {
+			keys := sequence(n)
+			values := make([]int, n)
+			for i, k := range keys {
+				values[i] = -k
+			}
+			bt, err := BTreeFromSorted(keys, values, WithOrder(order))
+			assert.Nil(t, err)
+			checkBTree(t, bt)
+			model := &modelMap{keys: keys, values: values}
+			assertMatchesModelMap(t, model, bt)
+
+			// the tree built should carry on working like any other
+			bt.Put(n, -n)
+			model.put(n, -n)
+			if n > 0 {
+				assert.Nil(t, bt.Delete(n/2))
+				model.delete(n / 2)
+			}
+			checkBTree(t, bt)
+			assertMatchesModelMap(t, model, bt)
+		}
+	}
+
+	// the keys are copied, so the tree doesn't change with the slices
+	keys, values := []int{1, 2, 3}, []int{1, 2, 3}
+	bt, _ := BTreeFromSorted(keys, values)
+	keys[0], values[0] = 10, 10
+	v, err := bt.Get(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = BTreeFromSorted([]int{1, 3, 2}, []int{1, 2, 3})
+	assert.ErrorIs(t, err, UnsortedKeysError{})
+	_, err = BTreeFromSorted([]int{1, 1}, []int{1, 2})
+	assert.ErrorIs(t, err, UnsortedKeysError{})
+	_, err = BTreeFromSorted([]int{1, 2}, []int{1})
+	assert.ErrorIs(t, err, KeyValueMismatchError{})
+}
+
+func TestEvenGroups(t *testing.T) {
+	assert.Equal(t, []int{3}, evenGroups(3, 4))
+	assert.Equal(t, []int{4}, evenGroups(4, 4))
+	assert.Equal(t, []int{3, 2}, evenGroups(5, 4))
+	assert.Equal(t, []int{4, 4, 3}, evenGroups(11, 4))
+	for capacity := 3; capacity <= 10; capacity++ {
+		for n := 1; n <= 100; n++ {
+			groups := evenGroups(n, capacity)
+			total := 0
+			for _, group := range groups {
+				total += group
+				assert.LessOrEqual(t, group, capacity)
+				if len(groups) > 1 {
+					assert.GreaterOrEqual(t, group, (capacity+1)/2)
+				}
+			}
+			assert.Equal(t, n, total)
+		}
+	}
+}
+
+// rangeMap is an ordered map which can iterate over a range of keys
+type rangeMap interface {
+	OrderedMap[int, int]
+	Range(low, high int) iter.Seq2[int, int]
+}
+
+func testRange[M rangeMap](t *testing.T, newMap func() M) {
+	r := rand.New(rand.NewSource(49))
+	om := newMap()
+	model := &modelMap{}
+	for range 500 {
+		k := r.Intn(1000)
+		om.Put(k, -k)
+		model.put(k, -k)
+	}
+
+	for range 300 {
+		low, high := r.Intn(1100)-50, r.Intn(1100)-50
+		keys, values := []int{}, []int{}
+		for k, v := range om.Range(low, high) {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		from, to := LowerBound(model.keys, low), max(LowerBound(model.keys, low), LowerBound(model.keys, high))
+		assert.Equal(t, append([]int{}, model.keys[from:to]...), keys, "[%d, %d)", low, high)
+		assert.Equal(t, append([]int{}, model.values[from:to]...), values)
+	}
+
+	// breaking out of the loop stops the iteration
+	count := 0
+	for range om.Range(0, 1000) {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, count)
+
+	for range newMap().Range(0, 10) {
+		assert.Fail(t, "an empty map has nothing in range")
+	}
+}
+
+func TestBTree_Range(t *testing.T) {
+	for _, order := range orders {
+		testRange(t, func() *BTree[int, int] { return NewBTree[int, int](WithOrder(order)) })
+	}
+}
+
+// rangeMaps are the maps compared in the range scan benchmarks
+var rangeMaps = []struct {
+	name  string
+	build func(keys []int) rangeMap
+}{
+	{name: "b-tree", build: func(keys []int) rangeMap {
+		bt, _ := BTreeFromSorted(keys, keys)
+		return bt
+	}},
+	{name: "b+ tree", build: func(keys []int) rangeMap {
+		bpt, _ := BPlusTreeFromSorted(keys, keys)
+		return bpt
+	}},
+}
+
+// BenchmarkRange scans ranges of keys, where the binary trees take a successor step for each
+// key, searching down from the root, against the B-trees reading runs of keys
+func BenchmarkRange(b *testing.B) {
+	const n, width = 1 << 20, 1000
+	keys := sequence(n)
+	r := rand.New(rand.NewSource(49))
+	avl, rb := NewAVLTree[int, int](), NewRedBlackTree[int, int]()
+	for _, k := range r.Perm(n) {
+		avl.Put(k, k)
+		rb.Put(k, k)
+	}
+	binaryTrees := []struct {
+		name string
+		m    OrderedMap[int, int]
+	}{
+		{name: "avl tree", m: avl},
+		{name: "red-black tree", m: rb},
+	}
+
+	for _, tree := range binaryTrees {
+		b.Run(tree.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				low := (i * 7919) % (n - width)
+				e, _ := tree.m.Ceiling(low)
+				for j := 1; j < width; j++ {
+					e, _ = tree.m.Successor(e.Key())
+				}
+			}
+		})
+	}
+	for _, m := range rangeMaps {
+		om := m.build(keys)
+		b.Run(m.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				low := (i * 7919) % (n - width)
+				for range om.Range(low, low+width) {
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLargeGet looks up random keys in maps far too big to fit in cache,
+// where following a pointer to each new position is most likely to miss it
+func BenchmarkLargeGet(b *testing.B) {
+	const n = 1 << 20
+	r := rand.New(rand.NewSource(49))
+	targets := r.Perm(n)
+	maps := []struct {
+		name string
+		m    benchmarkMap
+	}{
+		{name: "avl tree", m: NewAVLTree[int, int]()},
+		{name: "red-black tree", m: NewRedBlackTree[int, int]()},
+		{name: "b-tree", m: NewBTree[int, int]()},
+		{name: "b+ tree", m: NewBPlusTree[int, int]()},
+	}
+	for _, m := range maps {
+		for _, k := range r.Perm(n) {
+			m.m.Put(k, k)
+		}
+		b.Run(m.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = m.m.Get(targets[i%n])
+			}
+		})
+	}
+}
+
+// BenchmarkBulkLoad compares building a tree from sorted keys against adding them one at a time
+func BenchmarkBulkLoad(b *testing.B) {
+	const n = 1 << 16
+	keys := sequence(n)
+	b.Run("b-tree put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bt := NewBTree[int, int]()
+			for _, k := range keys {
+				bt.Put(k, k)
+			}
+		}
+	})
+	for _, m := range rangeMaps {
+		b.Run(m.name+" from sorted", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.build(keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package binarysearchtrees
+
+import "cmp"
+
+// BinarySearchTree is an OrderedMap stored in a binary tree where every key in the left
+// subtree of a position is less than the key at the position, and every key in the right
+// subtree is greater, so an in-order traversal visits the keys in sorted order.
+//
+// Looking up a key starts at the root and goes left or right at each position depending on
+// whether the key is less than or greater than the key there, the same way binary search
+// halves a sorted slice. Unlike a sorted slice though, inserting or deleting a key only
+// needs to change a few links, rather than shifting everything after it along.
+//
+// Every operation takes time proportional to the height of the tree. Nothing is done to
+// keep the tree balanced, so while keys inserted in random order give a height of
+// O(log n) on average, keys inserted in sorted order give a path of height n.
+type BinarySearchTree[K cmp.Ordered, V any] struct {
+	treeMap[K, V]
+}
+
+func NewBinarySearchTree[K cmp.Ordered, V any]() *BinarySearchTree[K, V] {
+	return &BinarySearchTree[K, V]{}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there
+func (bst *BinarySearchTree[K, V]) Put(k K, v V) {
+	bst.insert(k, v)
+}
+
+// Delete removes the entry for the key, replacing a position with two children
+// by its successor
+func (bst *BinarySearchTree[K, V]) Delete(k K) error {
+	_, err := bst.remove(k)
+	return err
+}
@@ -0,0 +1,86 @@
+package binarysearchtrees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checkBinarySearchTree(t *testing.T, bst *BinarySearchTree[int, int]) {
+	checkTreeMap(t, &bst.treeMap)
+}
+
+func TestBinarySearchTree(t *testing.T) {
+	testOrderedMap(t, NewBinarySearchTree[int, int], checkBinarySearchTree)
+}
+
+func newBinarySearchTree(keys ...int) *BinarySearchTree[int, int] {
+	bst := NewBinarySearchTree[int, int]()
+	for _, k := range keys {
+		bst.Put(k, -k)
+	}
+	return bst
+}
+
+func TestBinarySearchTree_Delete(t *testing.T) {
+	//        50
+	//      /    \
+	//    30      70
+	//   /  \    /  \
+	//  20  40  60  80
+	//             /
+	//            75
+	//              \
+	//              77
+	keys := []int{50, 30, 70, 20, 40, 60, 80, 75, 77}
+	for _, test := range []struct {
+		name     string
+		k        int
+		expected string
+	}{
+		{name: "leaf", k: 20, expected: "50(30(,40),70(60,80(75(,77),)))"},
+		{name: "only a left child", k: 80, expected: "50(30(20,40),70(60,75(,77)))"},
+		{name: "only a right child", k: 75, expected: "50(30(20,40),70(60,80(77,)))"},
+		{name: "successor is the right child", k: 30, expected: "50(40(20,),70(60,80(75(,77),)))"},
+		{name: "successor is deeper", k: 70, expected: "50(30(20,40),75(60,80(77,)))"},
+		{name: "root", k: 50, expected: "60(30(20,40),70(,80(75(,77),)))"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			bst := newBinarySearchTree(keys...)
+			assert.Nil(t, bst.Delete(test.k))
+			assert.Equal(t, test.expected, bst.Tree().Parenthetic())
+			checkBinarySearchTree(t, bst)
+			assert.ErrorIs(t, bst.Delete(test.k), KeyNotFoundError{})
+		})
+	}
+}
+
+func TestBinarySearchTree_EntriesSurviveDeletes(t *testing.T) {
+	bst := newBinarySearchTree(50, 30, 70, 20, 40, 60, 80)
+	// 60 is the successor taking the place of the root when it is deleted
+	e, err := bst.Floor(65)
+	assert.Nil(t, err)
+	assert.Nil(t, bst.Delete(50))
+	assert.Equal(t, 60, e.Key())
+	assert.Equal(t, -60, e.Value())
+}
+
+func TestBinarySearchTree_Put(t *testing.T) {
+	bst := newBinarySearchTree(2, 1, 3)
+	bst.Put(2, 20)
+	assert.Equal(t, 3, bst.Len())
+	v, err := bst.Get(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, v)
+
+	assert.Nil(t, NewBinarySearchTree[int, int]().Tree())
+
+	// keys inserted in sorted order build a path, the worst case for a binary search tree
+	bst = NewBinarySearchTree[int, int]()
+	for k := range 1000 {
+		bst.Put(k, k)
+	}
+	tree := bst.Tree()
+	assert.Equal(t, 1000, tree.Height())
+	assert.Equal(t, 1000, tree.Size())
+}
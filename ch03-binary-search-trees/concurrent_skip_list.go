@@ -0,0 +1,379 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"sync/atomic"
+)
+
+// ConcurrentSkipList is a SkipList which any number of goroutines can use at once without
+// locks, in the style of Java's ConcurrentSkipListMap, built from the lock-free skip list
+// of Herlihy and Shavit's The Art of Multiprocessor Programming.
+//
+// Every link is changed with a compare-and-swap, which only succeeds if the link still
+// holds what the goroutine last saw, so a goroutine whose view has gone stale finds out and
+// tries again instead of overwriting someone else's change. The bottom level decides what is
+// in the map, and the levels above are only shortcuts, linked in after the bottom level.
+//
+// Removing a position happens in three steps:
+//   - logically deleting it, by swapping its value for nil, which decides which Delete wins
+//     when several race, and is the point at which the entry leaves the map
+//   - marking every one of its links, so that nothing can be linked in after it,
+//     which would be lost along with it
+//   - physically unlinking it, which any goroutine whose search passes over a marked
+//     position does on the way, so no goroutine ever has to wait for another to finish
+//
+// A link and its mark are swapped together as one immutable skipLink, so that a position
+// cannot be marked between a goroutine checking the mark and changing the link.
+//
+// Len and All are weakly consistent: Len counts entries as they are added and deleted,
+// and All shows every entry there for the whole iteration, while entries added or deleted
+// during it may or may not show up.
+//
+// Each goroutine would need its own rand.Rand, since a rand.Rand is not safe to share, so
+// instead the levels of new positions are drawn from a shared, seeded, splitmix64 sequence.
+// Under concurrency, which goroutine gets which draw depends on scheduling, so only a
+// ConcurrentSkipList used by a single goroutine is guaranteed to have the same shape every time.
+type ConcurrentSkipList[K cmp.Ordered, V any] struct {
+	head *concurrentSkipNode[K, V]
+	// levels is the number of levels any position has ever been promoted to, which only grows,
+	// so that searches don't have to start from the top of the empty levels above
+	levels  atomic.Int64
+	size    atomic.Int64
+	seed    atomic.Uint64
+	options skipListOptions
+}
+
+// concurrentSkipNode is a position in a concurrent skip list, where a nil value
+// means the entry has been deleted, and the position is on its way out
+type concurrentSkipNode[K cmp.Ordered, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+	next  []atomic.Pointer[skipLink[K, V]]
+}
+
+// skipLink is a link to the next position on a level, which is marked
+// once the position it belongs to has been deleted
+type skipLink[K cmp.Ordered, V any] struct {
+	node   *concurrentSkipNode[K, V]
+	marked bool
+}
+
+func NewConcurrentSkipList[K cmp.Ordered, V any](seed uint64, opts ...SkipListOpt) *ConcurrentSkipList[K, V] {
+	options := newSkipListOptions(opts)
+	csl := &ConcurrentSkipList[K, V]{
+		head:    newConcurrentSkipNode[K, V](*new(K), options.maxLevels),
+		options: options,
+	}
+	csl.seed.Store(seed)
+	csl.levels.Store(1)
+	return csl
+}
+
+func newConcurrentSkipNode[K cmp.Ordered, V any](k K, levels int) *concurrentSkipNode[K, V] {
+	n := &concurrentSkipNode[K, V]{key: k, next: make([]atomic.Pointer[skipLink[K, V]], levels)}
+	for level := range n.next {
+		n.next[level].Store(&skipLink[K, V]{})
+	}
+	return n
+}
+
+func (csl *ConcurrentSkipList[K, V]) Len() int {
+	return int(csl.size.Load())
+}
+
+// randomLevels draws the number of levels for a new position,
+// with each draw from the shared sequence a uniform number in [0, 1)
+func (csl *ConcurrentSkipList[K, V]) randomLevels() int {
+	levels := 1
+	for levels < csl.options.maxLevels {
+		x := csl.seed.Add(0x9e3779b97f4a7c15)
+		x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+		x = (x ^ x>>27) * 0x94d049bb133111eb
+		x ^= x >> 31
+		if float64(x>>11)/(1<<53) >= csl.options.promotionProbability {
+			break
+		}
+		levels++
+	}
+	return levels
+}
+
+// find fills in preds with the last position with a key smaller than k on each level,
+// and succs with the position after it, unlinking any marked positions it passes over.
+// If unlinking fails because the link changed under it, it starts again from the top.
+func (csl *ConcurrentSkipList[K, V]) find(k K, preds, succs []*concurrentSkipNode[K, V]) {
+retry:
+	for {
+		pred := csl.head
+		for level := int(csl.levels.Load()) - 1; level >= 0; level-- {
+			predLink := pred.next[level].Load()
+			if predLink.marked {
+				continue retry
+			}
+			curr := predLink.node
+			for curr != nil {
+				currLink := curr.next[level].Load()
+				if currLink.marked {
+					// curr has been deleted, so take it out of this level
+					unlinked := &skipLink[K, V]{node: currLink.node}
+					if !pred.next[level].CompareAndSwap(predLink, unlinked) {
+						continue retry
+					}
+					predLink, curr = unlinked, currLink.node
+					continue
+				}
+				if curr.key >= k {
+					break
+				}
+				pred, predLink, curr = curr, currLink, currLink.node
+			}
+			preds[level], succs[level] = pred, curr
+		}
+		return
+	}
+}
+
+// lookup finds the first position with a key no smaller than k on the bottom level, without
+// changing anything, stepping over marked positions rather than unlinking them
+func (csl *ConcurrentSkipList[K, V]) lookup(k K) (pred, curr *concurrentSkipNode[K, V]) {
+	pred = csl.head
+	for level := int(csl.levels.Load()) - 1; level >= 0; level-- {
+		curr = pred.next[level].Load().node
+		for curr != nil {
+			currLink := curr.next[level].Load()
+			if currLink.marked {
+				curr = currLink.node
+				continue
+			}
+			if curr.key >= k {
+				break
+			}
+			pred, curr = curr, currLink.node
+		}
+	}
+	return pred, curr
+}
+
+// mark marks every link of a position whose value has been swapped for nil, from the top
+// down, so the bottom level, which decides what is in the map, is the last to be marked.
+// Marking is idempotent, so any goroutine that finds a deleted position can help.
+func (csl *ConcurrentSkipList[K, V]) mark(n *concurrentSkipNode[K, V]) {
+	for level := len(n.next) - 1; level >= 0; level-- {
+		for {
+			link := n.next[level].Load()
+			if link.marked || n.next[level].CompareAndSwap(link, &skipLink[K, V]{node: link.node, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// live returns a snapshot of the entry in the position, and false if it has been deleted,
+// helping the deletion along so that whoever is waiting for it to go away can move on
+func (csl *ConcurrentSkipList[K, V]) live(n *concurrentSkipNode[K, V]) (Entry[K, V], bool) {
+	v := n.value.Load()
+	if v == nil {
+		csl.mark(n)
+		return nil, false
+	}
+	// a snapshot of the entry, since the value in the position may change at any moment
+	return entry[K, V]{key: n.key, value: *v}, true
+}
+
+func (csl *ConcurrentSkipList[K, V]) Get(k K) (V, error) {
+	_, n := csl.lookup(k)
+	if n != nil && n.key == k {
+		if v := n.value.Load(); v != nil {
+			return *v, nil
+		}
+	}
+	var zero V
+	return zero, KeyNotFoundError{}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there.
+// A new position is linked into the bottom level first, at which point it is in the map,
+// then into each level above, looking for its neighbours again whenever they change.
+func (csl *ConcurrentSkipList[K, V]) Put(k K, v V) {
+	preds := make([]*concurrentSkipNode[K, V], csl.options.maxLevels)
+	succs := make([]*concurrentSkipNode[K, V], csl.options.maxLevels)
+	// draw the levels before searching, so the search covers every level the position needs
+	levels := csl.randomLevels()
+	for current := csl.levels.Load(); current < int64(levels); current = csl.levels.Load() {
+		csl.levels.CompareAndSwap(current, int64(levels))
+	}
+	for {
+		csl.find(k, preds, succs)
+		if n := succs[0]; n != nil && n.key == k {
+			old := n.value.Load()
+			if old != nil && n.value.CompareAndSwap(old, &v) {
+				return
+			}
+			if old == nil {
+				// it is being deleted, so help it out of the way, and add a new position
+				csl.mark(n)
+			}
+			continue
+		}
+
+		n := newConcurrentSkipNode[K, V](k, levels)
+		n.value.Store(&v)
+		for level := range n.next {
+			n.next[level].Store(&skipLink[K, V]{node: succs[level]})
+		}
+		if !csl.link(preds[0], 0, succs[0], n) {
+			continue
+		}
+		csl.size.Add(1)
+
+		for level := 1; level < len(n.next); level++ {
+			for {
+				link := n.next[level].Load()
+				if link.marked {
+					// already deleted, so there is no point linking it in any further
+					return
+				}
+				if link.node != succs[level] &&
+					!n.next[level].CompareAndSwap(link, &skipLink[K, V]{node: succs[level]}) {
+					continue
+				}
+				if csl.link(preds[level], level, succs[level], n) {
+					break
+				}
+				csl.find(k, preds, succs)
+			}
+		}
+		return
+	}
+}
+
+// link swaps pred's link on the level from succ to n, if it still links to succ, unmarked
+func (csl *ConcurrentSkipList[K, V]) link(pred *concurrentSkipNode[K, V], level int, succ, n *concurrentSkipNode[K, V]) bool {
+	current := pred.next[level].Load()
+	if current.marked || current.node != succ {
+		return false
+	}
+	return pred.next[level].CompareAndSwap(current, &skipLink[K, V]{node: n})
+}
+
+// Delete removes the entry for the key. Whichever Delete swaps the value for nil has deleted
+// the entry, and it marks the position, then searches for it to unlink it from every level.
+func (csl *ConcurrentSkipList[K, V]) Delete(k K) error {
+	_, n := csl.lookup(k)
+	for n != nil && n.key == k {
+		v := n.value.Load()
+		if v == nil {
+			// someone else got there first
+			break
+		}
+		if n.value.CompareAndSwap(v, nil) {
+			csl.size.Add(-1)
+			csl.mark(n)
+			preds := make([]*concurrentSkipNode[K, V], csl.options.maxLevels)
+			succs := make([]*concurrentSkipNode[K, V], csl.options.maxLevels)
+			csl.find(k, preds, succs)
+			return nil
+		}
+	}
+	return KeyNotFoundError{}
+}
+
+func (csl *ConcurrentSkipList[K, V]) Min() (Entry[K, V], error) {
+	for n := csl.head.next[0].Load().node; n != nil; n = n.next[0].Load().node {
+		if e, ok := csl.live(n); ok {
+			return e, nil
+		}
+	}
+	return nil, MapEmptyError{}
+}
+
+// Max takes the express lanes as far right as they go, starting again
+// if the position it ends at turns out to have been deleted
+func (csl *ConcurrentSkipList[K, V]) Max() (Entry[K, V], error) {
+	for {
+		n := csl.head
+		for level := int(csl.levels.Load()) - 1; level >= 0; level-- {
+			for {
+				next := n.next[level].Load().node
+				for next != nil && next.next[level].Load().marked {
+					next = next.next[level].Load().node
+				}
+				if next == nil {
+					break
+				}
+				n = next
+			}
+		}
+		if n == csl.head {
+			return nil, MapEmptyError{}
+		}
+		if e, ok := csl.live(n); ok {
+			return e, nil
+		}
+	}
+}
+
+func (csl *ConcurrentSkipList[K, V]) Floor(k K) (Entry[K, V], error) {
+	return csl.before(k, true)
+}
+
+func (csl *ConcurrentSkipList[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return csl.before(k, false)
+}
+
+// before finds the entry with the largest key smaller than k, or equal to it if inclusive,
+// starting again if the position it finds turns out to have been deleted
+func (csl *ConcurrentSkipList[K, V]) before(k K, inclusive bool) (Entry[K, V], error) {
+	for {
+		pred, n := csl.lookup(k)
+		if inclusive && n != nil && n.key == k {
+			if e, ok := csl.live(n); ok {
+				return e, nil
+			}
+		}
+		if pred == csl.head {
+			return nil, KeyNotFoundError{}
+		}
+		if e, ok := csl.live(pred); ok {
+			return e, nil
+		}
+	}
+}
+
+func (csl *ConcurrentSkipList[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return csl.after(k, true)
+}
+
+func (csl *ConcurrentSkipList[K, V]) Successor(k K) (Entry[K, V], error) {
+	return csl.after(k, false)
+}
+
+// after finds the entry with the smallest key larger than k, or equal to it if inclusive,
+// walking along the bottom level past any deleted positions
+func (csl *ConcurrentSkipList[K, V]) after(k K, inclusive bool) (Entry[K, V], error) {
+	_, n := csl.lookup(k)
+	for ; n != nil; n = n.next[0].Load().node {
+		if n.key == k && !inclusive {
+			continue
+		}
+		if e, ok := csl.live(n); ok {
+			return e, nil
+		}
+	}
+	return nil, KeyNotFoundError{}
+}
+
+// All iterates over the entries in order of their keys, along the bottom level,
+// skipping any which have been deleted by the time it gets to them
+func (csl *ConcurrentSkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := csl.head.next[0].Load().node; n != nil; n = n.next[0].Load().node {
+			if v := n.value.Load(); v != nil {
+				if !yield(n.key, *v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The stress tests here are meant to be run with the race detector, with go test -race
+
+// checkConcurrentSkipList verifies the structure once every goroutine is done with it.
+// Every deleted position should be gone from the bottom level, but may be left on the levels
+// above if it was linked into them after it was deleted, until a search next passes over it.
+func checkConcurrentSkipList(t *testing.T, csl *ConcurrentSkipList[int, int]) {
+	size := 0
+	for level := range csl.options.maxLevels {
+		for n := csl.head.next[level].Load().node; n != nil; {
+			link := n.next[level].Load()
+			assert.Greater(t, len(n.next), level)
+			if level == 0 {
+				assert.False(t, link.marked, n.key)
+				assert.NotNil(t, n.value.Load(), n.key)
+				size++
+			}
+			if link.node != nil {
+				assert.Less(t, n.key, link.node.key)
+			}
+			n = link.node
+		}
+	}
+	assert.Equal(t, csl.Len(), size)
+}
+
+func TestConcurrentSkipList(t *testing.T) {
+	seed := uint64(48)
+	testOrderedMap(t, func() *ConcurrentSkipList[int, int] {
+		seed++
+		return NewConcurrentSkipList[int, int](seed)
+	}, checkConcurrentSkipList)
+	testOrderedMap(t, func() *ConcurrentSkipList[int, int] {
+		return NewConcurrentSkipList[int, int](seed, WithPromotionProbability(0.25), WithMaxLevels(4))
+	}, checkConcurrentSkipList)
+}
+
+// TestConcurrentSkipList_Disjoint has each goroutine add and delete its own keys, interleaved
+// with everyone else's, checking against its own model, while readers look up keys all over
+func TestConcurrentSkipList_Disjoint(t *testing.T) {
+	const writers, readers, ops, keyRange = 8, 4, 2000, 4000
+	csl := NewConcurrentSkipList[int, int](48)
+	models := make([]*modelMap, writers)
+	done := make(chan struct{})
+
+	var wg, readersWg sync.WaitGroup
+	for w := range writers {
+		models[w] = &modelMap{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for range ops {
+				// every key that is w modulo the number of writers belongs to writer w
+				k := r.Intn(keyRange/writers)*writers + w
+				if r.Intn(3) == 0 {
+					found := models[w].delete(k)
+					err := csl.Delete(k)
+					assert.Equal(t, found, err == nil, k)
+				} else {
+					v := r.Int()
+					models[w].put(k, v)
+					csl.Put(k, v)
+				}
+				i, found := BinarySearch(models[w].keys, k)
+				v, err := csl.Get(k)
+				if assert.Equal(t, found, err == nil, k) && found {
+					assert.Equal(t, models[w].values[i], v)
+				}
+			}
+		}()
+	}
+	for range readers {
+		readersWg.Add(1)
+		go func() {
+			defer readersWg.Done()
+			r := rand.New(rand.NewSource(48))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				k := r.Intn(keyRange)
+				if e, err := csl.Floor(k); err == nil {
+					assert.LessOrEqual(t, e.Key(), k)
+				}
+				if e, err := csl.Successor(k); err == nil {
+					assert.Greater(t, e.Key(), k)
+				}
+				previous := -1
+				for key := range csl.All() {
+					assert.Greater(t, key, previous)
+					previous = key
+					if key > k {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	readersWg.Wait()
+
+	merged := &modelMap{}
+	for _, m := range models {
+		for i, k := range m.keys {
+			merged.put(k, m.values[i])
+		}
+	}
+	checkConcurrentSkipList(t, csl)
+	assertMatchesModelMap(t, merged, csl)
+}
+
+// TestConcurrentSkipList_Contended has every goroutine add and delete the same few keys,
+// so that the same links are fought over all the time
+func TestConcurrentSkipList_Contended(t *testing.T) {
+	const goroutines, ops, keyRange = 8, 3000, 32
+	csl := NewConcurrentSkipList[int, int](48)
+	// count the Puts of keys which were missing just before, and the Deletes that succeeded,
+	// to be sure both really happened
+	var added, deletes atomic.Int64
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(g)))
+			for range ops {
+				k := r.Intn(keyRange)
+				switch r.Intn(4) {
+				case 0:
+					if csl.Delete(k) == nil {
+						deletes.Add(1)
+					}
+				case 1:
+					if v, err := csl.Get(k); err == nil {
+						assert.Equal(t, k, v%(keyRange+1))
+					}
+				case 2:
+					if e, err := csl.Ceiling(k); err == nil {
+						assert.GreaterOrEqual(t, e.Key(), k)
+						assert.Equal(t, e.Key(), e.Value()%(keyRange+1))
+					}
+				default:
+					if _, err := csl.Get(k); err != nil {
+						added.Add(1)
+					}
+					// the value for a key is always a multiple of keyRange+1 plus the key,
+					// so a value torn between two Puts, or read from the wrong position, shows up
+					csl.Put(k, r.Intn(1000)*(keyRange+1)+k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	checkConcurrentSkipList(t, csl)
+	keys := []int{}
+	for k, v := range csl.All() {
+		keys = append(keys, k)
+		assert.Equal(t, k, v%(keyRange+1))
+	}
+	assert.True(t, slices.IsSorted(keys))
+	assert.Equal(t, len(keys), csl.Len())
+	assert.Greater(t, deletes.Load(), int64(0))
+	assert.Greater(t, added.Load(), int64(0))
+}
+
+// TestConcurrentSkipList_DeleteRace has every goroutine try to delete every key,
+// where only one Delete of each key should ever succeed
+func TestConcurrentSkipList_DeleteRace(t *testing.T) {
+	const goroutines, n = 8, 2000
+	csl := NewConcurrentSkipList[int, int](48)
+	for k := range n {
+		csl.Put(k, k)
+	}
+
+	var deleted atomic.Int64
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, k := range rand.New(rand.NewSource(int64(g))).Perm(n) {
+				if csl.Delete(k) == nil {
+					deleted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(n), deleted.Load())
+	assert.Equal(t, 0, csl.Len())
+	_, err := csl.Min()
+	assert.ErrorIs(t, err, MapEmptyError{})
+	checkConcurrentSkipList(t, csl)
+}
+
+// lockedSkipList guards a SkipList with a read-write lock, to compare against
+// the lock-free ConcurrentSkipList
+type lockedSkipList struct {
+	mu sync.RWMutex
+	sl *SkipList[int, int]
+}
+
+func (l *lockedSkipList) Put(k, v int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sl.Put(k, v)
+}
+
+func (l *lockedSkipList) Get(k int) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.sl.Get(k)
+}
+
+// BenchmarkConcurrentSkipList compares the lock-free skip list against a locked one, run with
+// -cpu to vary the number of goroutines. Each step of a lock-free search follows an extra
+// pointer to its skipLink, so with a single core the lock is cheaper, and the lock-free skip
+// list only pulls ahead once there are enough cores for the lock to be fought over.
+func BenchmarkConcurrentSkipList(b *testing.B) {
+	const n = 1 << 16
+	maps := []struct {
+		name string
+		m    benchmarkMap
+	}{
+		{name: "locked skip list", m: &lockedSkipList{sl: NewSkipList[int, int](rand.New(rand.NewSource(48)))}},
+		{name: "concurrent skip list", m: NewConcurrentSkipList[int, int](48)},
+	}
+	for _, m := range maps {
+		for k := range n {
+			m.m.Put(k, k)
+		}
+		// one Put for every nine Gets
+		b.Run(m.name, func(b *testing.B) {
+			var seed atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				r := rand.New(rand.NewSource(seed.Add(1)))
+				for i := 0; pb.Next(); i++ {
+					k := r.Intn(n)
+					if i%10 == 0 {
+						m.m.Put(k, k)
+					} else {
+						_, _ = m.m.Get(k)
+					}
+				}
+			})
+		})
+	}
+}
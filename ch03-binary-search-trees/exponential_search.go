@@ -0,0 +1,44 @@
+package binarysearchtrees
+
+import "cmp"
+
+// ExponentialSearch looks for k in the sorted slice a the same way BinarySearch does,
+// returning the index of its first occurrence and true, or the index it would need to be
+// inserted at and false, but only looks as far into the slice as it needs to.
+//
+// It takes O(log i) comparisons, where i is the index returned, so it beats BinarySearch
+// when the keys being looked for tend to be near the start of a long slice.
+func ExponentialSearch[T cmp.Ordered](a []T, k T) (int, bool) {
+	return ExponentialSearchUnbounded(func(i int) (T, bool) {
+		if i >= len(a) {
+			var zero T
+			return zero, false
+		}
+		return a[i], true
+	}, k)
+}
+
+// ExponentialSearchUnbounded is ExponentialSearch for sorted data with no known end,
+// such as an infinite sequence or a stream that fetches pages of elements on demand.
+// Calling at(i) gives the element at index i, or false if the data ends before i,
+// and is only ever called for indexes up to about twice the index returned.
+//
+// The search gallops ahead, doubling the index it looks at until it finds an element
+// which is not less than k, or runs off the end of the data. The first such element then
+// lies between that index and the one before it, a range as long as everything skipped
+// so far, and a binary search within that range finds it.
+func ExponentialSearchUnbounded[T cmp.Ordered](at func(i int) (T, bool), k T) (int, bool) {
+	past := func(i int) bool {
+		v, ok := at(i)
+		return !ok || v >= k
+	}
+
+	// everything before low is less than k, and high-1 is past it
+	low, high := 0, 1
+	for !past(high - 1) {
+		low, high = high, 2*high
+	}
+	i := low + partitionPoint(high-low, func(j int) bool { return past(low + j) })
+	v, ok := at(i)
+	return i, ok && v == k
+}
@@ -0,0 +1,58 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialSearch(t *testing.T) {
+	for _, test := range tests {
+		i, found := ExponentialSearch(test.a, test.k)
+		assert.Equal(t, test.expected, i)
+		assert.Equal(t, test.found, found)
+	}
+
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 200; i++ {
+		a := make([]int, r.Intn(100))
+		for j := range a {
+			a[j] = r.Intn(30)
+		}
+		slices.Sort(a)
+		for k := -1; k <= 31; k++ {
+			index, found := ExponentialSearch(a, k)
+			expectedIndex, expectedFound := BinarySearch(a, k)
+			assert.Equal(t, expectedIndex, index)
+			assert.Equal(t, expectedFound, found)
+		}
+	}
+}
+
+func TestExponentialSearchUnbounded(t *testing.T) {
+	// the multiples of 3 go on forever, so only the search itself can decide where to stop
+	var calls []int
+	multiplesOfThree := func(i int) (int, bool) {
+		calls = append(calls, i)
+		return 3 * i, true
+	}
+
+	i, found := ExponentialSearchUnbounded(multiplesOfThree, 300)
+	assert.Equal(t, 100, i)
+	assert.True(t, found)
+	assert.LessOrEqual(t, slices.Max(calls), 2*100)
+	assert.LessOrEqual(t, len(calls), 2*8+1, "about two lots of log 100 calls")
+
+	calls = nil
+	i, found = ExponentialSearchUnbounded(multiplesOfThree, 1000)
+	assert.Equal(t, 334, i)
+	assert.False(t, found)
+
+	calls = nil
+	i, found = ExponentialSearchUnbounded(multiplesOfThree, -5)
+	assert.Equal(t, 0, i)
+	assert.False(t, found)
+	assert.LessOrEqual(t, slices.Max(calls), 1)
+}
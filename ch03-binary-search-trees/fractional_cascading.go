@@ -0,0 +1,106 @@
+package binarysearchtrees
+
+import "cmp"
+
+// FractionalCascade answers where a key belongs in each of a number of sorted lists
+// at once, in O(log n + k) time for k lists holding n elements between them,
+// rather than the O(k log n) time of a separate binary search in each list.
+//
+// Each list is merged with every second element of the merged list built for the list after
+// it, working back from the last list, which is left as it is. Every element of a merged list
+// then remembers where it would go in its own list, and where it would go in the next merged
+// list. Since the promoted elements are spread evenly through the next merged list, the place
+// a key belongs in the next merged list is at most one step back from where the element
+// found for it in this merged list would go, so after a single binary search in the first
+// merged list, each list after it only takes a constant amount of work.
+//
+// Promoting half of each merged list into the one before it means each merged list holds at
+// most its own list plus half the next merged list, so in total they hold fewer than 2n elements.
+type FractionalCascade[T cmp.Ordered] struct {
+	lists  [][]T
+	merged [][]T
+	// own[i][j] is the lower bound of merged[i][j] in lists[i], and next[i][j] is its lower
+	// bound in merged[i+1], each with an extra entry on the end for keys past every element
+	own  [][]int
+	next [][]int
+}
+
+// NewFractionalCascade builds a FractionalCascade over sorted lists, which it does not copy,
+// so they must not be changed while the cascade is in use. It takes O(n) time.
+func NewFractionalCascade[T cmp.Ordered](lists [][]T) *FractionalCascade[T] {
+	k := len(lists)
+	fc := &FractionalCascade[T]{
+		lists:  lists,
+		merged: make([][]T, k),
+		own:    make([][]int, k),
+		next:   make([][]int, k),
+	}
+	for i := k - 1; i >= 0; i-- {
+		var promoted []T
+		if i+1 < k {
+			for j := 1; j < len(fc.merged[i+1]); j += 2 {
+				promoted = append(promoted, fc.merged[i+1][j])
+			}
+		}
+		fc.merged[i] = mergeSorted(lists[i], promoted)
+		fc.own[i] = lowerBounds(fc.merged[i], lists[i])
+		if i+1 < k {
+			fc.next[i] = lowerBounds(fc.merged[i], fc.merged[i+1])
+		}
+	}
+	return fc
+}
+
+// Search returns, for each list, the index of its first element which is not less than k,
+// or the length of the list if every element is less than k, just like LowerBound
+func (fc *FractionalCascade[T]) Search(k T) []int {
+	positions := make([]int, len(fc.lists))
+	if len(fc.lists) == 0 {
+		return positions
+	}
+	p := LowerBound(fc.merged[0], k)
+	for i := range fc.lists {
+		positions[i] = fc.own[i][p]
+		if i+1 == len(fc.lists) {
+			break
+		}
+		// where merged[i][p] would go in the next merged list, k can go at most one step
+		// further back, since at most one element between them could have been left unpromoted
+		p = fc.next[i][p]
+		for p > 0 && fc.merged[i+1][p-1] >= k {
+			p--
+		}
+	}
+	return positions
+}
+
+func mergeSorted[T cmp.Ordered](a, b []T) []T {
+	merged := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j] < a[i] {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
+
+// lowerBounds finds the lower bound in b of every element of a, followed by len(b),
+// walking through both sorted lists together in O(len(a) + len(b)) time
+func lowerBounds[T cmp.Ordered](a, b []T) []int {
+	bounds := make([]int, len(a)+1)
+	j := 0
+	for i, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		bounds[i] = j
+	}
+	bounds[len(a)] = len(b)
+	return bounds
+}
@@ -0,0 +1,85 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFractionalCascade(t *testing.T) {
+	lists := [][]int{
+		{24, 64, 65, 80, 93},
+		{23, 25, 26},
+		{13, 44, 62, 66},
+		{11, 35, 46, 79, 81},
+	}
+	fc := NewFractionalCascade(lists)
+	assert.Equal(t, []int{1, 3, 1, 2}, fc.Search(40))
+	assert.Equal(t, []int{0, 0, 0, 0}, fc.Search(0))
+	assert.Equal(t, []int{5, 3, 4, 5}, fc.Search(100))
+	assert.Equal(t, []int{1, 3, 3, 3}, fc.Search(64))
+
+	assert.Empty(t, NewFractionalCascade[int](nil).Search(1))
+	assert.Equal(t, []int{0, 0}, NewFractionalCascade([][]int{{}, {}}).Search(1))
+}
+
+func TestFractionalCascade_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 50; i++ {
+		lists := make([][]int, r.Intn(10))
+		total := 0
+		for j := range lists {
+			lists[j] = make([]int, r.Intn(40))
+			for l := range lists[j] {
+				lists[j][l] = r.Intn(60)
+			}
+			slices.Sort(lists[j])
+			total += len(lists[j])
+		}
+
+		fc := NewFractionalCascade(lists)
+		merged := 0
+		for _, m := range fc.merged {
+			merged += len(m)
+		}
+		assert.LessOrEqual(t, merged, 2*total)
+
+		for k := -1; k <= 61; k++ {
+			expected := make([]int, len(lists))
+			for j, list := range lists {
+				expected[j] = LowerBound(list, k)
+			}
+			assert.Equal(t, expected, fc.Search(k), k)
+		}
+	}
+}
+
+func BenchmarkFractionalCascade(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	lists := make([][]int, 32)
+	for i := range lists {
+		lists[i] = make([]int, 1<<12)
+		for j := range lists[i] {
+			lists[i][j] = r.Intn(1 << 30)
+		}
+		slices.Sort(lists[i])
+	}
+	fc := NewFractionalCascade(lists)
+
+	b.Run("cascade", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fc.Search(r.Intn(1 << 30))
+		}
+	})
+	b.Run("binary search each list", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k := r.Intn(1 << 30)
+			positions := make([]int, len(lists))
+			for j, list := range lists {
+				positions[j] = LowerBound(list, k)
+			}
+		}
+	})
+}
@@ -0,0 +1,242 @@
+package binarysearchtrees
+
+import (
+	"iter"
+	"math/rand"
+)
+
+// ImplicitTreap is a sequence of numbers kept in a Treap with no keys at all. The key of each
+// position is its index, which is never stored, since it is just the number of positions
+// before it in in-order, which can be worked out on the way down from the sizes of the left
+// subtrees passed. Splitting at an index instead of a key, and merging, give O(log n) expected
+// time to insert or delete at any index, where a slice has to shift everything after it.
+//
+// Each position also keeps the sum of its subtree, so the sum of any range of indexes is the
+// sum of the subtree left after splitting off everything before and after the range.
+//
+// Reversing a range the same way, by splitting it off, would take O(n) time to swap the children
+// of every position in it, so instead the root of the range is just flagged as reversed. The
+// swap is pushed down a level, flagging the children in turn, whenever a split or merge needs to
+// go below a flagged position, so each operation only does the swaps on the path it walks.
+type ImplicitTreap[T Number] struct {
+	root *implicitNode[T]
+	r    *rand.Rand
+}
+
+// implicitNode is a position in an implicit treap, where reversed means the children of
+// every position in its subtree, its own included, still need to be swapped
+type implicitNode[T Number] struct {
+	value                 T
+	priority              uint64
+	size                  int
+	sum                   T
+	reversed              bool
+	leftChild, rightChild *implicitNode[T]
+}
+
+func NewImplicitTreap[T Number](r *rand.Rand) *ImplicitTreap[T] {
+	return &ImplicitTreap[T]{r: r}
+}
+
+// ImplicitTreapFromSlice builds an ImplicitTreap holding the values in order
+func ImplicitTreapFromSlice[T Number](r *rand.Rand, values []T) *ImplicitTreap[T] {
+	it := NewImplicitTreap[T](r)
+	for _, v := range values {
+		it.root = mergeImplicit(it.root, it.newNode(v))
+	}
+	return it
+}
+
+func (it *ImplicitTreap[T]) newNode(v T) *implicitNode[T] {
+	return &implicitNode[T]{value: v, priority: it.r.Uint64(), size: 1, sum: v}
+}
+
+func (it *ImplicitTreap[T]) Len() int {
+	return it.root.getSize()
+}
+
+func (n *implicitNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *implicitNode[T]) getSum() T {
+	if n == nil {
+		return 0
+	}
+	return n.sum
+}
+
+// update recomputes the size and sum of the subtree at n from its children
+func (n *implicitNode[T]) update() *implicitNode[T] {
+	n.size = n.leftChild.getSize() + n.rightChild.getSize() + 1
+	n.sum = n.leftChild.getSum() + n.value + n.rightChild.getSum()
+	return n
+}
+
+// push swaps the children of a reversed position, passing the reversal down to them.
+// A subtree holds the same values reversed or not, so the size and sum don't change.
+func (n *implicitNode[T]) push() {
+	if n == nil || !n.reversed {
+		return
+	}
+	n.leftChild, n.rightChild = n.rightChild, n.leftChild
+	if n.leftChild != nil {
+		n.leftChild.reversed = !n.leftChild.reversed
+	}
+	if n.rightChild != nil {
+		n.rightChild.reversed = !n.rightChild.reversed
+	}
+	n.reversed = false
+}
+
+// splitImplicit cuts the treap at n into the first i values and the rest
+func splitImplicit[T Number](n *implicitNode[T], i int) (left, right *implicitNode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	n.push()
+	if index := n.leftChild.getSize(); index < i {
+		n.rightChild, right = splitImplicit(n.rightChild, i-index-1)
+		return n.update(), right
+	}
+	left, n.leftChild = splitImplicit(n.leftChild, i)
+	return left, n.update()
+}
+
+// mergeImplicit joins two treaps, with the values of left before the values of right
+func mergeImplicit[T Number](left, right *implicitNode[T]) *implicitNode[T] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority >= right.priority:
+		left.push()
+		left.rightChild = mergeImplicit(left.rightChild, right)
+		return left.update()
+	default:
+		right.push()
+		right.leftChild = mergeImplicit(left, right.leftChild)
+		return right.update()
+	}
+}
+
+// At returns the value at index i, counting the positions
+// before it in in-order on the way down
+func (it *ImplicitTreap[T]) At(i int) (T, error) {
+	n, err := it.at(i)
+	if err != nil {
+		return 0, err
+	}
+	return n.value, nil
+}
+
+// Set replaces the value at index i, splitting it off on its own so that the sums
+// above it are recomputed as it is merged back in
+func (it *ImplicitTreap[T]) Set(i int, v T) error {
+	if i < 0 || i >= it.Len() {
+		return IndexOutOfRangeError{}
+	}
+	left, rest := splitImplicit(it.root, i)
+	middle, right := splitImplicit(rest, 1)
+	middle.value = v
+	it.root = mergeImplicit(left, mergeImplicit(middle.update(), right))
+	return nil
+}
+
+func (it *ImplicitTreap[T]) at(i int) (*implicitNode[T], error) {
+	if i < 0 || i >= it.Len() {
+		return nil, IndexOutOfRangeError{}
+	}
+	n := it.root
+	for {
+		n.push()
+		index := n.leftChild.getSize()
+		switch {
+		case i < index:
+			n = n.leftChild
+		case i > index:
+			n, i = n.rightChild, i-index-1
+		default:
+			return n, nil
+		}
+	}
+}
+
+// InsertAt inserts the value at index i, moving the values from i on along by one,
+// where i can be anything from 0, for the front, to Len, for the back
+func (it *ImplicitTreap[T]) InsertAt(i int, v T) error {
+	if i < 0 || i > it.Len() {
+		return IndexOutOfRangeError{}
+	}
+	left, right := splitImplicit(it.root, i)
+	it.root = mergeImplicit(mergeImplicit(left, it.newNode(v)), right)
+	return nil
+}
+
+// DeleteAt removes the value at index i, moving the values after it back by one
+func (it *ImplicitTreap[T]) DeleteAt(i int) (T, error) {
+	if i < 0 || i >= it.Len() {
+		return 0, IndexOutOfRangeError{}
+	}
+	left, rest := splitImplicit(it.root, i)
+	middle, right := splitImplicit(rest, 1)
+	it.root = mergeImplicit(left, right)
+	return middle.value, nil
+}
+
+// Reverse reverses the order of the values from index low up to but not including high
+func (it *ImplicitTreap[T]) Reverse(low, high int) error {
+	if low < 0 || high > it.Len() || low > high {
+		return IndexOutOfRangeError{}
+	}
+	left, rest := splitImplicit(it.root, low)
+	middle, right := splitImplicit(rest, high-low)
+	if middle != nil {
+		middle.reversed = !middle.reversed
+	}
+	it.root = mergeImplicit(left, mergeImplicit(middle, right))
+	return nil
+}
+
+// Sum adds up the values from index low up to but not including high
+func (it *ImplicitTreap[T]) Sum(low, high int) (T, error) {
+	if low < 0 || high > it.Len() || low > high {
+		return 0, IndexOutOfRangeError{}
+	}
+	left, rest := splitImplicit(it.root, low)
+	middle, right := splitImplicit(rest, high-low)
+	sum := middle.getSum()
+	it.root = mergeImplicit(left, mergeImplicit(middle, right))
+	return sum, nil
+}
+
+// All iterates over the indexes and values in order, like slices.All,
+// pushing down any reversals on the way
+func (it *ImplicitTreap[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		var stack []*implicitNode[T]
+		i := 0
+		for n := it.root; n != nil || len(stack) > 0; n = n.rightChild {
+			for ; n != nil; n = n.leftChild {
+				n.push()
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(i, n.value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+type IndexOutOfRangeError struct{}
+
+func (e IndexOutOfRangeError) Error() string {
+	return "index out of range"
+}
@@ -0,0 +1,140 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkImplicitTreap verifies every position has a priority no lower than its children's,
+// and holds the size and sum of its subtree, then that the values match the model
+func checkImplicitTreap(t *testing.T, it *ImplicitTreap[int], model []int) {
+	checkImplicitSubtree(t, it.root)
+	values := []int{}
+	for i, v := range it.All() {
+		assert.Equal(t, len(values), i)
+		values = append(values, v)
+	}
+	assert.Equal(t, append([]int{}, model...), values)
+	assert.Equal(t, len(model), it.Len())
+}
+
+func checkImplicitSubtree(t *testing.T, n *implicitNode[int]) (size, sum int) {
+	if n == nil {
+		return 0, 0
+	}
+	for _, child := range []*implicitNode[int]{n.leftChild, n.rightChild} {
+		if child != nil {
+			assert.GreaterOrEqual(t, n.priority, child.priority)
+		}
+	}
+	leftSize, leftSum := checkImplicitSubtree(t, n.leftChild)
+	rightSize, rightSum := checkImplicitSubtree(t, n.rightChild)
+	assert.Equal(t, leftSize+rightSize+1, n.size)
+	assert.Equal(t, leftSum+rightSum+n.value, n.sum)
+	return n.size, n.sum
+}
+
+func TestImplicitTreap(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	it := NewImplicitTreap[int](r)
+	model := []int{}
+
+	for i := 0; i < 3000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4 || len(model) == 0:
+			i, v := r.Intn(len(model)+1), r.Intn(1000)
+			assert.Nil(t, it.InsertAt(i, v))
+			model = slices.Insert(model, i, v)
+		case op < 6:
+			i := r.Intn(len(model))
+			v, err := it.DeleteAt(i)
+			assert.Nil(t, err)
+			assert.Equal(t, model[i], v)
+			model = slices.Delete(model, i, i+1)
+		case op < 8:
+			low := r.Intn(len(model) + 1)
+			high := low + r.Intn(len(model)-low+1)
+			assert.Nil(t, it.Reverse(low, high))
+			slices.Reverse(model[low:high])
+		default:
+			i, v := r.Intn(len(model)), r.Intn(1000)
+			assert.Nil(t, it.Set(i, v))
+			model[i] = v
+		}
+
+		if len(model) > 0 {
+			i := r.Intn(len(model))
+			v, err := it.At(i)
+			assert.Nil(t, err)
+			assert.Equal(t, model[i], v)
+		}
+		low := r.Intn(len(model) + 1)
+		high := low + r.Intn(len(model)-low+1)
+		sum, err := it.Sum(low, high)
+		assert.Nil(t, err)
+		expected := 0
+		for _, v := range model[low:high] {
+			expected += v
+		}
+		assert.Equal(t, expected, sum)
+
+		if i%50 == 0 {
+			checkImplicitTreap(t, it, model)
+		}
+	}
+	checkImplicitTreap(t, it, model)
+}
+
+func TestImplicitTreap_Errors(t *testing.T) {
+	it := ImplicitTreapFromSlice(rand.New(rand.NewSource(50)), []float64{1.5, 2.5, 3})
+
+	_, err := it.At(3)
+	assert.ErrorIs(t, err, IndexOutOfRangeError{})
+	_, err = it.At(-1)
+	assert.ErrorIs(t, err, IndexOutOfRangeError{})
+	assert.ErrorIs(t, it.Set(3, 0), IndexOutOfRangeError{})
+	assert.ErrorIs(t, it.InsertAt(4, 0), IndexOutOfRangeError{})
+	_, err = it.DeleteAt(3)
+	assert.ErrorIs(t, err, IndexOutOfRangeError{})
+	assert.ErrorIs(t, it.Reverse(2, 1), IndexOutOfRangeError{})
+	_, err = it.Sum(0, 4)
+	assert.ErrorIs(t, err, IndexOutOfRangeError{})
+
+	// empty ranges are fine, and sum to zero
+	sum, err := it.Sum(3, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, sum)
+	assert.Nil(t, it.Reverse(0, 0))
+
+	assert.Nil(t, it.InsertAt(3, 4))
+	sum, err = it.Sum(0, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 11.0, sum)
+}
+
+// BenchmarkImplicitTreap_InsertAt compares inserting at random indexes
+// against a slice, which has to shift everything after the index along
+func BenchmarkImplicitTreap_InsertAt(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16} {
+		r := rand.New(rand.NewSource(50))
+		it := ImplicitTreapFromSlice(r, sequence(n))
+		a := sequence(n)
+		b.Run("implicit treap/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = it.InsertAt(r.Intn(it.Len()+1), i)
+				_, _ = it.DeleteAt(r.Intn(it.Len()))
+			}
+		})
+		b.Run("slice/"+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				a = slices.Insert(a, r.Intn(len(a)+1), i)
+				j := r.Intn(len(a))
+				a = slices.Delete(a, j, j+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package binarysearchtrees
+
+// Number is any type of integer or floating point number,
+// which can be subtracted and divided to estimate where a key lies
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// InterpolationSearch looks for k in the sorted slice a, returning the index of its
+// first occurrence and true, or the index it would need to be inserted at and false,
+// just like BinarySearch.
+//
+// Instead of always looking in the middle of the range left to search, it guesses where k
+// should be from how far k lies between the values at either end of the range, the way
+// someone would open a dictionary near the back to look up a word starting with W.
+// When the values are spread out evenly this takes O(log log n) comparisons on average,
+// but when they are not the guesses can be poor enough to take O(n) comparisons.
+func InterpolationSearch[T Number](a []T, k T) (int, bool) {
+	// everything before low is less than k, and everything from high on is not
+	low, high := 0, len(a)
+	for low < high {
+		if a[low] >= k {
+			high = low
+			break
+		}
+		if a[high-1] < k {
+			low = high
+			break
+		}
+
+		// a[low] < k <= a[high-1], so the first element not less than k is past low
+		// and no further than high-1; guess how far along it is
+		// (converting before subtracting, so the difference can't overflow)
+		fraction := (float64(k) - float64(a[low])) / (float64(a[high-1]) - float64(a[low]))
+		guess := low + int(fraction*float64(high-1-low))
+		guess = min(max(guess, low+1), high-1)
+		if a[guess] < k {
+			low = guess + 1
+		} else {
+			high = guess
+		}
+	}
+	return low, low < len(a) && a[low] == k
+}
@@ -0,0 +1,47 @@
+package binarysearchtrees
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolationSearch(t *testing.T) {
+	for _, test := range tests {
+		i, found := InterpolationSearch(test.a, test.k)
+		assert.Equal(t, test.expected, i)
+		assert.Equal(t, test.found, found)
+	}
+
+	i, found := InterpolationSearch([]float64{-2.5, 0.1, 0.1, 3.75, 1e9}, 0.1)
+	assert.Equal(t, 1, i)
+	assert.True(t, found)
+	i, found = InterpolationSearch([]uint8{0, 10, 200, 250}, 100)
+	assert.Equal(t, 2, i)
+	assert.False(t, found)
+
+	// the distance across the whole range doesn't fit in an int64
+	extremes := []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	i, found = InterpolationSearch(extremes, 1)
+	assert.Equal(t, 3, i)
+	assert.True(t, found)
+
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 200; i++ {
+		a := make([]int, r.Intn(100))
+		for j := range a {
+			// squaring skews the values so the guesses are often poor
+			a[j] = r.Intn(30) * r.Intn(30)
+		}
+		slices.Sort(a)
+		for k := -1; k <= 900; k += 7 {
+			index, found := InterpolationSearch(a, k)
+			expectedIndex, expectedFound := BinarySearch(a, k)
+			assert.Equal(t, expectedIndex, index)
+			assert.Equal(t, expectedFound, found)
+		}
+	}
+}
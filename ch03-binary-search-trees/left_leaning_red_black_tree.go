@@ -0,0 +1,200 @@
+package binarysearchtrees
+
+import "cmp"
+
+// LeftLeaningRedBlackTree is a RedBlackTree with the extra rule that a red position
+// is always a left child, following Sedgewick's left-leaning red-black trees.
+//
+// Thinking of a red position as glued to its parent, a red-black tree is a 2-3-4 tree,
+// where each black position and its red children make up a single node holding up to
+// three keys. Only allowing red left children rules out all but one way of drawing each
+// 2-3-4 node as binary tree positions, which cuts down the number of cases to handle so far
+// that insertion and deletion can each be written as a short recursive walk down the tree,
+// fixing up the colours on the way back up with the same three steps:
+//
+//   - rotate a red right child up to the left
+//   - rotate the upper of two reds in a row up to the right
+//   - split a position with two red children by flipping the colours of all three
+//
+// The price is that more rotations are done than in a classic red-black tree.
+type LeftLeaningRedBlackTree[K cmp.Ordered, V any] struct {
+	treeMap[K, V]
+}
+
+func NewLeftLeaningRedBlackTree[K cmp.Ordered, V any](opts ...TreeMapOpt) *LeftLeaningRedBlackTree[K, V] {
+	return &LeftLeaningRedBlackTree[K, V]{treeMap[K, V]{options: newTreeMapOptions(opts), label: redBlackLabel[K, V]}}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there,
+// attaching a new position as a red leaf and fixing up the colours on the way back up
+func (llrb *LeftLeaningRedBlackTree[K, V]) Put(k K, v V) {
+	llrb.root = llrb.put(llrb.root, k, v)
+	llrb.root.parent, llrb.root.red = nil, false
+}
+
+func (llrb *LeftLeaningRedBlackTree[K, V]) put(h *node[K, V], k K, v V) *node[K, V] {
+	if h == nil {
+		llrb.size++
+		return &node[K, V]{key: k, value: v, red: true}
+	}
+	switch {
+	case k < h.key:
+		h.leftChild = llrb.put(h.leftChild, k, v)
+		h.leftChild.parent = h
+	case k > h.key:
+		h.rightChild = llrb.put(h.rightChild, k, v)
+		h.rightChild.parent = h
+	default:
+		h.value = v
+	}
+	return llrb.fixUp(h)
+}
+
+// Delete removes the entry for the key.
+//
+// Removing a black leaf would leave its path one black short, so on the way down the tree
+// makes sure the position it steps to is never a 2-node, a black position with no red
+// children, by borrowing a key from a sibling or merging with it. The position to remove
+// then ends up red, or with a red child, and can be taken out without changing any black
+// height. Any right-leaning reds and 4-nodes the borrowing left behind are fixed up on
+// the way back up.
+func (llrb *LeftLeaningRedBlackTree[K, V]) Delete(k K) error {
+	if _, err := llrb.Get(k); err != nil {
+		return err
+	}
+	if !isRed(llrb.root.leftChild) && !isRed(llrb.root.rightChild) {
+		llrb.root.red = true
+	}
+	llrb.root = llrb.delete(llrb.root, k)
+	if llrb.root != nil {
+		llrb.root.parent, llrb.root.red = nil, false
+	}
+	llrb.size--
+	return nil
+}
+
+func (llrb *LeftLeaningRedBlackTree[K, V]) delete(h *node[K, V], k K) *node[K, V] {
+	if k < h.key {
+		if !isRed(h.leftChild) && !isRed(h.leftChild.leftChild) {
+			h = llrb.moveRedLeft(h)
+		}
+		h.leftChild = llrb.delete(h.leftChild, k)
+		setParent(h.leftChild, h)
+		return llrb.fixUp(h)
+	}
+
+	if isRed(h.leftChild) {
+		h = llrb.rotateRight(h)
+	}
+	if k == h.key && h.rightChild == nil {
+		// with the red left child rotated away, a position with no right child has no
+		// left child either, so it is a red leaf
+		h.parent = nil
+		return nil
+	}
+	if !isRed(h.rightChild) && !isRed(h.rightChild.leftChild) {
+		h = llrb.moveRedRight(h)
+	}
+	if k == h.key {
+		// replace h with its successor, which is taken out of the right subtree
+		// the same way the minimum of any subtree is
+		right, successor := llrb.deleteMin(h.rightChild)
+		successor.leftChild, successor.rightChild = h.leftChild, right
+		setParent(successor.leftChild, successor)
+		setParent(successor.rightChild, successor)
+		// the parent needs to point at the successor straight away,
+		// in case fixing up the successor rotates it out of h's place
+		llrb.transplant(h, successor)
+		successor.red = h.red
+		h.parent, h.leftChild, h.rightChild = nil, nil, nil
+		h = successor
+	} else {
+		h.rightChild = llrb.delete(h.rightChild, k)
+		setParent(h.rightChild, h)
+	}
+	return llrb.fixUp(h)
+}
+
+// deleteMin takes the position with the smallest key out of the subtree at h,
+// returning the new root of the subtree along with the position taken out
+func (llrb *LeftLeaningRedBlackTree[K, V]) deleteMin(h *node[K, V]) (*node[K, V], *node[K, V]) {
+	if h.leftChild == nil {
+		// a black position with no left child can't have a right child either,
+		// since a right child would have to be black and break the black height
+		h.parent = nil
+		return nil, h
+	}
+	if !isRed(h.leftChild) && !isRed(h.leftChild.leftChild) {
+		h = llrb.moveRedLeft(h)
+	}
+	left, minimum := llrb.deleteMin(h.leftChild)
+	h.leftChild = left
+	setParent(h.leftChild, h)
+	return llrb.fixUp(h), minimum
+}
+
+// moveRedLeft makes sure the left child of h, or one of its children, is red,
+// by merging it with h and its sibling, or borrowing from the sibling if that has a key to spare
+func (llrb *LeftLeaningRedBlackTree[K, V]) moveRedLeft(h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.rightChild.leftChild) {
+		llrb.rotateRight(h.rightChild)
+		h = llrb.rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight makes sure the right child of h, or one of its children, is red
+func (llrb *LeftLeaningRedBlackTree[K, V]) moveRedRight(h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.leftChild.leftChild) {
+		h = llrb.rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// fixUp restores the left-leaning rules at h on the way back up the tree
+func (llrb *LeftLeaningRedBlackTree[K, V]) fixUp(h *node[K, V]) *node[K, V] {
+	if isRed(h.rightChild) {
+		h = llrb.rotateLeft(h)
+	}
+	if isRed(h.leftChild) && isRed(h.leftChild.leftChild) {
+		h = llrb.rotateRight(h)
+	}
+	if isRed(h.leftChild) && isRed(h.rightChild) {
+		flipColors(h)
+	}
+	return h
+}
+
+// rotateLeft rotates the right child of h up into its place,
+// taking on h's colour while h becomes red, which keeps the black heights the same
+func (llrb *LeftLeaningRedBlackTree[K, V]) rotateLeft(h *node[K, V]) *node[K, V] {
+	x := h.rightChild
+	llrb.rotate(x)
+	x.red, h.red = h.red, true
+	return x
+}
+
+func (llrb *LeftLeaningRedBlackTree[K, V]) rotateRight(h *node[K, V]) *node[K, V] {
+	x := h.leftChild
+	llrb.rotate(x)
+	x.red, h.red = h.red, true
+	return x
+}
+
+// flipColors splits a 4-node into two 2-nodes, passing its middle key up to its parent,
+// or does the opposite and merges two 2-nodes with a key from their parent
+func flipColors[K cmp.Ordered, V any](h *node[K, V]) {
+	h.red = !h.red
+	h.leftChild.red = !h.leftChild.red
+	h.rightChild.red = !h.rightChild.red
+}
+
+func setParent[K cmp.Ordered, V any](n, parent *node[K, V]) {
+	if n != nil {
+		n.parent = parent
+	}
+}
@@ -0,0 +1,78 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Entry is a key-value pair stored in an OrderedMap
+type Entry[K cmp.Ordered, V any] interface {
+	Key() K
+	Value() V
+}
+
+// OrderedMap is a map which keeps its keys in sorted order, so on top of looking up a key
+// it can answer questions about the keys around it: the smallest and largest keys, the
+// nearest keys on either side of a key, and all the entries in order.
+//
+// Floor is the entry with the largest key no greater than k, and Ceiling the entry with
+// the smallest key no less than k, while Predecessor and Successor are the entries with the
+// nearest keys strictly less than and strictly greater than k. None of them need k itself
+// to be in the map.
+type OrderedMap[K cmp.Ordered, V any] interface {
+	Get(k K) (V, error)
+	Put(k K, v V)
+	Delete(k K) error
+	Min() (Entry[K, V], error)
+	Max() (Entry[K, V], error)
+	Floor(k K) (Entry[K, V], error)
+	Ceiling(k K) (Entry[K, V], error)
+	Predecessor(k K) (Entry[K, V], error)
+	Successor(k K) (Entry[K, V], error)
+	All() iter.Seq2[K, V]
+	Len() int
+}
+
+var _ OrderedMap[int, any] = (*BinarySearchTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*AVLTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*RedBlackTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*LeftLeaningRedBlackTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*SplayTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*SkipList[int, any])(nil)
+var _ OrderedMap[int, any] = (*ConcurrentSkipList[int, any])(nil)
+var _ OrderedMap[int, any] = (*BTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*BPlusTree[int, any])(nil)
+var _ OrderedMap[int, any] = (*Treap[int, any])(nil)
+
+// entry is an Entry held by value, for maps which don't keep each entry in a position of
+// its own, or whose positions can change after the entry has been handed out
+type entry[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+func (e entry[K, V]) Key() K {
+	return e.key
+}
+
+func (e entry[K, V]) Value() V {
+	return e.value
+}
+
+type MapEmptyError struct{}
+
+func (e MapEmptyError) Error() string {
+	return "map empty"
+}
+
+type KeyNotFoundError struct{}
+
+func (e KeyNotFoundError) Error() string {
+	return "key not found"
+}
+
+type KeyOrderError struct{}
+
+func (e KeyOrderError) Error() string {
+	return "keys of the first map must all be smaller than keys of the second"
+}
@@ -0,0 +1,250 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// modelMap is the reference model for the randomized model-check tests;
+// sorted slices of keys and values, searched with BinarySearch,
+// which is trivially correct if not very efficient
+type modelMap struct {
+	keys   []int
+	values []int
+}
+
+func (m *modelMap) put(k, v int) {
+	i, found := BinarySearch(m.keys, k)
+	if found {
+		m.values[i] = v
+		return
+	}
+	m.keys = append(m.keys[:i], append([]int{k}, m.keys[i:]...)...)
+	m.values = append(m.values[:i], append([]int{v}, m.values[i:]...)...)
+}
+
+func (m *modelMap) delete(k int) bool {
+	i, found := BinarySearch(m.keys, k)
+	if !found {
+		return false
+	}
+	m.keys = append(m.keys[:i], m.keys[i+1:]...)
+	m.values = append(m.values[:i], m.values[i+1:]...)
+	return true
+}
+
+// at returns the index of the entry for each query of the OrderedMap, or -1 if there is none
+func (m *modelMap) at(i int) int {
+	if i < 0 || i >= len(m.keys) {
+		return -1
+	}
+	return i
+}
+
+func (m *modelMap) floor(k int) int       { return m.at(UpperBound(m.keys, k) - 1) }
+func (m *modelMap) ceiling(k int) int     { return m.at(LowerBound(m.keys, k)) }
+func (m *modelMap) predecessor(k int) int { return m.at(LowerBound(m.keys, k) - 1) }
+func (m *modelMap) successor(k int) int   { return m.at(UpperBound(m.keys, k)) }
+
+// assertEntry checks an entry returned by one of the queries of the OrderedMap
+// against the entry at index i of the model, where -1 means there should be none
+func assertEntry(t *testing.T, m *modelMap, i int, e Entry[int, int], err error, query string) {
+	if i == -1 {
+		assert.ErrorIs(t, err, KeyNotFoundError{}, query)
+		return
+	}
+	if assert.Nil(t, err, query) {
+		assert.Equal(t, m.keys[i], e.Key(), query)
+		assert.Equal(t, m.values[i], e.Value(), query)
+	}
+}
+
+func assertMatchesModelMap[M OrderedMap[int, int]](t *testing.T, m *modelMap, om M) {
+	assert.Equal(t, len(m.keys), om.Len())
+
+	keys, values := []int{}, []int{}
+	for k, v := range om.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	assert.Equal(t, append([]int{}, m.keys...), keys)
+	assert.Equal(t, append([]int{}, m.values...), values)
+
+	lowest, err := om.Min()
+	highest, maxErr := om.Max()
+	if len(m.keys) == 0 {
+		assert.ErrorIs(t, err, MapEmptyError{})
+		assert.ErrorIs(t, maxErr, MapEmptyError{})
+		return
+	}
+	assertEntry(t, m, 0, lowest, err, "min")
+	assertEntry(t, m, len(m.keys)-1, highest, maxErr, "max")
+}
+
+// testOrderedMap runs a random sequence of puts, deletes and queries against a new map
+// and the model, checking that they always agree. checkInvariants is called on the map
+// after each change to verify the structural properties of the particular map being tested.
+func testOrderedMap[M OrderedMap[int, int]](t *testing.T, newMap func() M, checkInvariants func(t *testing.T, om M)) {
+	r := rand.New(rand.NewSource(44))
+	om := newMap()
+	m := &modelMap{}
+	const keyRange = 300
+
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(keyRange)
+		switch op := r.Intn(10); {
+		case op < 5:
+			v := r.Int()
+			om.Put(k, v)
+			m.put(k, v)
+			checkInvariants(t, om)
+		case op < 8:
+			err := om.Delete(k)
+			if m.delete(k) {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, KeyNotFoundError{})
+			}
+			checkInvariants(t, om)
+		default:
+			v, err := om.Get(k)
+			if i, found := BinarySearch(m.keys, k); found {
+				assert.Nil(t, err)
+				assert.Equal(t, m.values[i], v)
+			} else {
+				assert.ErrorIs(t, err, KeyNotFoundError{})
+			}
+		}
+
+		// query keys just outside the range too, so there is sometimes nothing on one side
+		q := r.Intn(keyRange+2) - 1
+		e, err := om.Floor(q)
+		assertEntry(t, m, m.floor(q), e, err, "floor")
+		e, err = om.Ceiling(q)
+		assertEntry(t, m, m.ceiling(q), e, err, "ceiling")
+		e, err = om.Predecessor(q)
+		assertEntry(t, m, m.predecessor(q), e, err, "predecessor")
+		e, err = om.Successor(q)
+		assertEntry(t, m, m.successor(q), e, err, "successor")
+
+		if i%50 == 0 {
+			assertMatchesModelMap(t, m, om)
+		}
+	}
+
+	// empty it out completely, in random order
+	for len(m.keys) > 0 {
+		k := m.keys[r.Intn(len(m.keys))]
+		assert.Nil(t, om.Delete(k))
+		m.delete(k)
+		checkInvariants(t, om)
+	}
+	assertMatchesModelMap(t, m, om)
+
+	// stopping the iteration early stops it for good
+	for k := range 10 {
+		om.Put(k, k)
+	}
+	count := 0
+	for k := range om.All() {
+		count++
+		if k == 4 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+}
+
+// sortedSliceMap is the simplest ordered map to compare against in benchmarks,
+// a sorted slice of keys with a matching slice of values, searched with BinarySearch
+type sortedSliceMap struct {
+	keys   []int
+	values []int
+}
+
+func (m *sortedSliceMap) Put(k, v int) {
+	i, found := BinarySearch(m.keys, k)
+	if found {
+		m.values[i] = v
+		return
+	}
+	m.keys = slices.Insert(m.keys, i, k)
+	m.values = slices.Insert(m.values, i, v)
+}
+
+func (m *sortedSliceMap) Get(k int) (int, error) {
+	i, found := BinarySearch(m.keys, k)
+	if !found {
+		return 0, KeyNotFoundError{}
+	}
+	return m.values[i], nil
+}
+
+// benchmarkMap is an ordered map trimmed down to the operations being benchmarked
+type benchmarkMap interface {
+	Put(k, v int)
+	Get(k int) (int, error)
+}
+
+// benchmarkMaps are the maps compared in the benchmarks
+var benchmarkMaps = []struct {
+	name   string
+	newMap func() benchmarkMap
+}{
+	{name: "sorted slice", newMap: func() benchmarkMap { return &sortedSliceMap{} }},
+	{name: "binary search tree", newMap: func() benchmarkMap { return NewBinarySearchTree[int, int]() }},
+	{name: "avl tree", newMap: func() benchmarkMap { return NewAVLTree[int, int]() }},
+	{name: "red-black tree", newMap: func() benchmarkMap { return NewRedBlackTree[int, int]() }},
+	{name: "left-leaning red-black tree", newMap: func() benchmarkMap { return NewLeftLeaningRedBlackTree[int, int]() }},
+	{name: "splay tree", newMap: func() benchmarkMap { return NewSplayTree[int, int]() }},
+	{name: "skip list", newMap: func() benchmarkMap { return NewSkipList[int, int](rand.New(rand.NewSource(48))) }},
+	{name: "b-tree", newMap: func() benchmarkMap { return NewBTree[int, int]() }},
+	{name: "b+ tree", newMap: func() benchmarkMap { return NewBPlusTree[int, int]() }},
+	{name: "treap", newMap: func() benchmarkMap { return NewTreap[int, int](rand.New(rand.NewSource(50))) }},
+}
+
+func BenchmarkOrderedMaps(b *testing.B) {
+	const n = 1 << 13
+	r := rand.New(rand.NewSource(45))
+	orders := []struct {
+		name string
+		keys []int
+	}{
+		{name: "random keys", keys: r.Perm(n)},
+		{name: "sorted keys", keys: sequence(n)},
+	}
+
+	for _, order := range orders {
+		for _, m := range benchmarkMaps {
+			b.Run(order.name+"/"+m.name+"/put", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					om := m.newMap()
+					for _, k := range order.keys {
+						om.Put(k, k)
+					}
+				}
+			})
+
+			om := m.newMap()
+			for _, k := range order.keys {
+				om.Put(k, k)
+			}
+			b.Run(order.name+"/"+m.name+"/get", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _ = om.Get(order.keys[i%n])
+				}
+			})
+		}
+	}
+}
+
+func sequence(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
@@ -0,0 +1,153 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// RedBlackTree is a BinarySearchTree which keeps itself balanced by colouring each
+// position red or black, so that every operation takes O(log n) time.
+//
+// The colours follow three rules: the root is black, a red position has no red children,
+// and every path from a position down to a missing child passes through the same number of
+// black positions, its black height. The shortest possible path down from the root is all
+// black, and the longest alternates red and black, so no path is more than twice as long as
+// any other, keeping the height below 2 log(n+1).
+//
+// Compared to an AVLTree, the balance is looser, so lookups can take a little longer, but
+// fixing up the colours after an insertion or deletion takes at most two or three rotations,
+// with any other work being recolouring, which makes changes to the tree cheaper.
+type RedBlackTree[K cmp.Ordered, V any] struct {
+	treeMap[K, V]
+}
+
+func NewRedBlackTree[K cmp.Ordered, V any](opts ...TreeMapOpt) *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{treeMap[K, V]{options: newTreeMapOptions(opts), label: redBlackLabel[K, V]}}
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there.
+//
+// A new position is coloured red, which keeps the black heights the same, but breaks the
+// rule against red children if its parent is red too, a double red. If the parent's
+// sibling is also red, the parent and its sibling can both be made black and the
+// grandparent red instead, which keeps the black heights the same but can move the double
+// red two levels up. If the parent's sibling is black, a restructuring like an AVL tree's
+// puts the middle key of the position, its parent and grandparent on top, coloured black,
+// with the other two as its red children, which fixes the double red for good.
+func (rb *RedBlackTree[K, V]) Put(k K, v V) {
+	n, added := rb.insert(k, v)
+	if !added {
+		return
+	}
+	n.red = true
+
+	for isRed(n.parent) {
+		// a red parent can't be the root, so there is always a grandparent
+		parent, grandparent := n.parent, n.parent.parent
+		left := parent == grandparent.leftChild
+		uncle := child(grandparent, !left)
+		if isRed(uncle) {
+			parent.red, uncle.red, grandparent.red = false, false, true
+			n = grandparent
+			continue
+		}
+
+		if n == child(parent, !left) {
+			// n is the middle key, so rotate it up into its parent's place first
+			rb.rotate(n)
+			parent = n
+		}
+		rb.rotate(parent)
+		parent.red, grandparent.red = false, true
+		break
+	}
+	rb.root.red = false
+}
+
+// Delete removes the entry for the key.
+//
+// Removing a red position leaves every black height the same. Removing a black position
+// leaves the subtree that moves up into its place one black short, which is made up for by
+// treating that subtree as carrying an extra black. If its root is red, it can just be
+// coloured black. Otherwise the extra black is fixed up using its sibling, which must have
+// a black height of at least one: recolouring the sibling red pushes the extra black up a
+// level, while rotating it can shift one of its own black positions across to cover it.
+func (rb *RedBlackTree[K, V]) Delete(k K) error {
+	r, err := rb.remove(k)
+	if err != nil {
+		return err
+	}
+	// a successor moving up into the removed position's place takes on its colour,
+	// so it is the successor's own colour which is lost from its old place
+	removedRed := r.removed.red
+	if r.successor != nil {
+		removedRed, r.successor.red = r.successor.red, r.removed.red
+	}
+	if !removedRed {
+		rb.fixExtraBlack(r.child, r.parent)
+	}
+	return nil
+}
+
+// fixExtraBlack fixes up a subtree x, hanging off parent, which is one black short of its
+// sibling. x may be nil, in which case its sibling can't be, since the sibling's black
+// height is at least one, so x is on whichever side of parent has a nil child.
+func (rb *RedBlackTree[K, V]) fixExtraBlack(x, parent *node[K, V]) {
+	for x != rb.root && !isRed(x) {
+		left := x == parent.leftChild
+		sibling := child(parent, !left)
+		if isRed(sibling) {
+			// rotate the red sibling up, so x gets a black sibling from the sibling's children
+			sibling.red, parent.red = false, true
+			rb.rotate(sibling)
+			sibling = child(parent, !left)
+		}
+
+		if !isRed(sibling.leftChild) && !isRed(sibling.rightChild) {
+			// colouring the sibling red takes a black off both sides,
+			// moving the shortfall up to parent
+			sibling.red = true
+			x, parent = parent, parent.parent
+			continue
+		}
+
+		if !isRed(child(sibling, !left)) {
+			// make sure the sibling's red child is the one on the far side from x
+			near := child(sibling, left)
+			near.red, sibling.red = false, true
+			rb.rotate(near)
+			sibling = near
+		}
+		// rotating the sibling up over parent, with parent coloured black,
+		// adds a black above x, while the sibling's far red child is coloured black
+		// to replace the black the sibling's side loses
+		sibling.red, parent.red = parent.red, false
+		child(sibling, !left).red = false
+		rb.rotate(sibling)
+		x = rb.root
+	}
+	if x != nil {
+		x.red = false
+	}
+}
+
+// isRed reports whether a position is red, where a missing child counts as black
+func isRed[K cmp.Ordered, V any](n *node[K, V]) bool {
+	return n != nil && n.red
+}
+
+// child returns the left or right child of n
+func child[K cmp.Ordered, V any](n *node[K, V], left bool) *node[K, V] {
+	if left {
+		return n.leftChild
+	}
+	return n.rightChild
+}
+
+// redBlackLabel labels a position with its key, in square brackets if it is red
+func redBlackLabel[K cmp.Ordered, V any](n *node[K, V]) string {
+	if n.red {
+		return fmt.Sprintf("[%v]", n.key)
+	}
+	return fmt.Sprint(n.key)
+}
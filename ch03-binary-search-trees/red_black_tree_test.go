@@ -0,0 +1,148 @@
+package binarysearchtrees
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkRedBlackTree verifies the binary search tree invariants, then the colour rules:
+// the root is black, no red position has a red child, and every path down from a position
+// passes through the same number of black positions. With leftLeaning set, it also checks
+// that no red position is a right child.
+func checkRedBlackTree(t *testing.T, tm *treeMap[int, int], leftLeaning bool) {
+	checkTreeMap(t, tm)
+	assert.False(t, isRed(tm.root), "root should be black")
+	checkRedBlackSubtree(t, tm.root, leftLeaning)
+}
+
+// checkRedBlackSubtree returns the black height of the subtree
+func checkRedBlackSubtree(t *testing.T, n *node[int, int], leftLeaning bool) int {
+	if n == nil {
+		return 0
+	}
+	if n.red {
+		assert.False(t, isRed(n.leftChild), "double red at %d", n.key)
+		assert.False(t, isRed(n.rightChild), "double red at %d", n.key)
+	}
+	if leftLeaning {
+		assert.False(t, isRed(n.rightChild), "red right child of %d", n.key)
+	}
+	left := checkRedBlackSubtree(t, n.leftChild, leftLeaning)
+	right := checkRedBlackSubtree(t, n.rightChild, leftLeaning)
+	assert.Equal(t, left, right, "black heights differ below %d", n.key)
+	if n.red {
+		return left
+	}
+	return left + 1
+}
+
+func TestRedBlackTree(t *testing.T) {
+	testOrderedMap(t, func() *RedBlackTree[int, int] { return NewRedBlackTree[int, int]() },
+		func(t *testing.T, rb *RedBlackTree[int, int]) { checkRedBlackTree(t, &rb.treeMap, false) })
+}
+
+func TestLeftLeaningRedBlackTree(t *testing.T) {
+	testOrderedMap(t, func() *LeftLeaningRedBlackTree[int, int] { return NewLeftLeaningRedBlackTree[int, int]() },
+		func(t *testing.T, llrb *LeftLeaningRedBlackTree[int, int]) { checkRedBlackTree(t, &llrb.treeMap, true) })
+}
+
+func TestRedBlackTree_Insert(t *testing.T) {
+	rb := NewRedBlackTree[int, int]()
+	for _, k := range []int{10, 20, 30} {
+		rb.Put(k, -k)
+	}
+	// a double red with a black uncle is restructured
+	assert.Equal(t, "20([10],[30])", rb.LabelledTree().Parenthetic())
+
+	// a double red with a red uncle is recoloured, with the root going back to black
+	rb.Put(15, -15)
+	assert.Equal(t, "20(10(,[15]),30)", rb.LabelledTree().Parenthetic())
+
+	// a double red on the inside takes a double rotation
+	rb.Put(12, -12)
+	assert.Equal(t, "20(12([10],[15]),30)", rb.LabelledTree().Parenthetic())
+	checkRedBlackTree(t, &rb.treeMap, false)
+}
+
+func TestRedBlackTree_Delete(t *testing.T) {
+	rb := NewRedBlackTree[int, int]()
+	for k := range 100 {
+		rb.Put(k, -k)
+	}
+	// delete every other key, then everything else, from both ends
+	for k := 0; k < 100; k += 2 {
+		assert.Nil(t, rb.Delete(k))
+		checkRedBlackTree(t, &rb.treeMap, false)
+	}
+	for k := 1; k < 50; k += 2 {
+		assert.Nil(t, rb.Delete(k))
+		assert.Nil(t, rb.Delete(100-k))
+		checkRedBlackTree(t, &rb.treeMap, false)
+	}
+	assert.Equal(t, 0, rb.Len())
+	assert.Nil(t, rb.root)
+}
+
+func TestRedBlackTrees_Height(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000, 10000} {
+		rb, llrb := NewRedBlackTree[int, int](), NewLeftLeaningRedBlackTree[int, int]()
+		for k := range n {
+			rb.Put(k, k)
+			llrb.Put(k, k)
+		}
+		bound := 2 * math.Log2(float64(n+1))
+		assert.LessOrEqual(t, float64(rb.Tree().Height()-1), bound, n)
+		assert.LessOrEqual(t, float64(llrb.Tree().Height()-1), bound, n)
+	}
+}
+
+func TestLeftLeaningRedBlackTree_Insert(t *testing.T) {
+	llrb := NewLeftLeaningRedBlackTree[int, int]()
+	for _, k := range []int{10, 20, 30} {
+		llrb.Put(k, -k)
+	}
+	// the 4-node made by the third key is split straight away
+	assert.Equal(t, "20(10,30)", llrb.LabelledTree().Parenthetic())
+	llrb.Put(40, -40)
+	assert.Equal(t, "20(10,40([30],))", llrb.LabelledTree().Parenthetic())
+	checkRedBlackTree(t, &llrb.treeMap, true)
+}
+
+func TestRotationHook(t *testing.T) {
+	var snapshots []string
+	hook := func(tree *trees.BinaryTree[string]) {
+		var sb strings.Builder
+		assert.Nil(t, tree.FprintTopDown(&sb))
+		snapshots = append(snapshots, sb.String())
+		assert.Contains(t, tree.DOT(), "digraph")
+	}
+
+	rb := NewRedBlackTree[int, int](WithRotationHook(hook))
+	rb.Put(1, -1)
+	rb.Put(3, -3)
+	assert.Empty(t, snapshots)
+	// the double red on the inside takes two rotations; the hook sees each one before
+	// the colours are fixed up, so 2 still looks red and 1 black after the second
+	rb.Put(2, -2)
+	assert.Equal(t, []string{
+		"1_\n" +
+			"  \\\n" +
+			" [2]_\n" +
+			"     \\\n" +
+			"    [3]\n",
+		" [2]_\n" +
+			"/    \\\n" +
+			"1   [3]\n",
+	}, snapshots)
+
+	snapshots = nil
+	avl := NewAVLTree[int, int](WithRotationHook(hook))
+	for _, k := range []int{1, 2, 3} {
+		avl.Put(k, -k)
+	}
+	assert.Len(t, snapshots, 1)
+}
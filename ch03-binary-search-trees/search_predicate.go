@@ -0,0 +1,156 @@
+package binarysearchtrees
+
+// NonMonotonePredicateError is returned by a search with WithMonotonicityCheck
+// when the predicate is found to be true at some point and false at a later one
+type NonMonotonePredicateError struct{}
+
+func (e NonMonotonePredicateError) Error() string {
+	return "predicate is not monotone: it is true at some point and false at a later one"
+}
+
+const (
+	// DefaultSearchEpsilon is how close together the bounds of a search over floats
+	// need to get before it stops, when no other epsilon is given
+	DefaultSearchEpsilon = 1e-9
+	// DefaultSearchMaxIterations is how many times a search over floats halves its range
+	// before giving up, when no other limit is given; halving a range of any two
+	// finite floats this many times narrows it down to a single float
+	DefaultSearchMaxIterations = 2100
+)
+
+type searchOptions struct {
+	epsilon       float64
+	maxIterations int
+	checkMonotone bool
+}
+
+type SearchOpt func(opts *searchOptions)
+
+// WithEpsilon stops a search over floats once its bounds are within epsilon of each other
+func WithEpsilon(epsilon float64) SearchOpt {
+	return func(opts *searchOptions) {
+		opts.epsilon = epsilon
+	}
+}
+
+// WithMaxIterations stops a search over floats after it has halved its range the given
+// number of times, even if its bounds are still further apart than epsilon
+func WithMaxIterations(iterations int) SearchOpt {
+	return func(opts *searchOptions) {
+		opts.maxIterations = iterations
+	}
+}
+
+// WithMonotonicityCheck makes a search spend extra evaluations of the predicate checking
+// that it really is false up to some point and true after it, returning a
+// NonMonotonePredicateError if it is not, which is handy while debugging a predicate.
+//
+// Each time the search throws away half of its range on the grounds that the predicate
+// must be false, or true, all the way through it, the check evaluates the predicate in the
+// middle of that half too, along with the two ends of the whole range to begin with.
+// That catches a lot of mistakes for about twice the evaluations, but no check short of
+// evaluating the predicate everywhere can catch them all.
+func WithMonotonicityCheck() SearchOpt {
+	return func(opts *searchOptions) {
+		opts.checkMonotone = true
+	}
+}
+
+func newSearchOptions(opts []SearchOpt) searchOptions {
+	options := searchOptions{epsilon: DefaultSearchEpsilon, maxIterations: DefaultSearchMaxIterations}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// SearchPredicate finds the smallest x in [low, high) for which pred(x) is true, or high if
+// there is none, for a predicate which is false up to some point and true from then on.
+// This is binary search on the answer: rather than looking for a value in a slice, it looks
+// for the boundary of a yes or no question, such as the smallest capacity which gets a job
+// done in time, without having to try every capacity in turn.
+//
+// It takes O(log(high - low)) evaluations of pred.
+func SearchPredicate(low, high int, pred func(x int) bool, opts ...SearchOpt) (int, error) {
+	options := newSearchOptions(opts)
+	if options.checkMonotone && low < high && pred(low) && !pred(high-1) {
+		return 0, NonMonotonePredicateError{}
+	}
+
+	for low < high {
+		midIdx := low + (high-low)/2
+		if pred(midIdx) {
+			// everything in (midIdx, high) must be true
+			if options.checkMonotone && midIdx+1 < high && !pred(midIdx+1+(high-midIdx-1)/2) {
+				return 0, NonMonotonePredicateError{}
+			}
+			high = midIdx
+		} else {
+			// everything in [low, midIdx) must be false
+			if options.checkMonotone && low < midIdx && pred(low+(midIdx-low)/2) {
+				return 0, NonMonotonePredicateError{}
+			}
+			low = midIdx + 1
+		}
+	}
+	return low, nil
+}
+
+// SearchPredicateFloat finds, to within epsilon, the smallest x in [low, high] for which
+// pred(x) is true, or high if there is none, for a predicate which is false up to some
+// point and true from then on. The x returned always has pred(x) true, unless it is high.
+//
+// The search stops once its bounds are within the epsilon given by WithEpsilon, or
+// DefaultSearchEpsilon, of each other, or once it has halved its range the number of
+// times given by WithMaxIterations, or DefaultSearchMaxIterations, whichever comes first.
+// An epsilon too small to tell apart from the floats around the answer just means
+// the search carries on until there are no floats left between its bounds.
+func SearchPredicateFloat(low, high float64, pred func(x float64) bool, opts ...SearchOpt) (float64, error) {
+	options := newSearchOptions(opts)
+	if options.checkMonotone && pred(low) && !pred(high) {
+		return 0, NonMonotonePredicateError{}
+	}
+
+	for i := 0; i < options.maxIterations && high-low > options.epsilon; i++ {
+		middle := low + (high-low)/2
+		if middle <= low || middle >= high {
+			break
+		}
+		if pred(middle) {
+			if options.checkMonotone && !pred(middle+(high-middle)/2) {
+				return 0, NonMonotonePredicateError{}
+			}
+			high = middle
+		} else {
+			if options.checkMonotone && pred(low+(middle-low)/2) {
+				return 0, NonMonotonePredicateError{}
+			}
+			low = middle
+		}
+	}
+	return high, nil
+}
+
+// SearchPredicateSequence finds the index of the first element of a sequence for which
+// pred is true, for a predicate which is false up to some point in the sequence and true
+// from then on. The sequence is implicit: at(i) gives the element at index i, or false if
+// the sequence ends before i, so it can be computed on demand and can go on forever.
+// If pred is true for no element, the length of the sequence is returned,
+// and for a sequence that goes on forever, the search does too.
+//
+// Like ExponentialSearchUnbounded, the search gallops ahead, doubling the index it looks at
+// until pred is true or the sequence ends, then finishes with SearchPredicate, taking
+// O(log i) evaluations of at and pred, where i is the index returned.
+func SearchPredicateSequence[T any](at func(i int) (T, bool), pred func(v T) bool, opts ...SearchOpt) (int, error) {
+	past := func(i int) bool {
+		v, ok := at(i)
+		return !ok || pred(v)
+	}
+
+	// everything before low is false, and high-1 is past the boundary
+	low, high := 0, 1
+	for !past(high - 1) {
+		low, high = high, 2*high
+	}
+	return SearchPredicate(low, high, past, opts...)
+}
@@ -0,0 +1,180 @@
+package binarysearchtrees
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPredicate(t *testing.T) {
+	atLeast := func(boundary int) func(int) bool {
+		return func(x int) bool { return x >= boundary }
+	}
+
+	for _, test := range []struct {
+		low, high, boundary, expected int
+	}{
+		{low: 0, high: 100, boundary: 37, expected: 37},
+		{low: 0, high: 100, boundary: 0, expected: 0},
+		{low: 0, high: 100, boundary: -5, expected: 0},
+		{low: 0, high: 100, boundary: 99, expected: 99},
+		{low: 0, high: 100, boundary: 100, expected: 100},
+		{low: 0, high: 100, boundary: 500, expected: 100},
+		{low: -50, high: -10, boundary: -20, expected: -20},
+		{low: 5, high: 5, boundary: 0, expected: 5},
+		{low: math.MinInt / 2, high: math.MaxInt / 2, boundary: 12345, expected: 12345},
+	} {
+		x, err := SearchPredicate(test.low, test.high, atLeast(test.boundary))
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, x, test)
+
+		x, err = SearchPredicate(test.low, test.high, atLeast(test.boundary), WithMonotonicityCheck())
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, x, test)
+	}
+
+	evaluations := 0
+	_, _ = SearchPredicate(0, 1<<20, func(x int) bool {
+		evaluations++
+		return x >= 1000
+	})
+	assert.Equal(t, 20, evaluations)
+}
+
+func TestSearchPredicate_NonMonotone(t *testing.T) {
+	// true only in a window, so both ends are false
+	window := func(x int) bool { return x >= 20 && x < 60 }
+	_, err := SearchPredicate(0, 100, window, WithMonotonicityCheck())
+	assert.ErrorIs(t, err, NonMonotonePredicateError{})
+
+	// a window narrow enough to fall in between the points checked goes unnoticed
+	narrow := func(x int) bool { return x >= 40 && x < 45 }
+	_, err = SearchPredicate(0, 100, narrow, WithMonotonicityCheck())
+	assert.Nil(t, err)
+
+	// true at the start and false at the end
+	_, err = SearchPredicate(0, 100, func(x int) bool { return x < 10 }, WithMonotonicityCheck())
+	assert.ErrorIs(t, err, NonMonotonePredicateError{})
+
+	// without the check, the search just returns one of the boundaries
+	x, err := SearchPredicate(0, 100, window)
+	assert.Nil(t, err)
+	assert.True(t, window(x) || x == 100 || !window(x-1))
+}
+
+func TestSearchPredicateFloat(t *testing.T) {
+	// the square root of 2 is the smallest x with x*x >= 2
+	root, err := SearchPredicateFloat(0, 2, func(x float64) bool { return x*x >= 2 })
+	assert.Nil(t, err)
+	assert.InDelta(t, math.Sqrt2, root, DefaultSearchEpsilon)
+	assert.GreaterOrEqual(t, root*root, 2.0)
+
+	root, err = SearchPredicateFloat(0, 2, func(x float64) bool { return x*x >= 2 }, WithEpsilon(0.01))
+	assert.Nil(t, err)
+	assert.InDelta(t, math.Sqrt2, root, 0.01)
+
+	// halving a range of 2 five times narrows it down to 1/16
+	root, err = SearchPredicateFloat(0, 2, func(x float64) bool { return x*x >= 2 }, WithMaxIterations(5))
+	assert.Nil(t, err)
+	assert.InDelta(t, math.Sqrt2, root, 1.0/16)
+
+	// an epsilon of zero carries on until there are no floats left between the bounds
+	root, err = SearchPredicateFloat(0, 2, func(x float64) bool { return x*x >= 2 }, WithEpsilon(0))
+	assert.Nil(t, err)
+	assert.Equal(t, math.Nextafter(root, 0)*math.Nextafter(root, 0) < 2, true)
+
+	never, err := SearchPredicateFloat(-1, 1, func(float64) bool { return false })
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, never)
+
+	_, err = SearchPredicateFloat(0, 10, func(x float64) bool { return math.Sin(x) > 0 }, WithMonotonicityCheck())
+	assert.ErrorIs(t, err, NonMonotonePredicateError{})
+}
+
+func TestSearchPredicateSequence(t *testing.T) {
+	squares := func(i int) (int, bool) { return i * i, true }
+	i, err := SearchPredicateSequence(squares, func(v int) bool { return v > 1000 })
+	assert.Nil(t, err)
+	assert.Equal(t, 32, i)
+
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	at := func(i int) (int, bool) {
+		if i >= len(primes) {
+			return 0, false
+		}
+		return primes[i], true
+	}
+	i, err = SearchPredicateSequence(at, func(p int) bool { return p >= 12 })
+	assert.Nil(t, err)
+	assert.Equal(t, 5, i)
+	i, err = SearchPredicateSequence(at, func(p int) bool { return p >= 100 })
+	assert.Nil(t, err)
+	assert.Equal(t, len(primes), i)
+
+	_, err = SearchPredicateSequence(squares, func(v int) bool { return v >= 10 && v != 25 }, WithMonotonicityCheck())
+	assert.ErrorIs(t, err, NonMonotonePredicateError{})
+}
+
+// The integer square root of n is the largest x with x*x <= n,
+// one less than the smallest x with x*x > n
+func ExampleSearchPredicate() {
+	isqrt := func(n int) int {
+		x, _ := SearchPredicate(0, n+1, func(x int) bool { return x > n/max(x, 1) })
+		return x - 1
+	}
+	fmt.Println(isqrt(0), isqrt(1), isqrt(15), isqrt(16), isqrt(1<<62))
+	// Output: 0 1 3 4 2147483648
+}
+
+// Capacity planning: the packages need to be shipped in order, within 5 days,
+// so what is the smallest truck that can carry them?
+func ExampleSearchPredicate_capacityPlanning() {
+	packages := []int{3, 2, 2, 4, 1, 4, 8, 7, 3, 5}
+	days := 5
+
+	fitsIn := func(capacity int) bool {
+		needed, load := 1, 0
+		for _, p := range packages {
+			if load+p > capacity {
+				needed, load = needed+1, 0
+			}
+			load += p
+		}
+		return needed <= days
+	}
+
+	// the truck must fit the biggest package, and never needs to fit more than all of them
+	total := 0
+	for _, p := range packages {
+		total += p
+	}
+	capacity, _ := SearchPredicate(slices.Max(packages), total+1, fitsIn)
+	fmt.Println(capacity)
+	// Output: 9
+}
+
+// Find the interest rate at which saving 100 a year for 10 years adds up to 1500
+func ExampleSearchPredicateFloat() {
+	savings := func(rate float64) float64 {
+		total := 0.0
+		for year := 0; year < 10; year++ {
+			total = total*(1+rate) + 100
+		}
+		return total
+	}
+	rate, _ := SearchPredicateFloat(0, 1, func(rate float64) bool { return savings(rate) >= 1500 })
+	fmt.Printf("%.4f\n", rate)
+	// Output: 0.0873
+}
+
+// Find the first triangular number over a million, without knowing how far to look
+func ExampleSearchPredicateSequence() {
+	triangular := func(i int) (int, bool) { return i * (i + 1) / 2, true }
+	i, _ := SearchPredicateSequence(triangular, func(v int) bool { return v > 1_000_000 })
+	v, _ := triangular(i)
+	fmt.Println(i, v)
+	// Output: 1414 1000405
+}
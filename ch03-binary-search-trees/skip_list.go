@@ -0,0 +1,256 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+)
+
+// SkipList is an OrderedMap kept as a sorted linked list of its entries, with a tower of
+// express lanes above it. Each level is a sorted linked list holding a random subset of the
+// level below, where every position is promoted from one level to the next with the
+// promotion probability p, so each level holds about p times as many positions as the
+// one below, and there are about log_{1/p}(n) levels in all.
+//
+// A search starts on the top level at the head, moving right while the next key is
+// smaller than the key being searched for, and dropping down a level when it isn't. Looking
+// at the search path backwards, each step either climbs up, with probability p, or moves
+// left, so the expected number of steps on each level is 1/p, and the expected cost of
+// a search is O(log n) with no rebalancing at all, whatever order the keys arrive in.
+//
+// The randomness comes from the rand.Rand the skip list is given, so a skip list given
+// a rand.Rand with the same seed and the same operations always has the same shape.
+type SkipList[K cmp.Ordered, V any] struct {
+	// head is a sentinel position with no entry, standing in front of every level
+	head *skipNode[K, V]
+	// levels is the number of levels holding at least one position
+	levels  int
+	size    int
+	r       *rand.Rand
+	options skipListOptions
+}
+
+// skipNode is a position in a skip list, holding an entry,
+// with a link to the next position on each level it has been promoted to
+type skipNode[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	next  []*skipNode[K, V]
+}
+
+func (n *skipNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *skipNode[K, V]) Value() V {
+	return n.value
+}
+
+const (
+	DefaultPromotionProbability = 0.5
+	// DefaultMaxLevels is enough levels for 2^32 keys with the default promotion probability
+	DefaultMaxLevels = 32
+)
+
+type skipListOptions struct {
+	promotionProbability float64
+	maxLevels            int
+}
+
+type SkipListOpt func(opts *skipListOptions)
+
+// WithPromotionProbability sets the probability p of promoting a position to the next level.
+// A smaller p saves space, with 1/(1-p) links per position on average, but makes searches
+// take more steps on each level, with 1/p expected per level. Values of p outside of (0, 1)
+// are ignored.
+func WithPromotionProbability(p float64) SkipListOpt {
+	return func(opts *skipListOptions) {
+		if p > 0 && p < 1 {
+			opts.promotionProbability = p
+		}
+	}
+}
+
+// WithMaxLevels caps the number of levels, which should be around log_{1/p}(n)
+// for the largest number of keys n the skip list is expected to hold
+func WithMaxLevels(levels int) SkipListOpt {
+	return func(opts *skipListOptions) {
+		if levels > 0 {
+			opts.maxLevels = levels
+		}
+	}
+}
+
+func newSkipListOptions(opts []SkipListOpt) skipListOptions {
+	options := skipListOptions{
+		promotionProbability: DefaultPromotionProbability,
+		maxLevels:            DefaultMaxLevels,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func NewSkipList[K cmp.Ordered, V any](r *rand.Rand, opts ...SkipListOpt) *SkipList[K, V] {
+	options := newSkipListOptions(opts)
+	return &SkipList[K, V]{
+		head:    &skipNode[K, V]{next: make([]*skipNode[K, V], options.maxLevels)},
+		levels:  1,
+		r:       r,
+		options: options,
+	}
+}
+
+func (sl *SkipList[K, V]) Len() int {
+	return sl.size
+}
+
+// randomLevels flips a biased coin until it comes up tails,
+// giving the number of levels a new position is promoted to
+func (sl *SkipList[K, V]) randomLevels() int {
+	levels := 1
+	for levels < sl.options.maxLevels && sl.r.Float64() < sl.options.promotionProbability {
+		levels++
+	}
+	return levels
+}
+
+// search finds the last position with a key smaller than k on each level,
+// recording them in preds if it is not nil, and returns the one on the bottom level
+func (sl *SkipList[K, V]) search(k K, preds []*skipNode[K, V]) *skipNode[K, V] {
+	n := sl.head
+	for level := sl.levels - 1; level >= 0; level-- {
+		for n.next[level] != nil && n.next[level].key < k {
+			n = n.next[level]
+		}
+		if preds != nil {
+			preds[level] = n
+		}
+	}
+	return n
+}
+
+func (sl *SkipList[K, V]) Get(k K) (V, error) {
+	n := sl.search(k, nil).next[0]
+	if n == nil || n.key != k {
+		var zero V
+		return zero, KeyNotFoundError{}
+	}
+	return n.value, nil
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there.
+// A new position is spliced in after the last position with a smaller key on each of the
+// levels it is promoted to, which are exactly the positions the search drops down from.
+func (sl *SkipList[K, V]) Put(k K, v V) {
+	preds := make([]*skipNode[K, V], sl.options.maxLevels)
+	if n := sl.search(k, preds).next[0]; n != nil && n.key == k {
+		n.value = v
+		return
+	}
+
+	levels := sl.randomLevels()
+	for ; sl.levels < levels; sl.levels++ {
+		preds[sl.levels] = sl.head
+	}
+	n := &skipNode[K, V]{key: k, value: v, next: make([]*skipNode[K, V], levels)}
+	for level := range levels {
+		n.next[level], preds[level].next[level] = preds[level].next[level], n
+	}
+	sl.size++
+}
+
+// Delete removes the entry for the key, unlinking its position from every level it is on
+func (sl *SkipList[K, V]) Delete(k K) error {
+	preds := make([]*skipNode[K, V], sl.options.maxLevels)
+	n := sl.search(k, preds).next[0]
+	if n == nil || n.key != k {
+		return KeyNotFoundError{}
+	}
+	for level := range n.next {
+		preds[level].next[level] = n.next[level]
+	}
+	for sl.levels > 1 && sl.head.next[sl.levels-1] == nil {
+		sl.levels--
+	}
+	sl.size--
+	return nil
+}
+
+func (sl *SkipList[K, V]) Min() (Entry[K, V], error) {
+	if sl.size == 0 {
+		return nil, MapEmptyError{}
+	}
+	return sl.head.next[0], nil
+}
+
+// Max takes the express lanes as far right as they go
+func (sl *SkipList[K, V]) Max() (Entry[K, V], error) {
+	if sl.size == 0 {
+		return nil, MapEmptyError{}
+	}
+	n := sl.head
+	for level := sl.levels - 1; level >= 0; level-- {
+		for n.next[level] != nil {
+			n = n.next[level]
+		}
+	}
+	return n, nil
+}
+
+func (sl *SkipList[K, V]) Floor(k K) (Entry[K, V], error) {
+	pred := sl.search(k, nil)
+	if n := pred.next[0]; n != nil && n.key == k {
+		return n, nil
+	}
+	return sl.found(pred)
+}
+
+func (sl *SkipList[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return sl.found(sl.search(k, nil).next[0])
+}
+
+func (sl *SkipList[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return sl.found(sl.search(k, nil))
+}
+
+func (sl *SkipList[K, V]) Successor(k K) (Entry[K, V], error) {
+	n := sl.search(k, nil).next[0]
+	if n != nil && n.key == k {
+		n = n.next[0]
+	}
+	return sl.found(n)
+}
+
+// found turns the position a query ended at into its result,
+// where both running off the end and the head mean there is no such entry
+func (sl *SkipList[K, V]) found(n *skipNode[K, V]) (Entry[K, V], error) {
+	if n == nil || n == sl.head {
+		return nil, KeyNotFoundError{}
+	}
+	return n, nil
+}
+
+// All iterates over the entries in order of their keys, along the bottom level
+func (sl *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := sl.head.next[0]; n != nil; n = n.next[0] {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// LevelSizes returns the number of positions on each level, from the bottom up,
+// which shrink by about a factor of p from one level to the next
+func (sl *SkipList[K, V]) LevelSizes() []int {
+	sizes := make([]int, sl.levels)
+	for level := range sizes {
+		for n := sl.head.next[level]; n != nil; n = n.next[level] {
+			sizes[level]++
+		}
+	}
+	return sizes
+}
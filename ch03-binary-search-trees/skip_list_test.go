@@ -0,0 +1,103 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkSkipList verifies every level is sorted, and holds only positions promoted
+// at least that far, which are all on the level below, then that the size is right
+// and the top level in use is not empty
+func checkSkipList(t *testing.T, sl *SkipList[int, int]) {
+	for level := 0; level < sl.options.maxLevels; level++ {
+		n := sl.head.next[level]
+		if level >= sl.levels {
+			assert.Nil(t, n, level)
+			continue
+		}
+		below := sl.head
+		for ; n != nil; n = n.next[level] {
+			assert.Greater(t, len(n.next), level)
+			if n.next[level] != nil {
+				assert.Less(t, n.key, n.next[level].key)
+			}
+			if level > 0 {
+				for below != n && below != nil {
+					below = below.next[level-1]
+				}
+				assert.Equal(t, n, below, "every position on a level is on the level below")
+			}
+		}
+	}
+	if sl.levels > 1 {
+		assert.NotNil(t, sl.head.next[sl.levels-1])
+	}
+	sizes := sl.LevelSizes()
+	assert.Equal(t, sl.size, sizes[0])
+}
+
+func TestSkipList(t *testing.T) {
+	r := rand.New(rand.NewSource(48))
+	testOrderedMap(t, func() *SkipList[int, int] { return NewSkipList[int, int](r) }, checkSkipList)
+	testOrderedMap(t, func() *SkipList[int, int] {
+		return NewSkipList[int, int](r, WithPromotionProbability(0.25), WithMaxLevels(4))
+	}, checkSkipList)
+}
+
+func TestSkipList_Seeded(t *testing.T) {
+	shape := func(seed int64) [][]int {
+		sl := NewSkipList[int, int](rand.New(rand.NewSource(seed)))
+		for _, k := range rand.New(rand.NewSource(48)).Perm(500) {
+			sl.Put(k, k)
+		}
+		var levels [][]int
+		for level := range sl.levels {
+			var keys []int
+			for n := sl.head.next[level]; n != nil; n = n.next[level] {
+				keys = append(keys, n.key)
+			}
+			levels = append(levels, keys)
+		}
+		return levels
+	}
+	assert.Equal(t, shape(48), shape(48))
+	assert.NotEqual(t, shape(48), shape(49))
+}
+
+func TestSkipList_PromotionProbability(t *testing.T) {
+	const n = 1 << 14
+	for _, p := range []float64{0.5, 0.25, 0.125} {
+		sl := NewSkipList[int, int](rand.New(rand.NewSource(48)), WithPromotionProbability(p))
+		for k := range n {
+			sl.Put(k, k)
+		}
+		checkSkipList(t, sl)
+
+		// each level should hold about p times as many positions as the one below,
+		// for about 1/(1-p) links per position in all
+		sizes := sl.LevelSizes()
+		links := 0
+		for level, size := range sizes {
+			links += size
+			if level > 0 && sizes[level-1] >= 1000 {
+				assert.InDelta(t, p, float64(size)/float64(sizes[level-1]), 0.05, p)
+			}
+		}
+		assert.InDelta(t, 1/(1-p), float64(links)/n, 0.05, p)
+	}
+
+	// probabilities outside (0, 1) are ignored
+	sl := NewSkipList[int, int](rand.New(rand.NewSource(48)), WithPromotionProbability(1), WithMaxLevels(0))
+	assert.Equal(t, DefaultPromotionProbability, sl.options.promotionProbability)
+	assert.Equal(t, DefaultMaxLevels, sl.options.maxLevels)
+
+	// the number of levels never goes past the cap, however many keys there are
+	sl = NewSkipList[int, int](rand.New(rand.NewSource(48)), WithMaxLevels(3))
+	for k := range n {
+		sl.Put(k, k)
+	}
+	assert.Len(t, sl.LevelSizes(), 3)
+	checkSkipList(t, sl)
+}
@@ -0,0 +1,359 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"math"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+)
+
+// SplayTree is a BinarySearchTree which moves every position it accesses up to the root,
+// so that keys accessed often, or close together, stay near the top and are quick to reach.
+// It never stores anything about balance, yet every operation takes O(log n) amortized time.
+//
+// Splaying is done top-down, in a single pass: walking down towards the key, the positions
+// passed are split off into a left tree of smaller keys and a right tree of larger keys,
+// rotating pairs of positions on the way when the path goes the same way twice, which
+// roughly halves the depth of every position on the path. Once the key, or the last
+// position before where it would be, is reached, it becomes the root, with the left and
+// right trees reassembled as its subtrees.
+//
+// SplayTree tracks its primitive operation "accounting credits" in a SplayLedger to
+// illustrate the potential method of analyzing amortized cost, in the same way
+// FibonacciHeap does. Every operation is charged its amortized cost up front, and every
+// link or rotation actually done while splaying spends 1 credit. The potential of the tree is
+//
+//	potential = sum over every position x of rank(x) = log2(size of the subtree at x)
+//
+// and the Access Lemma of Sleator and Tarjan says splaying a tree of n positions costs at most
+// 3 * (rank(root) - rank(x)) + 1 <= 3 log2(n) + 1 credits, once the change in potential is
+// paid for. Any operation that raises the rank of the root, like adding a position above it,
+// also has to pay for the potential that adds. As long as the costs charged are right, the
+// credits saved up always cover the potential built up since the ledger was started.
+//
+// Amortized costs charged:
+//   - each splay: 3 log2(n) + 1 credits
+//   - Put of a new key: 1 credit for linking in the new root, plus log2(n+1) for its rank
+//   - Delete: 1 credit for linking the two subtrees back together, with a second splay
+//     to find the largest key on the left; the potential can only go down
+//   - Split: 1 credit for cutting the tree in two; the potential can only go down
+//   - Join: 1 credit for linking the two trees, plus log2(n) for the rank of the new root
+//
+// SplayTree leaves the parent links of its positions unset,
+// since top-down splaying never needs to walk back up the tree.
+type SplayTree[K cmp.Ordered, V any] struct {
+	root   *node[K, V]
+	size   int
+	ledger SplayLedger
+}
+
+// SplayLedger is the record of credits kept by a SplayTree
+type SplayLedger struct {
+	Charged float64 // amortized cost charged to every operation so far
+	Spent   int     // primitive operations actually done, one per link or rotation
+}
+
+// Credits is the number of credits saved up, which should never be less than
+// the potential the tree has built up since the ledger was started
+func (l SplayLedger) Credits() float64 {
+	return l.Charged - float64(l.Spent)
+}
+
+func NewSplayTree[K cmp.Ordered, V any]() *SplayTree[K, V] {
+	return &SplayTree[K, V]{}
+}
+
+func (st *SplayTree[K, V]) Len() int {
+	return st.size
+}
+
+func (st *SplayTree[K, V]) Ledger() SplayLedger {
+	return st.ledger
+}
+
+// Potential adds up the ranks of all the positions in the tree, which takes O(n) time,
+// so it is for checking the ledger rather than for use by the tree itself
+func (st *SplayTree[K, V]) Potential() float64 {
+	var potential float64
+	var rank func(n *node[K, V])
+	rank = func(n *node[K, V]) {
+		if n != nil {
+			potential += math.Log2(float64(n.size))
+			rank(n.leftChild)
+			rank(n.rightChild)
+		}
+	}
+	rank(st.root)
+	return potential
+}
+
+// splay moves the position holding k up to the root, or if there is none,
+// the last position visited looking for it, which holds either the next smaller
+// or the next larger key in the tree
+func (st *SplayTree[K, V]) splay(k K) {
+	if st.root == nil {
+		return
+	}
+	st.ledger.Charged += 3*math.Log2(float64(st.size)) + 1
+
+	// header.rightChild is the root of the left tree, and header.leftChild the root of the
+	// right tree, with lastLeft being the largest key of the left tree, whose right child is
+	// where the next position goes, and lastRight the smallest key of the right tree
+	var header node[K, V]
+	lastLeft, lastRight := &header, &header
+	// the sizes of the left and right trees, since the sizes of the positions linked into
+	// them can only be worked out once they are complete
+	leftSize, rightSize := 0, 0
+	t := st.root
+	for {
+		if k < t.key {
+			if t.leftChild == nil {
+				break
+			}
+			if k < t.leftChild.key {
+				// zig-zig: rotate the left child up before linking
+				y := t.leftChild
+				t.leftChild, y.rightChild = y.rightChild, t
+				t.size = size(t.leftChild) + size(t.rightChild) + 1
+				t = y
+				st.ledger.Spent++
+				if t.leftChild == nil {
+					break
+				}
+			}
+			// link t into the right tree, since everything left in t's right subtree
+			// is larger than k
+			lastRight.leftChild = t
+			rightSize += size(t.rightChild) + 1
+			lastRight, t = t, t.leftChild
+			st.ledger.Spent++
+		} else if k > t.key {
+			if t.rightChild == nil {
+				break
+			}
+			if k > t.rightChild.key {
+				y := t.rightChild
+				t.rightChild, y.leftChild = y.leftChild, t
+				t.size = size(t.leftChild) + size(t.rightChild) + 1
+				t = y
+				st.ledger.Spent++
+				if t.rightChild == nil {
+					break
+				}
+			}
+			lastLeft.rightChild = t
+			leftSize += size(t.leftChild) + 1
+			lastLeft, t = t, t.rightChild
+			st.ledger.Spent++
+		} else {
+			break
+		}
+	}
+
+	// reassemble, with t's subtrees going to the inside edges of the left and right trees
+	lastLeft.rightChild, lastRight.leftChild = t.leftChild, t.rightChild
+	leftSize, rightSize = leftSize+size(t.leftChild), rightSize+size(t.rightChild)
+	t.leftChild, t.rightChild = header.rightChild, header.leftChild
+	t.size = leftSize + rightSize + 1
+	st.root = t
+
+	// walk back down the inside edges, where each position holds what is left
+	// of the size of the tree after taking away the positions above and outside it
+	for n := t.leftChild; n != nil && lastLeft != &header; n = n.rightChild {
+		n.size = leftSize
+		leftSize -= size(n.leftChild) + 1
+		if n == lastLeft {
+			break
+		}
+	}
+	for n := t.rightChild; n != nil && lastRight != &header; n = n.leftChild {
+		n.size = rightSize
+		rightSize -= size(n.rightChild) + 1
+		if n == lastRight {
+			break
+		}
+	}
+}
+
+// Get looks up the key, splaying it to the root
+func (st *SplayTree[K, V]) Get(k K) (V, error) {
+	st.splay(k)
+	if st.root == nil || st.root.key != k {
+		var zero V
+		return zero, KeyNotFoundError{}
+	}
+	return st.root.value, nil
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there.
+// After splaying, the root holds the next smaller or next larger key, so the new
+// position can go above it as the new root, taking one of its subtrees.
+func (st *SplayTree[K, V]) Put(k K, v V) {
+	st.splay(k)
+	if st.root != nil && st.root.key == k {
+		st.root.value = v
+		return
+	}
+
+	st.size++
+	n := &node[K, V]{key: k, value: v, size: st.size}
+	st.ledger.Charged += 1 + math.Log2(float64(st.size))
+	st.ledger.Spent++
+	switch {
+	case st.root == nil:
+	case k < st.root.key:
+		n.leftChild, n.rightChild = st.root.leftChild, st.root
+		st.root.leftChild = nil
+		st.root.size -= size(n.leftChild)
+	default:
+		n.leftChild, n.rightChild = st.root, st.root.rightChild
+		st.root.rightChild = nil
+		st.root.size -= size(n.rightChild)
+	}
+	st.root = n
+}
+
+// Delete removes the entry for the key, splaying it to the root,
+// then joining the subtrees left behind
+func (st *SplayTree[K, V]) Delete(k K) error {
+	st.splay(k)
+	if st.root == nil || st.root.key != k {
+		return KeyNotFoundError{}
+	}
+	left, right := st.root.leftChild, st.root.rightChild
+	st.root.leftChild, st.root.rightChild = nil, nil
+	st.size--
+	st.root = st.join(left, right)
+	return nil
+}
+
+// join links two trees, where every key of left is smaller than every key of right,
+// by splaying the largest key of left to its root, which leaves its right subtree empty
+func (st *SplayTree[K, V]) join(left, right *node[K, V]) *node[K, V] {
+	if left == nil {
+		return right
+	}
+	st.root = left
+	st.splay(maximum(left).key)
+	st.root.rightChild = right
+	st.root.size += size(right)
+	st.ledger.Charged++
+	st.ledger.Spent++
+	return st.root
+}
+
+// Split moves every entry with a key of at least k out into a new SplayTree,
+// which starts a ledger of its own
+func (st *SplayTree[K, V]) Split(k K) *SplayTree[K, V] {
+	other := NewSplayTree[K, V]()
+	st.splay(k)
+	if st.root == nil {
+		return other
+	}
+	st.ledger.Charged++
+	st.ledger.Spent++
+
+	if st.root.key >= k {
+		other.root, st.root = st.root, st.root.leftChild
+		other.root.leftChild = nil
+		other.root.size -= size(st.root)
+	} else {
+		other.root = st.root.rightChild
+		st.root.rightChild = nil
+		st.root.size -= size(other.root)
+	}
+	other.size = size(other.root)
+	st.size -= other.size
+	return other
+}
+
+// Join moves every entry of other into this tree, leaving other empty, as long as every key
+// of this tree is smaller than every key of other. The credits saved up in other come along
+// with its entries, since they still cover the potential of its positions.
+func (st *SplayTree[K, V]) Join(other *SplayTree[K, V]) error {
+	if st.root != nil && other.root != nil {
+		if maximum(st.root).key >= minimum(other.root).key {
+			return KeyOrderError{}
+		}
+	}
+
+	st.ledger.Charged += other.ledger.Charged
+	st.ledger.Spent += other.ledger.Spent
+	st.size += other.size
+	if st.root != nil && other.root != nil {
+		st.ledger.Charged += math.Log2(float64(st.size))
+	}
+	st.root = st.join(st.root, other.root)
+	other.root, other.size, other.ledger = nil, 0, SplayLedger{}
+	return nil
+}
+
+func (st *SplayTree[K, V]) Min() (Entry[K, V], error) {
+	if st.root == nil {
+		return nil, MapEmptyError{}
+	}
+	st.splay(minimum(st.root).key)
+	return st.root, nil
+}
+
+func (st *SplayTree[K, V]) Max() (Entry[K, V], error) {
+	if st.root == nil {
+		return nil, MapEmptyError{}
+	}
+	st.splay(maximum(st.root).key)
+	return st.root, nil
+}
+
+// Floor splays k, after which the root holds either the floor itself,
+// or the next key after the floor, in which case the floor is the largest key
+// of its left subtree, which is splayed up in turn
+func (st *SplayTree[K, V]) Floor(k K) (Entry[K, V], error) {
+	return st.splayNeighbour(k, func(root K) bool { return root <= k }, true)
+}
+
+func (st *SplayTree[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return st.splayNeighbour(k, func(root K) bool { return root >= k }, false)
+}
+
+func (st *SplayTree[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return st.splayNeighbour(k, func(root K) bool { return root < k }, true)
+}
+
+func (st *SplayTree[K, V]) Successor(k K) (Entry[K, V], error) {
+	return st.splayNeighbour(k, func(root K) bool { return root > k }, false)
+}
+
+// splayNeighbour splays k, then if the root doesn't satisfy ok, splays the nearest key
+// on the given side of the root, which is the nearest key to k on that side
+func (st *SplayTree[K, V]) splayNeighbour(k K, ok func(root K) bool, left bool) (Entry[K, V], error) {
+	st.splay(k)
+	if st.root == nil {
+		return nil, KeyNotFoundError{}
+	}
+	if ok(st.root.key) {
+		return st.root, nil
+	}
+	side := child(st.root, left)
+	if side == nil {
+		return nil, KeyNotFoundError{}
+	}
+	if left {
+		st.splay(maximum(side).key)
+	} else {
+		st.splay(minimum(side).key)
+	}
+	return st.root, nil
+}
+
+// All iterates over the entries in order of their keys, without splaying any of them
+func (st *SplayTree[K, V]) All() iter.Seq2[K, V] {
+	return inOrderStack(st.root)
+}
+
+// Tree copies the shape of the map into a trees.BinaryTree holding its keys,
+// so it can be printed, drawn or measured with the tools of the trees package.
+// An empty map gives an empty, nil, tree.
+func (st *SplayTree[K, V]) Tree() *trees.BinaryTree[K] {
+	return shape(st.root, func(n *node[K, V]) K { return n.key })
+}
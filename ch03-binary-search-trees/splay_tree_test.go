@@ -0,0 +1,212 @@
+package binarysearchtrees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkSplayTree verifies the keys are in order and every position holds the size of its
+// subtree, then that the credits saved up cover the potential of the tree, which started
+// out empty with no potential
+func checkSplayTree(t *testing.T, st *SplayTree[int, int]) {
+	assert.Equal(t, st.size, checkSizedSubtree(t, st.root, nil, nil, nil))
+	checkLedger(t, st, 0)
+}
+
+// checkLedger verifies the credits saved up cover the potential built up since the ledger
+// was started, when the tree had the starting potential, allowing for rounding
+func checkLedger(t *testing.T, st *SplayTree[int, int], starting float64) {
+	assert.GreaterOrEqual(t, st.Ledger().Credits()+1e-6, st.Potential()-starting)
+}
+
+func TestSplayTree(t *testing.T) {
+	testOrderedMap(t, NewSplayTree[int, int], checkSplayTree)
+}
+
+func newSplayTree(keys ...int) *SplayTree[int, int] {
+	st := NewSplayTree[int, int]()
+	for _, k := range keys {
+		st.Put(k, -k)
+	}
+	return st
+}
+
+func TestSplayTree_Splay(t *testing.T) {
+	assert := assert.New(t)
+
+	// adding keys in order leaves a path down the left, with each new key at the root
+	st := newSplayTree(1, 2, 3, 4, 5, 6, 7)
+	assert.Equal("7(6(5(4(3(2(1,),),),),),)", st.Tree().Parenthetic())
+
+	// splaying the deepest key rotates pairs of positions on the way down,
+	// roughly halving the depth of the path
+	v, err := st.Get(1)
+	assert.Nil(err)
+	assert.Equal(-1, v)
+	assert.Equal("1(,6(4(2(,3),5),7))", st.Tree().Parenthetic())
+
+	// a key that isn't there still brings the last position looked at up to the root
+	_, err = st.Get(8)
+	assert.Equal(KeyNotFoundError{}, err)
+	assert.Equal(7, st.root.key)
+
+	e, err := st.Floor(0)
+	assert.Equal(KeyNotFoundError{}, err)
+	assert.Nil(e)
+	e, err = st.Ceiling(0)
+	assert.Nil(err)
+	assert.Equal(1, e.Key())
+	assert.Equal(1, st.root.key)
+	e, err = st.Predecessor(5)
+	assert.Nil(err)
+	assert.Equal(4, e.Key())
+	assert.Equal(4, st.root.key)
+	checkSplayTree(t, st)
+}
+
+func TestSplayTree_SplitJoin(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(47))
+
+	for _, k := range []int{-1, 0, 17, 50, 99, 100} {
+		st := newSplayTree(r.Perm(100)...)
+		right := st.Split(k)
+		low, high := max(0, min(k, 100)), 100-max(0, min(k, 100))
+		assert.Equal(low, st.Len(), k)
+		assert.Equal(high, right.Len(), k)
+		checkSplayTree(t, st)
+		// the tree split off starts a ledger of its own, with the potential it already had
+		starting := right.Potential()
+		assert.Equal(SplayLedger{}, right.Ledger())
+		below := k - 1
+		assert.Equal(high, checkSizedSubtree(t, right.root, &below, nil, nil))
+		for key := range st.All() {
+			assert.Less(key, k)
+		}
+		for key := range right.All() {
+			assert.GreaterOrEqual(key, k)
+		}
+
+		for i := 0; i < 50; i++ {
+			_, _ = right.Get(k + r.Intn(100))
+			checkLedger(t, right, starting)
+		}
+
+		assert.Nil(st.Join(right))
+		assert.Equal(100, st.Len())
+		assert.Equal(0, right.Len())
+		assert.Nil(right.root)
+		checkSizedSubtree(t, st.root, nil, nil, nil)
+		checkLedger(t, st, starting)
+		for i := 0; i < 100; i++ {
+			v, err := st.Get(i)
+			assert.Nil(err)
+			assert.Equal(-i, v)
+		}
+	}
+
+	// joining a tree whose keys aren't all larger leaves both alone
+	st, other := newSplayTree(1, 5), newSplayTree(3, 7)
+	assert.Equal(KeyOrderError{}, st.Join(other))
+	assert.Equal(2, st.Len())
+	assert.Equal(2, other.Len())
+
+	empty := NewSplayTree[int, int]()
+	assert.Equal(0, empty.Split(3).Len())
+	assert.Nil(empty.Join(other))
+	assert.Equal(2, empty.Len())
+	assert.Nil(other.Join(NewSplayTree[int, int]()))
+}
+
+// TestSplayTree_AmortizedBound runs access sequences which are bad for some other trees,
+// or which make single operations on a splay tree expensive, checking after every access
+// that the credits cover the potential, and that the total cost stays within O(log n) per access
+func TestSplayTree_AmortizedBound(t *testing.T) {
+	const n = 1 << 10
+	r := rand.New(rand.NewSource(47))
+
+	reversed := make([]int, n)
+	for i := range reversed {
+		reversed[i] = n - 1 - i
+	}
+	// bit reversal permutations are as bad as any sequence for a splay tree
+	bitReversed := make([]int, n)
+	for i := range bitReversed {
+		for bit := 1; bit < n; bit <<= 1 {
+			if i&bit != 0 {
+				bitReversed[i] |= n / bit / 2
+			}
+		}
+	}
+	accesses := []struct {
+		name string
+		keys []int
+	}{
+		{name: "sequential", keys: sequence(n)},
+		{name: "reversed", keys: reversed},
+		{name: "bit reversed", keys: bitReversed},
+		{name: "random", keys: r.Perm(n)},
+	}
+
+	for _, inserts := range accesses {
+		for _, access := range accesses {
+			t.Run(inserts.name+" inserts/"+access.name+" accesses", func(t *testing.T) {
+				st := NewSplayTree[int, int]()
+				for _, k := range inserts.keys {
+					st.Put(k, k)
+				}
+				checkSplayTree(t, st)
+
+				for round := 0; round < 3; round++ {
+					for i, k := range access.keys {
+						_, err := st.Get(k)
+						assert.Nil(t, err)
+						checkLedger(t, st, 0)
+						// the whole tree takes longer to check, so only every so often
+						if i%64 == 0 {
+							checkSplayTree(t, st)
+						}
+					}
+				}
+				checkSplayTree(t, st)
+
+				// 4 full passes of n operations at no more than 3 log2(n) + 2 credits each
+				assert.LessOrEqual(t, st.Ledger().Spent, 4*n*(3*10+2))
+			})
+		}
+	}
+
+	// a splay tree built from sorted keys is a path, and the first access is as slow as
+	// it could be, costing n-1 links and rotations, but the credits saved up adding the
+	// keys pay for it
+	st := newSplayTree(sequence(n)...)
+	before := st.Ledger().Spent
+	_, _ = st.Get(0)
+	assert.Equal(t, n-1, st.Ledger().Spent-before)
+	checkSplayTree(t, st)
+}
+
+func BenchmarkSplayTree(b *testing.B) {
+	const n = 1 << 13
+	r := rand.New(rand.NewSource(47))
+	st := newSplayTree(r.Perm(n)...)
+	b.Run("sequential get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = st.Get(i % n)
+		}
+	})
+	// accessing a few keys over and over keeps them near the root
+	b.Run("working set get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = st.Get(i % 16 * 97)
+		}
+	})
+	b.Run("split and join", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			right := st.Split(r.Intn(n))
+			_ = st.Join(right)
+		}
+	})
+}
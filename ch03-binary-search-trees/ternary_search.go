@@ -0,0 +1,73 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"math"
+)
+
+// TernarySearch finds the x in [low, high] at which the unimodal function f is smallest,
+// to within epsilon, where f falls to its minimum and then rises again.
+// To find the maximum of a function which rises and then falls, search its negative.
+//
+// Comparing f at two points a third and two thirds of the way along the range shows which
+// side of one of them the minimum can't be on, since f is still falling to the left of the
+// minimum and already rising to its right. Each step throws away a third of the range.
+func TernarySearch(f func(float64) float64, low, high, epsilon float64) float64 {
+	for high-low > epsilon && !converged(low, high) {
+		third := (high - low) / 3
+		left, right := low+third, high-third
+		if f(left) < f(right) {
+			high = right
+		} else {
+			low = left
+		}
+	}
+	return low + (high-low)/2
+}
+
+// invPhi is one over the golden ratio, the fraction of the range GoldenSectionSearch keeps
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// GoldenSectionSearch finds the x in [low, high] at which the unimodal function f is
+// smallest, to within epsilon, like TernarySearch, but needs fewer evaluations of f.
+//
+// Placing the two points at the golden ratio along the range, rather than at thirds,
+// means that the point kept from one step lands exactly where one of the two points of the
+// next step needs to be. Each step then evaluates f only once while keeping about 62% of
+// the range, where TernarySearch evaluates f twice to keep 67% of it.
+func GoldenSectionSearch(f func(float64) float64, low, high, epsilon float64) float64 {
+	left, right := high-invPhi*(high-low), low+invPhi*(high-low)
+	fLeft, fRight := f(left), f(right)
+	for high-low > epsilon && !converged(low, high) {
+		if fLeft < fRight {
+			high, right, fRight = right, left, fLeft
+			left = high - invPhi*(high-low)
+			fLeft = f(left)
+		} else {
+			low, left, fLeft = left, right, fRight
+			right = low + invPhi*(high-low)
+			fRight = f(right)
+		}
+	}
+	return low + (high-low)/2
+}
+
+// converged reports whether low and high are so close together that there are no
+// floating point numbers left between them, so an epsilon too small to ever reach
+// can't keep a search going forever
+func converged(low, high float64) bool {
+	middle := low + (high-low)/2
+	return middle <= low || middle >= high
+}
+
+// UnimodalMinimum finds the smallest x in [low, high] at which the function f is smallest,
+// where f strictly falls to its minimum, stays there for any number of integers,
+// then strictly rises again.
+//
+// On integers there is no need to split the range in three: the minimum is the first x
+// at which f stops falling, so that f(x) <= f(x+1), and binary search can find that x.
+func UnimodalMinimum[T cmp.Ordered](f func(int) T, low, high int) int {
+	return low + partitionPoint(high-low, func(i int) bool {
+		return f(low+i) <= f(low+i+1)
+	})
+}
@@ -0,0 +1,57 @@
+package binarysearchtrees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTernarySearch(t *testing.T) {
+	parabola := func(x float64) float64 { return (x - 1.7) * (x - 1.7) }
+	assert.InDelta(t, 1.7, TernarySearch(parabola, -10, 10, 1e-9), 1e-6)
+	assert.InDelta(t, 1.7, GoldenSectionSearch(parabola, -10, 10, 1e-9), 1e-6)
+
+	// the maximum of sin on [0, pi] is at pi/2
+	negSin := func(x float64) float64 { return -math.Sin(x) }
+	assert.InDelta(t, math.Pi/2, TernarySearch(negSin, 0, math.Pi, 1e-9), 1e-6)
+	assert.InDelta(t, math.Pi/2, GoldenSectionSearch(negSin, 0, math.Pi, 1e-9), 1e-6)
+
+	// a minimum at the end of the range
+	rising := func(x float64) float64 { return x }
+	assert.InDelta(t, 2, TernarySearch(rising, 2, 5, 1e-9), 1e-6)
+	assert.InDelta(t, 2, GoldenSectionSearch(rising, 2, 5, 1e-9), 1e-6)
+
+	// an epsilon too small to ever reach still ends the search
+	assert.InDelta(t, 1.7, TernarySearch(parabola, -10, 10, 0), 1e-6)
+	assert.InDelta(t, 1.7, GoldenSectionSearch(parabola, -10, 10, 0), 1e-6)
+}
+
+func TestGoldenSectionSearch_Evaluations(t *testing.T) {
+	evaluations := 0
+	parabola := func(x float64) float64 {
+		evaluations++
+		return (x - 1.7) * (x - 1.7)
+	}
+	GoldenSectionSearch(parabola, -10, 10, 1e-6)
+	golden := evaluations
+
+	evaluations = 0
+	TernarySearch(parabola, -10, 10, 1e-6)
+	assert.Less(t, golden, evaluations)
+}
+
+func TestUnimodalMinimum(t *testing.T) {
+	valley := func(x int) int { return (x - 12) * (x - 12) }
+	assert.Equal(t, 12, UnimodalMinimum(valley, -100, 100))
+	assert.Equal(t, 20, UnimodalMinimum(valley, 20, 100))
+	assert.Equal(t, 5, UnimodalMinimum(valley, -100, 5))
+	assert.Equal(t, 7, UnimodalMinimum(valley, 7, 7))
+
+	// the first of a flat run of minimums
+	plateau := func(x int) int { return max(3-x, 0, x-8) }
+	assert.Equal(t, 3, UnimodalMinimum(plateau, 0, 20))
+
+	words := []string{"pear", "fig", "apple", "date", "kiwi"}
+	assert.Equal(t, 2, UnimodalMinimum(func(i int) string { return words[i] }, 0, len(words)-1))
+}
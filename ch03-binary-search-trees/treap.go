@@ -0,0 +1,323 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+)
+
+// Treap is a BinarySearchTree whose positions are also in heap order, by a priority drawn at
+// random for each position when it is added, with every position's priority at least as high
+// as its children's. For a given set of keys and priorities there is exactly one such tree,
+// the one you get by adding the keys to a plain BinarySearchTree in order of priority, so a
+// treap is shaped like a binary search tree built from its keys in random order, whatever
+// order they really arrived in, and has an expected depth of O(log n).
+//
+// Everything a treap does is built from two operations:
+//   - split cuts a treap into the keys before k and the keys from k on, by following the
+//     search path for k, handing each position along it to one side or the other
+//   - merge joins two treaps, where every key of the first is smaller than every key of the
+//     second, by zipping down the right edge of the first and the left edge of the second,
+//     taking whichever position has the higher priority at each step
+//
+// each taking time proportional to the depth of the treaps, which is O(log n) expected.
+//
+// Put splits the treap where the new key belongs, at the depth its priority puts it, and hangs
+// the two halves under it. Delete merges the subtrees of the position it removes. The set
+// operations Union, Intersection and Difference split one treap around the root of the other,
+// and recurse on the two halves, which takes O(m log(n/m + 1)) expected time for treaps of
+// m <= n keys. That is never worse than the O(m log n) of adding or removing the keys of the
+// smaller treap one at a time, and falls to O(n) when the treaps are about the same size.
+//
+// The priorities come from the rand.Rand the treap is given, so a treap given a rand.Rand
+// with the same seed and the same operations always has the same shape. Treap leaves the
+// parent links of its positions unset, since split and merge only ever work downwards.
+type Treap[K cmp.Ordered, V any] struct {
+	root *node[K, V]
+	r    *rand.Rand
+}
+
+func NewTreap[K cmp.Ordered, V any](r *rand.Rand) *Treap[K, V] {
+	return &Treap[K, V]{r: r}
+}
+
+func (tr *Treap[K, V]) Len() int {
+	return size(tr.root)
+}
+
+// resize recomputes the size of the subtree at n from its children
+func resize[K cmp.Ordered, V any](n *node[K, V]) *node[K, V] {
+	n.size = size(n.leftChild) + size(n.rightChild) + 1
+	return n
+}
+
+// split cuts the treap at n into the keys smaller than k and the keys from k on.
+// If n's key is smaller than k, n and its left subtree belong on the left, and only its
+// right subtree needs splitting, with everything from it smaller than k going under n.
+func split[K cmp.Ordered, V any](n *node[K, V], k K) (left, right *node[K, V]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.key < k {
+		n.rightChild, right = split(n.rightChild, k)
+		return resize(n), right
+	}
+	left, n.leftChild = split(n.leftChild, k)
+	return left, resize(n)
+}
+
+// splitOut cuts the treap at n into the keys smaller than k, the position holding k,
+// detached from the rest, or nil if there is none, and the keys larger than k
+func splitOut[K cmp.Ordered, V any](n *node[K, V], k K) (left, middle, right *node[K, V]) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	switch {
+	case n.key < k:
+		n.rightChild, middle, right = splitOut(n.rightChild, k)
+		return resize(n), middle, right
+	case n.key > k:
+		left, middle, n.leftChild = splitOut(n.leftChild, k)
+		return left, middle, resize(n)
+	default:
+		left, right = n.leftChild, n.rightChild
+		n.leftChild, n.rightChild = nil, nil
+		return left, resize(n), right
+	}
+}
+
+// merge joins two treaps, where every key of left is smaller than every key of right.
+// Whichever root has the higher priority stays the root, with the other treap merged
+// into its inside subtree, the right subtree of the left root or the left of the right root.
+func merge[K cmp.Ordered, V any](left, right *node[K, V]) *node[K, V] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority >= right.priority:
+		left.rightChild = merge(left.rightChild, right)
+		return resize(left)
+	default:
+		right.leftChild = merge(left, right.leftChild)
+		return resize(right)
+	}
+}
+
+func (tr *Treap[K, V]) Get(k K) (V, error) {
+	n := search(tr.root, k)
+	if n == nil || n.key != k {
+		var zero V
+		return zero, KeyNotFoundError{}
+	}
+	return n.value, nil
+}
+
+// Put adds an entry for the key, or replaces the value of the entry already there.
+// A new position goes down the search path until it reaches a position with a lower priority,
+// where it takes that position's place, with the subtree it displaced split around its key.
+func (tr *Treap[K, V]) Put(k K, v V) {
+	if n := search(tr.root, k); n != nil && n.key == k {
+		n.value = v
+		return
+	}
+	tr.root = insertTreap(tr.root, &node[K, V]{key: k, value: v, priority: tr.r.Uint64(), size: 1})
+}
+
+func insertTreap[K cmp.Ordered, V any](n, added *node[K, V]) *node[K, V] {
+	if n == nil {
+		return added
+	}
+	if added.priority > n.priority {
+		added.leftChild, added.rightChild = split(n, added.key)
+		return resize(added)
+	}
+	if added.key < n.key {
+		n.leftChild = insertTreap(n.leftChild, added)
+	} else {
+		n.rightChild = insertTreap(n.rightChild, added)
+	}
+	return resize(n)
+}
+
+// Delete removes the entry for the key, merging the subtrees of its position in its place
+func (tr *Treap[K, V]) Delete(k K) error {
+	root, ok := deleteTreap(tr.root, k)
+	if !ok {
+		return KeyNotFoundError{}
+	}
+	tr.root = root
+	return nil
+}
+
+func deleteTreap[K cmp.Ordered, V any](n *node[K, V], k K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var ok bool
+	switch {
+	case k < n.key:
+		n.leftChild, ok = deleteTreap(n.leftChild, k)
+	case k > n.key:
+		n.rightChild, ok = deleteTreap(n.rightChild, k)
+	default:
+		return merge(n.leftChild, n.rightChild), true
+	}
+	return resize(n), ok
+}
+
+// Split moves every entry with a key of at least k out into a new Treap,
+// which draws its priorities from the same rand.Rand
+func (tr *Treap[K, V]) Split(k K) *Treap[K, V] {
+	other := NewTreap[K, V](tr.r)
+	tr.root, other.root = split(tr.root, k)
+	return other
+}
+
+// Merge moves every entry of other into this treap, leaving other empty, as long as every
+// key of this treap is smaller than every key of other
+func (tr *Treap[K, V]) Merge(other *Treap[K, V]) error {
+	if tr.root != nil && other.root != nil && maximum(tr.root).key >= minimum(other.root).key {
+		return KeyOrderError{}
+	}
+	tr.root, other.root = merge(tr.root, other.root), nil
+	return nil
+}
+
+// Union moves every entry of other into this treap, where the value already in this treap
+// wins for keys in both, leaving other empty, since its positions may have moved into this one
+func (tr *Treap[K, V]) Union(other *Treap[K, V]) {
+	if other == tr {
+		return
+	}
+	tr.root, other.root = union(tr.root, other.root), nil
+}
+
+// Intersection keeps only the entries of this treap whose keys are also in other,
+// leaving other empty, since its positions may have moved into this one
+func (tr *Treap[K, V]) Intersection(other *Treap[K, V]) {
+	if other == tr {
+		return
+	}
+	tr.root, other.root = intersection(tr.root, other.root), nil
+}
+
+// Difference keeps only the entries of this treap whose keys are not in other,
+// leaving other empty, since its positions may have moved into this one
+func (tr *Treap[K, V]) Difference(other *Treap[K, V]) {
+	if other == tr {
+		tr.root = nil
+		return
+	}
+	tr.root, other.root = difference(tr.root, other.root), nil
+}
+
+// union keeps the root with the higher priority as the root of the result, splitting the
+// other treap around its key, then takes the union of the treaps either side of it. If the
+// root comes from b, and a has its key too, the value from a is moved into it.
+func union[K cmp.Ordered, V any](a, b *node[K, V]) *node[K, V] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.priority >= b.priority:
+		left, _, right := splitOut(b, a.key)
+		a.leftChild, a.rightChild = union(a.leftChild, left), union(a.rightChild, right)
+		return resize(a)
+	default:
+		left, middle, right := splitOut(a, b.key)
+		if middle != nil {
+			b.value = middle.value
+		}
+		b.leftChild, b.rightChild = union(left, b.leftChild), union(right, b.rightChild)
+		return resize(b)
+	}
+}
+
+// intersection splits around the root with the higher priority in the same way as union,
+// keeping the root only if both treaps have its key, and merging the two halves otherwise
+func intersection[K cmp.Ordered, V any](a, b *node[K, V]) *node[K, V] {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a.priority >= b.priority {
+		left, middle, right := splitOut(b, a.key)
+		l, r := intersection(a.leftChild, left), intersection(a.rightChild, right)
+		if middle == nil {
+			return merge(l, r)
+		}
+		a.leftChild, a.rightChild = l, r
+		return resize(a)
+	}
+	left, middle, right := splitOut(a, b.key)
+	l, r := intersection(left, b.leftChild), intersection(right, b.rightChild)
+	if middle == nil {
+		return merge(l, r)
+	}
+	b.value, b.leftChild, b.rightChild = middle.value, l, r
+	return resize(b)
+}
+
+// difference splits around the root with the higher priority in the same way as union,
+// keeping the root only if it is a's and b doesn't have its key
+func difference[K cmp.Ordered, V any](a, b *node[K, V]) *node[K, V] {
+	if a == nil || b == nil {
+		return a
+	}
+	if a.priority >= b.priority {
+		left, middle, right := splitOut(b, a.key)
+		l, r := difference(a.leftChild, left), difference(a.rightChild, right)
+		if middle != nil {
+			return merge(l, r)
+		}
+		a.leftChild, a.rightChild = l, r
+		return resize(a)
+	}
+	left, _, right := splitOut(a, b.key)
+	return merge(difference(left, b.leftChild), difference(right, b.rightChild))
+}
+
+func (tr *Treap[K, V]) Min() (Entry[K, V], error) {
+	if tr.root == nil {
+		return nil, MapEmptyError{}
+	}
+	return minimum(tr.root), nil
+}
+
+func (tr *Treap[K, V]) Max() (Entry[K, V], error) {
+	if tr.root == nil {
+		return nil, MapEmptyError{}
+	}
+	return maximum(tr.root), nil
+}
+
+func (tr *Treap[K, V]) Floor(k K) (Entry[K, V], error) {
+	return found(floor(tr.root, k, true))
+}
+
+func (tr *Treap[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return found(ceiling(tr.root, k, true))
+}
+
+func (tr *Treap[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return found(floor(tr.root, k, false))
+}
+
+func (tr *Treap[K, V]) Successor(k K) (Entry[K, V], error) {
+	return found(ceiling(tr.root, k, false))
+}
+
+// All iterates over the entries in order of their keys
+func (tr *Treap[K, V]) All() iter.Seq2[K, V] {
+	return inOrderStack(tr.root)
+}
+
+// Tree copies the shape of the map into a trees.BinaryTree holding its keys,
+// so it can be printed, drawn or measured with the tools of the trees package.
+// An empty map gives an empty, nil, tree.
+func (tr *Treap[K, V]) Tree() *trees.BinaryTree[K] {
+	return shape(tr.root, func(n *node[K, V]) K { return n.key })
+}
@@ -0,0 +1,189 @@
+package binarysearchtrees
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkTreap verifies the binary search tree invariants, then that every position
+// has a priority no lower than its children's, and holds the size of its subtree
+func checkTreap(t *testing.T, tr *Treap[int, int]) {
+	checkSizedSubtree(t, tr.root, nil, nil, func(n *node[int, int]) {
+		for _, child := range []*node[int, int]{n.leftChild, n.rightChild} {
+			if child != nil {
+				assert.GreaterOrEqual(t, n.priority, child.priority, n.key)
+			}
+		}
+	})
+}
+
+func TestTreap(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	testOrderedMap(t, func() *Treap[int, int] { return NewTreap[int, int](r) }, checkTreap)
+}
+
+func newTreap(r *rand.Rand, keys ...int) *Treap[int, int] {
+	tr := NewTreap[int, int](r)
+	for _, k := range keys {
+		tr.Put(k, -k)
+	}
+	return tr
+}
+
+func TestTreap_Seeded(t *testing.T) {
+	keys := rand.New(rand.NewSource(50)).Perm(200)
+	shape := func(seed int64) string {
+		return newTreap(rand.New(rand.NewSource(seed)), keys...).Tree().Parenthetic()
+	}
+	assert.Equal(t, shape(50), shape(50))
+	assert.NotEqual(t, shape(50), shape(51))
+}
+
+// TestTreap_Height adds keys in sorted order, which makes a path of a plain binary search tree,
+// but gives a treap the same expected depth as adding them in random order, about 2 ln(n)
+func TestTreap_Height(t *testing.T) {
+	const n = 1 << 14
+	tr := newTreap(rand.New(rand.NewSource(50)), sequence(n)...)
+	checkTreap(t, tr)
+	assert.Less(t, tr.Tree().Height(), int(4*math.Log(n)))
+}
+
+func TestTreap_SplitMerge(t *testing.T) {
+	assert := assert.New(t)
+	r := rand.New(rand.NewSource(50))
+
+	for _, k := range []int{-1, 0, 17, 50, 99, 100} {
+		tr := newTreap(r, r.Perm(100)...)
+		right := tr.Split(k)
+		low := max(0, min(k, 100))
+		assert.Equal(low, tr.Len(), k)
+		assert.Equal(100-low, right.Len(), k)
+		checkTreap(t, tr)
+		checkTreap(t, right)
+		for key := range tr.All() {
+			assert.Less(key, k)
+		}
+		for key := range right.All() {
+			assert.GreaterOrEqual(key, k)
+		}
+
+		assert.Nil(tr.Merge(right))
+		assert.Equal(100, tr.Len())
+		assert.Equal(0, right.Len())
+		checkTreap(t, tr)
+		for i := 0; i < 100; i++ {
+			v, err := tr.Get(i)
+			assert.Nil(err)
+			assert.Equal(-i, v)
+		}
+	}
+
+	tr, other := newTreap(r, 1, 5), newTreap(r, 3, 7)
+	assert.Equal(KeyOrderError{}, tr.Merge(other))
+	assert.Equal(2, tr.Len())
+	assert.Equal(2, other.Len())
+	assert.Nil(NewTreap[int, int](r).Merge(other))
+}
+
+func TestTreap_SetOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	// build a pair of treaps, the first with values from 0 to 9, and the second from 10 on,
+	// so it is clear which treap each value in the result came from
+	pair := func(n, m, keyRange int) (*Treap[int, int], *Treap[int, int], map[int]int, map[int]int) {
+		a, b := NewTreap[int, int](r), NewTreap[int, int](r)
+		aKeys, bKeys := map[int]int{}, map[int]int{}
+		for range n {
+			k, v := r.Intn(keyRange), r.Intn(10)
+			a.Put(k, v)
+			aKeys[k] = v
+		}
+		for range m {
+			k, v := r.Intn(keyRange), 10+r.Intn(10)
+			b.Put(k, v)
+			bKeys[k] = v
+		}
+		return a, b, aKeys, bKeys
+	}
+	// expect collects the keys kept by an operation into a model, with the value from a
+	// when a has the key, and checks the treap matches it
+	expect := func(tr *Treap[int, int], aKeys, bKeys map[int]int, keep func(inA, inB bool) bool) {
+		model := &modelMap{}
+		for k, v := range bKeys {
+			if _, inA := aKeys[k]; !inA && keep(false, true) {
+				model.put(k, v)
+			}
+		}
+		for k, v := range aKeys {
+			if _, inB := bKeys[k]; keep(true, inB) {
+				model.put(k, v)
+			}
+		}
+		checkTreap(t, tr)
+		assertMatchesModelMap(t, model, tr)
+	}
+
+	sizes := []struct{ n, m, keyRange int }{
+		{0, 0, 10}, {0, 20, 50}, {20, 0, 50}, {30, 30, 50}, {200, 10, 500}, {10, 200, 500}, {300, 300, 200},
+	}
+	for _, s := range sizes {
+		a, b, aKeys, bKeys := pair(s.n, s.m, s.keyRange)
+		a.Union(b)
+		assert.Equal(t, 0, b.Len())
+		expect(a, aKeys, bKeys, func(inA, inB bool) bool { return inA || inB })
+
+		a, b, aKeys, bKeys = pair(s.n, s.m, s.keyRange)
+		a.Intersection(b)
+		assert.Equal(t, 0, b.Len())
+		expect(a, aKeys, bKeys, func(inA, inB bool) bool { return inA && inB })
+
+		a, b, aKeys, bKeys = pair(s.n, s.m, s.keyRange)
+		a.Difference(b)
+		assert.Equal(t, 0, b.Len())
+		expect(a, aKeys, bKeys, func(inA, inB bool) bool { return inA && !inB })
+
+		// a treap with itself has every key in both
+		a, _, aKeys, _ = pair(s.n, 0, s.keyRange)
+		a.Union(a)
+		expect(a, aKeys, aKeys, func(inA, inB bool) bool { return inA || inB })
+		a.Intersection(a)
+		expect(a, aKeys, aKeys, func(inA, inB bool) bool { return inA && inB })
+		a.Difference(a)
+		expect(a, aKeys, aKeys, func(inA, inB bool) bool { return inA && !inB })
+	}
+}
+
+// BenchmarkTreap_Union compares taking the union of two treaps against adding the keys
+// of one to the other one at a time, which is worst when they are about the same size
+func BenchmarkTreap_Union(b *testing.B) {
+	const n = 1 << 16
+	r := rand.New(rand.NewSource(50))
+	large := r.Perm(n)
+	for _, m := range []int{1 << 8, n} {
+		small := make([]int, m)
+		for i := range small {
+			small[i] = r.Intn(2 * n)
+		}
+		b.Run("union/"+strconv.Itoa(m), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tr, other := newTreap(r, large...), newTreap(r, small...)
+				b.StartTimer()
+				tr.Union(other)
+			}
+		})
+		b.Run("put/"+strconv.Itoa(m), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tr := newTreap(r, large...)
+				b.StartTimer()
+				for _, k := range small {
+					tr.Put(k, -k)
+				}
+			}
+		})
+	}
+}
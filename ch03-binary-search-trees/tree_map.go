@@ -0,0 +1,408 @@
+package binarysearchtrees
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	trees "algorithms-and-data-structures/ch02-basic-data-structures/04-trees"
+)
+
+// node is a position in a binary search tree, with the same parent and child links
+// as a trees.BinaryTree, holding an entry of the map
+type node[K cmp.Ordered, V any] struct {
+	key        K
+	value      V
+	parent     *node[K, V]
+	leftChild  *node[K, V]
+	rightChild *node[K, V]
+	// height is the number of levels of the subtree, only kept up to date by AVLTree
+	height int
+	// size is the number of positions in the subtree, only kept up to date by SplayTree and Treap
+	size int
+	// priority is drawn at random for each position of a Treap, which keeps it in heap order
+	priority uint64
+	// red is the colour of the position in a red-black tree, where a nil child counts as black
+	red bool
+}
+
+func (n *node[K, V]) Key() K {
+	return n.key
+}
+
+func (n *node[K, V]) Value() V {
+	return n.value
+}
+
+// treeMap is the binary search tree at the core of every tree-based OrderedMap in the
+// package. It answers all the queries, which work the same way however the tree is shaped,
+// and does the plain binary search tree insertion and deletion, which each balanced tree
+// follows up with its own rebalancing.
+type treeMap[K cmp.Ordered, V any] struct {
+	root    *node[K, V]
+	size    int
+	options treeMapOptions
+	// label names a position in LabelledTree, defaulting to its key
+	label func(n *node[K, V]) string
+}
+
+// RotationHook is called after every rotation with a snapshot of the whole tree, labelled
+// the same way as LabelledTree, so that the steps of rebalancing can be printed or drawn
+// with the trees package. The snapshot is taken part way through an operation, so anything
+// else the rebalancing keeps up to date, like the colours of a red-black tree,
+// may not have caught up with the rotation yet.
+type RotationHook func(tree *trees.BinaryTree[string])
+
+type treeMapOptions struct {
+	rotationHook RotationHook
+}
+
+type TreeMapOpt func(opts *treeMapOptions)
+
+// WithRotationHook calls the hook after every rotation made by a self-balancing tree.
+// Taking a snapshot of the tree takes O(n) time, so the hook is for inspecting
+// small trees while learning or debugging, not for use in production.
+func WithRotationHook(hook RotationHook) TreeMapOpt {
+	return func(opts *treeMapOptions) {
+		opts.rotationHook = hook
+	}
+}
+
+func newTreeMapOptions(opts []TreeMapOpt) treeMapOptions {
+	var options treeMapOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func (tm *treeMap[K, V]) Len() int {
+	return tm.size
+}
+
+func (tm *treeMap[K, V]) Get(k K) (V, error) {
+	n := search(tm.root, k)
+	if n == nil || n.key != k {
+		var zero V
+		return zero, KeyNotFoundError{}
+	}
+	return n.value, nil
+}
+
+func (tm *treeMap[K, V]) Min() (Entry[K, V], error) {
+	if tm.root == nil {
+		return nil, MapEmptyError{}
+	}
+	return minimum(tm.root), nil
+}
+
+func (tm *treeMap[K, V]) Max() (Entry[K, V], error) {
+	if tm.root == nil {
+		return nil, MapEmptyError{}
+	}
+	return maximum(tm.root), nil
+}
+
+func (tm *treeMap[K, V]) Floor(k K) (Entry[K, V], error) {
+	return found(floor(tm.root, k, true))
+}
+
+func (tm *treeMap[K, V]) Ceiling(k K) (Entry[K, V], error) {
+	return found(ceiling(tm.root, k, true))
+}
+
+func (tm *treeMap[K, V]) Predecessor(k K) (Entry[K, V], error) {
+	return found(floor(tm.root, k, false))
+}
+
+func (tm *treeMap[K, V]) Successor(k K) (Entry[K, V], error) {
+	return found(ceiling(tm.root, k, false))
+}
+
+// All iterates over the entries in order of their keys
+func (tm *treeMap[K, V]) All() iter.Seq2[K, V] {
+	return inOrder(tm.root)
+}
+
+// Tree copies the shape of the map into a trees.BinaryTree holding its keys,
+// so it can be printed, drawn or measured with the tools of the trees package.
+// An empty map gives an empty, nil, tree.
+func (tm *treeMap[K, V]) Tree() *trees.BinaryTree[K] {
+	return shape(tm.root, func(n *node[K, V]) K { return n.key })
+}
+
+// LabelledTree copies the shape of the map into a trees.BinaryTree holding a label for
+// each position, which shows anything the particular tree keeps about the position
+// alongside its key. An empty map gives an empty, nil, tree.
+func (tm *treeMap[K, V]) LabelledTree() *trees.BinaryTree[string] {
+	label := tm.label
+	if label == nil {
+		label = func(n *node[K, V]) string { return fmt.Sprint(n.key) }
+	}
+	return shape(tm.root, label)
+}
+
+// insert adds an entry for the key, or replaces the value of the entry already there,
+// returning the position holding the key and whether it is a new position
+func (tm *treeMap[K, V]) insert(k K, v V) (*node[K, V], bool) {
+	parent := search(tm.root, k)
+	if parent != nil && parent.key == k {
+		parent.value = v
+		return parent, false
+	}
+
+	// the search ended at the position the new key needs to hang off
+	child := &node[K, V]{key: k, value: v, parent: parent, height: 1}
+	switch {
+	case parent == nil:
+		tm.root = child
+	case k < parent.key:
+		parent.leftChild = child
+	default:
+		parent.rightChild = child
+	}
+	tm.size++
+	return child, true
+}
+
+// removal describes how the shape of the tree changed when a position was removed
+type removal[K cmp.Ordered, V any] struct {
+	// removed is the position deleted, now detached from the tree
+	removed *node[K, V]
+	// successor is the position which took the removed position's place,
+	// if the removed position had two children
+	successor *node[K, V]
+	// child is the subtree which moved up into the place left empty, which may be nil,
+	// and parent is the position it hangs off, the deepest position whose subtree lost a
+	// position, or nil if that was the root
+	child  *node[K, V]
+	parent *node[K, V]
+}
+
+// remove deletes the entry for the key, describing how the shape of the tree changed
+// so that any rebalancing knows where to start from.
+//
+// A position with at most one child can just be replaced by that child. A position with
+// two children is instead replaced by its successor, the position with the smallest key
+// in its right subtree, which keeps every key in the left subtree smaller and every key
+// left in the right subtree larger. The successor has no left child, since a left child
+// would have an even smaller key, so it can be taken out of its own place by the first rule.
+// Moving the successor's position, rather than just its entry, keeps every Entry handed out
+// by the map holding the same key and value.
+func (tm *treeMap[K, V]) remove(k K) (removal[K, V], error) {
+	n := search(tm.root, k)
+	if n == nil || n.key != k {
+		return removal[K, V]{}, KeyNotFoundError{}
+	}
+
+	r := removal[K, V]{removed: n, parent: n.parent}
+	switch {
+	case n.leftChild == nil:
+		r.child = n.rightChild
+		tm.transplant(n, n.rightChild)
+	case n.rightChild == nil:
+		r.child = n.leftChild
+		tm.transplant(n, n.leftChild)
+	default:
+		successor := minimum(n.rightChild)
+		r.successor, r.child, r.parent = successor, successor.rightChild, successor
+		if successor != n.rightChild {
+			r.parent = successor.parent
+			tm.transplant(successor, successor.rightChild)
+			successor.rightChild = n.rightChild
+			successor.rightChild.parent = successor
+		}
+		tm.transplant(n, successor)
+		successor.leftChild = n.leftChild
+		successor.leftChild.parent = successor
+	}
+	n.parent, n.leftChild, n.rightChild = nil, nil, nil
+	tm.size--
+	return r, nil
+}
+
+// transplant puts the replacement subtree in n's place under n's parent,
+// leaving it to the caller to deal with n's own children
+func (tm *treeMap[K, V]) transplant(n, replacement *node[K, V]) {
+	switch {
+	case n.parent == nil:
+		tm.root = replacement
+	case n == n.parent.leftChild:
+		n.parent.leftChild = replacement
+	default:
+		n.parent.rightChild = replacement
+	}
+	if replacement != nil {
+		replacement.parent = n.parent
+	}
+}
+
+// rotate moves n up into its parent's place, with its parent becoming its child.
+// Rotating a left child up to the right:
+//
+//	    p            n
+//	   / \          / \
+//	  n   c  =>    a   p
+//	 / \              / \
+//	a   b            b   c
+//
+// The subtree b, which holds the keys between n's and p's, moves across from n to p,
+// so the keys stay in order, while a moves up a level and c moves down a level.
+func (tm *treeMap[K, V]) rotate(n *node[K, V]) {
+	parent := n.parent
+	if n == parent.leftChild {
+		parent.leftChild = n.rightChild
+		if n.rightChild != nil {
+			n.rightChild.parent = parent
+		}
+		n.rightChild = parent
+	} else {
+		parent.rightChild = n.leftChild
+		if n.leftChild != nil {
+			n.leftChild.parent = parent
+		}
+		n.leftChild = parent
+	}
+	tm.transplant(parent, n)
+	parent.parent = n
+
+	if tm.options.rotationHook != nil {
+		tm.options.rotationHook(tm.LabelledTree())
+	}
+}
+
+// search walks down from n looking for k, returning the position holding k,
+// or if there is none, the last position visited, which is where k would be attached
+func search[K cmp.Ordered, V any](n *node[K, V], k K) *node[K, V] {
+	var last *node[K, V]
+	for n != nil {
+		last = n
+		switch {
+		case k < n.key:
+			n = n.leftChild
+		case k > n.key:
+			n = n.rightChild
+		default:
+			return n
+		}
+	}
+	return last
+}
+
+func minimum[K cmp.Ordered, V any](n *node[K, V]) *node[K, V] {
+	for n.leftChild != nil {
+		n = n.leftChild
+	}
+	return n
+}
+
+func maximum[K cmp.Ordered, V any](n *node[K, V]) *node[K, V] {
+	for n.rightChild != nil {
+		n = n.rightChild
+	}
+	return n
+}
+
+// successor finds the position with the next key after n's: the smallest key of
+// n's right subtree if it has one, otherwise the first ancestor which n is to the left of
+func successor[K cmp.Ordered, V any](n *node[K, V]) *node[K, V] {
+	if n.rightChild != nil {
+		return minimum(n.rightChild)
+	}
+	for n.parent != nil && n == n.parent.rightChild {
+		n = n.parent
+	}
+	return n.parent
+}
+
+// floor finds the position with the largest key less than k, or equal to k if inclusive,
+// remembering the last position where the search went right, since everything found
+// after that is greater than it
+func floor[K cmp.Ordered, V any](n *node[K, V], k K, inclusive bool) *node[K, V] {
+	var best *node[K, V]
+	for n != nil {
+		if n.key < k || (inclusive && n.key == k) {
+			best = n
+			n = n.rightChild
+		} else {
+			n = n.leftChild
+		}
+	}
+	return best
+}
+
+// ceiling finds the position with the smallest key greater than k, or equal to k if inclusive
+func ceiling[K cmp.Ordered, V any](n *node[K, V], k K, inclusive bool) *node[K, V] {
+	var best *node[K, V]
+	for n != nil {
+		if n.key > k || (inclusive && n.key == k) {
+			best = n
+			n = n.leftChild
+		} else {
+			n = n.rightChild
+		}
+	}
+	return best
+}
+
+// size is the number of positions in the subtree, where an empty subtree has none,
+// for the trees which keep it up to date
+func size[K cmp.Ordered, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func found[K cmp.Ordered, V any](n *node[K, V]) (Entry[K, V], error) {
+	if n == nil {
+		return nil, KeyNotFoundError{}
+	}
+	return n, nil
+}
+
+// inOrder follows successor links from the smallest key, which needs no stack,
+// and visits each link at most twice over the whole iteration
+func inOrder[K cmp.Ordered, V any](root *node[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if root == nil {
+			return
+		}
+		for n := minimum(root); n != nil; n = successor(n) {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// inOrderStack keeps a stack of the positions still to visit, for trees which don't keep
+// their parent links, so have no successor links to follow
+func inOrderStack[K cmp.Ordered, V any](root *node[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*node[K, V]
+		for n := root; n != nil || len(stack) > 0; n = n.rightChild {
+			for ; n != nil; n = n.leftChild {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// shape copies the subtree below n into a trees.BinaryTree, labelling each position.
+// The copy is built from the bottom up, so that every child is attached to a parent which is
+// still the root of its own tree and checking the attachment doesn't need to walk up the tree.
+func shape[K cmp.Ordered, V any, T any](n *node[K, V], label func(*node[K, V]) T) *trees.BinaryTree[T] {
+	if n == nil {
+		return nil
+	}
+	tree := trees.NewBinaryTree(label(n))
+	_ = tree.SetLeft(shape(n.leftChild, label))
+	_ = tree.SetRight(shape(n.rightChild, label))
+	return tree
+}
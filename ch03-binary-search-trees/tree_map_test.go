@@ -0,0 +1,74 @@
+package binarysearchtrees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkTreeMap verifies the key order and parent links of every position,
+// and that the size matches the number of positions
+func checkTreeMap(t *testing.T, tm *treeMap[int, int]) {
+	if tm.root != nil {
+		assert.Nil(t, tm.root.parent)
+	}
+	checkParent := func(n *node[int, int]) {
+		for _, child := range []*node[int, int]{n.leftChild, n.rightChild} {
+			if child != nil {
+				assert.Equal(t, n, child.parent)
+			}
+		}
+	}
+	assert.Equal(t, tm.size, checkBinarySearchSubtree(t, tm.root, nil, nil, checkParent))
+}
+
+// checkBinarySearchSubtree checks that every key of the subtree lies strictly between
+// low and high, where nil means no bound, calling check, if it isn't nil, on every position
+// for whatever else the tree keeps in its positions, and returns the number of positions
+func checkBinarySearchSubtree(t *testing.T, n *node[int, int], low, high *int, check func(n *node[int, int])) int {
+	if n == nil {
+		return 0
+	}
+	if low != nil {
+		assert.Greater(t, n.key, *low)
+	}
+	if high != nil {
+		assert.Less(t, n.key, *high)
+	}
+	if check != nil {
+		check(n)
+	}
+	return 1 + checkBinarySearchSubtree(t, n.leftChild, low, &n.key, check) +
+		checkBinarySearchSubtree(t, n.rightChild, &n.key, high, check)
+}
+
+// checkSizedSubtree checks the key order of a subtree whose positions keep the size of
+// their subtrees, as the splay tree and treap do, in the same way as checkBinarySearchSubtree
+func checkSizedSubtree(t *testing.T, n *node[int, int], low, high *int, check func(n *node[int, int])) int {
+	return checkBinarySearchSubtree(t, n, low, high, func(n *node[int, int]) {
+		assert.Equal(t, size(n.leftChild)+size(n.rightChild)+1, n.size, n.key)
+		if check != nil {
+			check(n)
+		}
+	})
+}
+
+func TestTreeMap_Rotate(t *testing.T) {
+	tm := &treeMap[int, int]{}
+	for _, k := range []int{50, 30, 70, 20, 40} {
+		tm.insert(k, -k)
+	}
+
+	// rotating the root's left child up to the right, then back up to the left
+	tm.rotate(tm.root.leftChild)
+	assert.Equal(t, "30(20,50(40,70))", tm.Tree().Parenthetic())
+	checkTreeMap(t, tm)
+	tm.rotate(tm.root.rightChild)
+	assert.Equal(t, "50(30(20,40),70)", tm.Tree().Parenthetic())
+	checkTreeMap(t, tm)
+
+	// rotating below the root keeps the grandparent's link up to date
+	tm.rotate(tm.root.leftChild.rightChild)
+	assert.Equal(t, "50(40(30(20,),),70)", tm.Tree().Parenthetic())
+	checkTreeMap(t, tm)
+}
@@ -0,0 +1,182 @@
+package priorityqueues
+
+import "cmp"
+
+// Locator is the Entry handed out by an AdaptableHeapPriorityQueue.
+//
+// Besides its key and value, a Locator remembers its current index in the heap array
+// and which heap it belongs to. Every time the heap swaps two entries while bubbling
+// up or down, it also updates the index of both Locators, so a caller holding onto a
+// Locator can always find the entry again in O(1) time without searching the heap.
+type Locator[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	index int
+	heap  *AdaptableHeapPriorityQueue[K, V] // nil once the entry has been removed
+}
+
+func (l *Locator[K, V]) Key() K {
+	return l.key
+}
+
+func (l *Locator[K, V]) Value() V {
+	return l.value
+}
+
+// AdaptableHeapPriorityQueue demonstrates an array-based binary heap supporting
+// removal and key/value updates of arbitrary entries in O(log n) time.
+//
+// The heap is stored in level order in a slice, so the children of the entry at
+// index i are found at indices 2i+1 and 2i+2 and its parent is at index (i-1)/2.
+type AdaptableHeapPriorityQueue[K cmp.Ordered, V any] struct {
+	data []*Locator[K, V]
+}
+
+func NewAdaptableHeapPriorityQueue[K cmp.Ordered, V any]() *AdaptableHeapPriorityQueue[K, V] {
+	return &AdaptableHeapPriorityQueue[K, V]{}
+}
+
+// Insert adds the key-value pair to the heap and returns its Locator,
+// which can later be passed to Remove, ReplaceKey, or ReplaceValue
+func (pq *AdaptableHeapPriorityQueue[K, V]) Insert(k K, v V) Entry[K, V] {
+	loc := &Locator[K, V]{key: k, value: v, index: len(pq.data), heap: pq}
+	pq.data = append(pq.data, loc)
+	pq.upHeap(loc.index)
+	return loc
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) Min() (Entry[K, V], error) {
+	if len(pq.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return pq.data[0], nil
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) RemoveMin() (Entry[K, V], error) {
+	if len(pq.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	loc := pq.data[0]
+	pq.removeAt(0)
+	return loc, nil
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) Remove(e Entry[K, V]) error {
+	loc, err := pq.validate(e)
+	if err != nil {
+		return err
+	}
+	pq.removeAt(loc.index)
+	return nil
+}
+
+// ReplaceKey changes the key of the entry, then restores the heap-order property by
+// bubbling the entry up if the new key is smaller, or down if the new key is larger
+func (pq *AdaptableHeapPriorityQueue[K, V]) ReplaceKey(e Entry[K, V], k K) error {
+	loc, err := pq.validate(e)
+	if err != nil {
+		return err
+	}
+	loc.key = k
+	pq.bubble(loc.index)
+	return nil
+}
+
+// ReplaceValue changes the value of the entry; the heap order is unaffected
+func (pq *AdaptableHeapPriorityQueue[K, V]) ReplaceValue(e Entry[K, V], v V) error {
+	loc, err := pq.validate(e)
+	if err != nil {
+		return err
+	}
+	loc.value = v
+	return nil
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) Len() int {
+	return len(pq.data)
+}
+
+// validate ensures the entry is a Locator still stored in this heap;
+// Locators from other heaps or which have already been removed are rejected
+func (pq *AdaptableHeapPriorityQueue[K, V]) validate(e Entry[K, V]) (*Locator[K, V], error) {
+	loc, ok := e.(*Locator[K, V])
+	if !ok || loc == nil || loc.heap != pq {
+		return nil, InvalidLocatorError{}
+	}
+	if loc.index < 0 || loc.index >= len(pq.data) || pq.data[loc.index] != loc {
+		return nil, InvalidLocatorError{}
+	}
+	return loc, nil
+}
+
+// removeAt swaps the entry at index i with the last entry, truncates the array,
+// then bubbles the entry that was moved into index i to restore heap order
+func (pq *AdaptableHeapPriorityQueue[K, V]) removeAt(i int) {
+	last := len(pq.data) - 1
+	loc := pq.data[i]
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.data[last] = nil
+	pq.data = pq.data[:last]
+	loc.index, loc.heap = -1, nil
+
+	if i < len(pq.data) {
+		pq.bubble(i)
+	}
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) bubble(i int) {
+	if i > 0 && pq.data[i].key < pq.data[parent(i)].key {
+		pq.upHeap(i)
+	} else {
+		pq.downHeap(i)
+	}
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) upHeap(i int) {
+	for i > 0 {
+		p := parent(i)
+		if pq.data[i].key >= pq.data[p].key {
+			break
+		}
+		pq.swap(i, p)
+		i = p
+	}
+}
+
+func (pq *AdaptableHeapPriorityQueue[K, V]) downHeap(i int) {
+	for {
+		smallest := i
+		if l := left(i); l < len(pq.data) && pq.data[l].key < pq.data[smallest].key {
+			smallest = l
+		}
+		if r := right(i); r < len(pq.data) && pq.data[r].key < pq.data[smallest].key {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		pq.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// swap exchanges two entries in the heap array and updates both of their Locators
+func (pq *AdaptableHeapPriorityQueue[K, V]) swap(i, j int) {
+	pq.data[i], pq.data[j] = pq.data[j], pq.data[i]
+	pq.data[i].index = i
+	pq.data[j].index = j
+}
+
+func parent(i int) int {
+	return (i - 1) / 2
+}
+
+func left(i int) int {
+	return 2*i + 1
+}
+
+func right(i int) int {
+	return 2*i + 2
+}
@@ -0,0 +1,112 @@
+package priorityqueues
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptableHeapPriorityQueue(t *testing.T) {
+
+	t.Run("remove min in key order", func(t *testing.T) {
+		pq := NewAdaptableHeapPriorityQueue[int, string]()
+		inputs := []int{15, 9, 4, 13, 7, 20, 5, 11, 6, 25, 16, 12}
+
+		for i, k := range inputs {
+			e := pq.Insert(k, "")
+			assert.Equal(t, k, e.Key())
+			assert.Equal(t, i+1, pq.Len())
+		}
+
+		expected := append([]int{}, inputs...)
+		sort.Ints(expected)
+		for _, k := range expected {
+			e, err := pq.Min()
+			assert.Nil(t, err)
+			assert.Equal(t, k, e.Key())
+
+			e, err = pq.RemoveMin()
+			assert.Nil(t, err)
+			assert.Equal(t, k, e.Key())
+		}
+
+		_, err := pq.Min()
+		assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+		_, err = pq.RemoveMin()
+		assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+	})
+
+	t.Run("locators follow entries as the heap is reordered", func(t *testing.T) {
+		pq := NewAdaptableHeapPriorityQueue[int, string]()
+		jobA := pq.Insert(10, "a")
+		jobB := pq.Insert(20, "b")
+		jobC := pq.Insert(30, "c")
+		jobD := pq.Insert(40, "d")
+
+		// reprioritize d to the front of the queue, then push a to the back
+		assert.Nil(t, pq.ReplaceKey(jobD, 5))
+		assert.Nil(t, pq.ReplaceKey(jobA, 50))
+		assert.Nil(t, pq.ReplaceValue(jobB, "b2"))
+
+		// cancel c
+		assert.Nil(t, pq.Remove(jobC))
+		assert.Equal(t, 3, pq.Len())
+
+		var values []string
+		for pq.Len() > 0 {
+			e, err := pq.RemoveMin()
+			assert.Nil(t, err)
+			values = append(values, e.Value())
+		}
+		assert.Equal(t, []string{"d", "b2", "a"}, values)
+	})
+
+	t.Run("invalid locators", func(t *testing.T) {
+		pq := NewAdaptableHeapPriorityQueue[int, string]()
+		other := NewAdaptableHeapPriorityQueue[int, string]()
+
+		removed := pq.Insert(1, "removed")
+		kept := pq.Insert(2, "kept")
+		foreign := other.Insert(3, "foreign")
+		assert.Nil(t, pq.Remove(removed))
+
+		assert.ErrorIs(t, pq.Remove(removed), InvalidLocatorError{})
+		assert.ErrorIs(t, pq.ReplaceKey(removed, 0), InvalidLocatorError{})
+		assert.ErrorIs(t, pq.ReplaceValue(foreign, ""), InvalidLocatorError{})
+		assert.ErrorIs(t, pq.Remove(nil), InvalidLocatorError{})
+
+		e, err := pq.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, kept, e)
+		assert.Equal(t, 1, other.Len())
+	})
+
+	t.Run("random updates keep heap order", func(t *testing.T) {
+		r := rand.New(rand.NewSource(26))
+		pq := NewAdaptableHeapPriorityQueue[int, int]()
+
+		var live []Entry[int, int]
+		for i := 0; i < 1000; i++ {
+			switch op := r.Intn(4); {
+			case op == 0 && len(live) > 0:
+				j := r.Intn(len(live))
+				assert.Nil(t, pq.Remove(live[j]))
+				live = append(live[:j], live[j+1:]...)
+			case op == 1 && len(live) > 0:
+				j := r.Intn(len(live))
+				assert.Nil(t, pq.ReplaceKey(live[j], r.Intn(100)))
+			default:
+				live = append(live, pq.Insert(r.Intn(100), i))
+			}
+			assert.Equal(t, len(live), pq.Len())
+			for j, loc := range pq.data {
+				assert.Equal(t, j, loc.index)
+				if j > 0 {
+					assert.LessOrEqual(t, pq.data[parent(j)].key, loc.key)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package priorityqueues
+
+import "cmp"
+
+type binomialNode[K cmp.Ordered, V any] struct {
+	key      K
+	value    V
+	children []*binomialNode[K, V] // children[i] is the root of a binomial tree of order i
+}
+
+func (n *binomialNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *binomialNode[K, V]) Value() V {
+	return n.value
+}
+
+// BinomialHeap demonstrates a forest of heap-ordered binomial trees.
+//
+// A binomial tree of order k is two binomial trees of order k-1 linked together by
+// making one root the newest child of the other, so it has exactly 2^k nodes and its
+// root has k children of orders 0 through k-1.
+//
+// A heap of n entries keeps at most one tree of each order, exactly like the 1 bits of n
+// written in binary, so there are at most log(n)+1 trees. Melding two heaps works just
+// like adding two binary numbers: trees of equal order are linked, "carrying" a tree of
+// the next order up, for O(log n) time in total.
+type BinomialHeap[K cmp.Ordered, V any] struct {
+	trees []*binomialNode[K, V] // trees[i] is the tree of order i, or nil if there is none
+	size  int
+}
+
+func NewBinomialHeap[K cmp.Ordered, V any]() *BinomialHeap[K, V] {
+	return &BinomialHeap[K, V]{}
+}
+
+// Insert melds a single-node heap, which costs O(log n) in the worst case when it
+// triggers a long chain of carries, but O(1) amortized over a sequence of inserts,
+// just like incrementing a binary counter
+func (h *BinomialHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	node := &binomialNode[K, V]{key: k, value: v}
+	h.trees = meldBinomial(h.trees, []*binomialNode[K, V]{node})
+	h.size++
+	return node
+}
+
+func (h *BinomialHeap[K, V]) Min() (Entry[K, V], error) {
+	i := h.minIndex()
+	if i < 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.trees[i], nil
+}
+
+// RemoveMin takes the tree with the smallest root out of the forest;
+// the children of that root are themselves a valid forest of binomial trees
+// of orders 0 through k-1, which is melded back into the heap
+func (h *BinomialHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	i := h.minIndex()
+	if i < 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	min := h.trees[i]
+	h.trees[i] = nil
+	h.trees = meldBinomial(h.trees, min.children)
+	min.children = nil
+	h.size--
+	return min, nil
+}
+
+func (h *BinomialHeap[K, V]) Meld(other MeldablePriorityQueue[K, V]) error {
+	o, ok := other.(*BinomialHeap[K, V])
+	if !ok {
+		return IncompatibleMeldError{}
+	}
+	if o == h {
+		return nil
+	}
+	h.trees = meldBinomial(h.trees, o.trees)
+	h.size += o.size
+	o.trees, o.size = nil, 0
+	return nil
+}
+
+func (h *BinomialHeap[K, V]) Len() int {
+	return h.size
+}
+
+func (h *BinomialHeap[K, V]) minIndex() int {
+	minIndex := -1
+	for i, tree := range h.trees {
+		if tree != nil && (minIndex < 0 || tree.key < h.trees[minIndex].key) {
+			minIndex = i
+		}
+	}
+	return minIndex
+}
+
+// meldBinomial adds two forests together order by order, like binary addition
+func meldBinomial[K cmp.Ordered, V any](a, b []*binomialNode[K, V]) []*binomialNode[K, V] {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	var carry *binomialNode[K, V]
+	for i := 0; i < len(a); i++ {
+		var fromB *binomialNode[K, V]
+		if i < len(b) {
+			fromB = b[i]
+		}
+
+		// gather up to three trees of order i: one from each forest plus a carry
+		var present []*binomialNode[K, V]
+		for _, tree := range []*binomialNode[K, V]{a[i], fromB, carry} {
+			if tree != nil {
+				present = append(present, tree)
+			}
+		}
+
+		switch len(present) {
+		case 0:
+			a[i], carry = nil, nil
+		case 1:
+			a[i], carry = present[0], nil
+		case 2:
+			a[i], carry = nil, linkBinomial(present[0], present[1])
+		case 3:
+			a[i], carry = present[2], linkBinomial(present[0], present[1])
+		}
+	}
+	if carry != nil {
+		a = append(a, carry)
+	}
+	// trim empty high orders left over from removed trees
+	for len(a) > 0 && a[len(a)-1] == nil {
+		a = a[:len(a)-1]
+	}
+	return a
+}
+
+// linkBinomial combines two trees of order k into one of order k+1
+// by making the root with the larger key the newest child of the other
+func linkBinomial[K cmp.Ordered, V any](a, b *binomialNode[K, V]) *binomialNode[K, V] {
+	if b.key < a.key {
+		a, b = b, a
+	}
+	a.children = append(a.children, b)
+	return a
+}
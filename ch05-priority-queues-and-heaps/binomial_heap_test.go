@@ -0,0 +1,36 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkBinomialTree verifies heap order and that the tree is a binomial tree of the
+// given order, returning its size, which must be 2^order
+func checkBinomialTree(t *testing.T, node *binomialNode[int, int], order int) int {
+	assert.Equal(t, order, len(node.children))
+	size := 1
+	for i, child := range node.children {
+		assert.LessOrEqual(t, node.key, child.key)
+		size += checkBinomialTree(t, child, i)
+	}
+	assert.Equal(t, 1<<order, size)
+	return size
+}
+
+func TestBinomialHeap(t *testing.T) {
+	testMeldablePriorityQueue(t, NewBinomialHeap[int, int], func(t *testing.T, pq *BinomialHeap[int, int]) {
+		size := 0
+		for order, tree := range pq.trees {
+			if tree != nil {
+				// a tree of order i is present exactly when bit i of the size is set
+				assert.NotZero(t, pq.Len()&(1<<order))
+				size += checkBinomialTree(t, tree, order)
+			} else {
+				assert.Zero(t, pq.Len()&(1<<order))
+			}
+		}
+		assert.Equal(t, pq.Len(), size)
+	})
+}
@@ -0,0 +1,11 @@
+package priorityqueues
+
+import "cmp"
+
+// DoubleEndedPriorityQueue is a PriorityQueue which also gives efficient access
+// to the entry with the largest key, so entries can be removed from either end
+type DoubleEndedPriorityQueue[K cmp.Ordered, V any] interface {
+	PriorityQueue[K, V]
+	Max() (Entry[K, V], error)
+	RemoveMax() (Entry[K, V], error)
+}
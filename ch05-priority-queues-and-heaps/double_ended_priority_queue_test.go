@@ -0,0 +1,87 @@
+package priorityqueues
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDoubleEndedPriorityQueue runs the randomized model check for a priority queue,
+// then again removing from both ends, checking invariants after each operation
+func testDoubleEndedPriorityQueue[Q DoubleEndedPriorityQueue[int, int]](
+	t *testing.T, newQueue func() Q, checkInvariants func(t *testing.T, pq Q),
+) {
+	testPriorityQueue(t, func() PriorityQueue[int, int] {
+		return newQueue()
+	})
+
+	r := rand.New(rand.NewSource(29))
+	pq := newQueue()
+	var model modelQueue
+
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(5); {
+		case op < 3:
+			k := r.Intn(500)
+			pq.Insert(k, -k)
+			model.insert(k)
+		case op == 3:
+			e, err := pq.RemoveMin()
+			if len(model) == 0 {
+				assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, model.removeMin(), e.Key())
+			}
+		default:
+			e, err := pq.RemoveMax()
+			if len(model) == 0 {
+				assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, model[len(model)-1], e.Key())
+				assert.Equal(t, -e.Key(), e.Value())
+				model = model[:len(model)-1]
+			}
+		}
+
+		assertMatchesModel(t, model, pq)
+		e, err := pq.Max()
+		if len(model) == 0 {
+			assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, model[len(model)-1], e.Key())
+		}
+		checkInvariants(t, pq)
+	}
+}
+
+func TestDoubleEndedPriorityQueue_EvictWorstServeBest(t *testing.T) {
+	queues := map[string]DoubleEndedPriorityQueue[int, string]{
+		"min-max heap":  NewMinMaxHeap[int, string](),
+		"interval heap": NewIntervalHeap[int, string](),
+	}
+	for name, pq := range queues {
+		t.Run(name, func(t *testing.T) {
+			const capacity = 3
+			for i, k := range []int{5, 1, 9, 3, 7, 2} {
+				pq.Insert(k, string(rune('a'+i)))
+				if pq.Len() > capacity {
+					// keep only the best few by evicting the worst
+					_, err := pq.RemoveMax()
+					assert.Nil(t, err)
+				}
+			}
+
+			var served []int
+			for pq.Len() > 0 {
+				e, err := pq.RemoveMin()
+				assert.Nil(t, err)
+				served = append(served, e.Key())
+			}
+			assert.Equal(t, []int{1, 2, 3}, served)
+		})
+	}
+}
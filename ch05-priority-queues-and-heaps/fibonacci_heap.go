@@ -0,0 +1,371 @@
+package priorityqueues
+
+import (
+	"cmp"
+	"math"
+)
+
+// goldenRatio bounds the size of a Fibonacci heap subtree: a node with k children
+// is the root of a subtree with at least F(k+2) >= goldenRatio^k nodes
+var goldenRatio = (1 + math.Sqrt(5)) / 2
+
+type fibonacciNode[K cmp.Ordered, V any] struct {
+	key     K
+	value   V
+	degree  int  // number of children
+	marked  bool // whether the node has lost a child since it was last made a child itself
+	removed bool // whether the node has been removed from the heap
+
+	parent *fibonacciNode[K, V]
+	child  *fibonacciNode[K, V] // any one of the children; the rest are reached through its siblings
+	left   *fibonacciNode[K, V] // siblings form a circular doubly-linked list
+	right  *fibonacciNode[K, V]
+	owner  *fibonacciOwner[K, V] // leads to the heap the node belongs to
+}
+
+// fibonacciOwner records which heap a group of nodes belongs to. Rather than re-pointing
+// every node of a heap melded into another, which would make Meld O(n), the melded heap's
+// owner is re-pointed to forward to the other heap's owner instead, so a node's heap is found
+// by following the forwards, which are shortcut on the way like the parents in a union-find.
+type fibonacciOwner[K cmp.Ordered, V any] struct {
+	heap    *FibonacciHeap[K, V]
+	forward *fibonacciOwner[K, V]
+}
+
+// find follows the forwards to the heap the owner's nodes now belong to,
+// pointing every owner on the way straight at the last one
+func (o *fibonacciOwner[K, V]) find() *FibonacciHeap[K, V] {
+	root := o
+	for root.forward != nil {
+		root = root.forward
+	}
+	for o != root {
+		o, o.forward = o.forward, root
+	}
+	return root.heap
+}
+
+func (n *fibonacciNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *fibonacciNode[K, V]) Value() V {
+	return n.value
+}
+
+// FibonacciHeap demonstrates a lazy collection of heap-ordered trees, with their roots
+// kept in a circular doubly-linked root list.
+//
+// Insert and Meld just splice nodes into the root list, and DecreaseKey just cuts a node
+// away from its parent into the root list, so the heap can end up with many small trees.
+// RemoveMin pays for all of that laziness by consolidating the root list, linking trees
+// of equal degree until no two roots have the same degree.
+//
+// FibonacciHeap tracks its primitive operation "accounting credits" to illustrate the
+// potential method of analyzing the amortized cost of operations, in the same way
+// DynamicArray illustrates the accounting method.
+// Every operation is charged its amortized cost up front, and every primitive operation
+// actually performed spends 1 credit. The potential of the heap is
+//
+//	potential = 2 * (number of roots) + 3 * (number of marked nodes)
+//
+// and as long as the amortized costs charged are correct, the credits saved up always
+// cover the potential, which is exactly the work some later operation might have to do:
+//   - each root will need to be visited, and possibly linked, during a consolidation
+//   - each marked node may be cut into the root list by a cascading cut, costing 1 credit
+//     for the cut and 2 credits for the new root, which is paid for by unmarking it
+//
+// Amortized costs charged:
+//   - Insert: 3 credits; 1 spent splicing in the new root, 2 saved for its potential
+//   - Meld: 1 credit spent concatenating the root lists
+//   - DecreaseKey: 7 credits, no matter how long the chain of cascading cuts is;
+//     each cascading cut spends 1 credit but releases 1 more than it costs in potential
+//   - RemoveMin: 4 * D(n) + 1 credits, where D(n) = log_φ(n) is the maximum degree of any node
+type FibonacciHeap[K cmp.Ordered, V any] struct {
+	min              *fibonacciNode[K, V]
+	owner            *fibonacciOwner[K, V]
+	size             int
+	roots            int // number of trees in the root list
+	marked           int // number of marked nodes
+	operationCredits int // number of primitive operation credits built up
+}
+
+func NewFibonacciHeap[K cmp.Ordered, V any]() *FibonacciHeap[K, V] {
+	return &FibonacciHeap[K, V]{}
+}
+
+// ensureOwner returns the owner of the heap's nodes, creating it on first use
+// so that a zero-value heap works as well as one from NewFibonacciHeap
+func (h *FibonacciHeap[K, V]) ensureOwner() *fibonacciOwner[K, V] {
+	if h.owner == nil {
+		h.owner = &fibonacciOwner[K, V]{heap: h}
+	}
+	return h.owner
+}
+
+func (h *FibonacciHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	h.operationCredits += 3
+
+	node := &fibonacciNode[K, V]{key: k, value: v, owner: h.ensureOwner()}
+	h.addRoot(node)
+	h.size++
+	h.operationCredits--
+	return node
+}
+
+func (h *FibonacciHeap[K, V]) Min() (Entry[K, V], error) {
+	if h.min == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.min, nil
+}
+
+func (h *FibonacciHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if h.min == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	h.operationCredits += 4*maxFibonacciDegree(h.size) + 1
+
+	min := h.min
+	for _, child := range siblings(min.child) {
+		// promoting each child to a root resets its mark
+		h.unmark(child)
+		h.addRoot(child)
+		h.operationCredits--
+	}
+	min.child, min.degree = nil, 0
+
+	h.removeRoot(min)
+	h.operationCredits--
+	h.size--
+	min.removed = true
+
+	h.consolidate()
+	return min, nil
+}
+
+func (h *FibonacciHeap[K, V]) Meld(other MeldablePriorityQueue[K, V]) error {
+	o, ok := other.(*FibonacciHeap[K, V])
+	if !ok {
+		return IncompatibleMeldError{}
+	}
+	if o == h {
+		return nil
+	}
+	h.operationCredits++
+
+	if o.min != nil {
+		if h.min == nil {
+			h.min = o.min
+		} else {
+			// concatenate the two circular root lists
+			hRight, oLeft := h.min.right, o.min.left
+			h.min.right, o.min.left = o.min, h.min
+			hRight.left, oLeft.right = oLeft, hRight
+			if o.min.key < h.min.key {
+				h.min = o.min
+			}
+		}
+	}
+	h.size += o.size
+	h.roots += o.roots
+	h.marked += o.marked
+	// the credits saved up in the other heap still cover the potential of its nodes
+	h.operationCredits += o.operationCredits
+	// the other heap's nodes now belong to this heap, and the other heap starts
+	// over with an owner of its own for any nodes inserted into it from now on
+	if o.owner != nil {
+		o.owner.heap, o.owner.forward = nil, h.ensureOwner()
+	}
+	*o = FibonacciHeap[K, V]{}
+
+	h.operationCredits--
+	return nil
+}
+
+// DecreaseKey lowers the key of the entry, cutting it away from its parent into the
+// root list if heap order is violated.
+//
+// A parent which loses a child gets marked; a marked parent losing a second child is
+// cut away from its own parent as well, and so on up the tree. These cascading cuts make
+// sure a node of degree k always keeps a subtree of at least F(k+2) nodes, which is what
+// keeps the maximum degree, and therefore the cost of RemoveMin, logarithmic.
+//
+// Entries which have been removed, or which belong to another heap, are rejected.
+func (h *FibonacciHeap[K, V]) DecreaseKey(e Entry[K, V], k K) error {
+	node, ok := e.(*fibonacciNode[K, V])
+	if !ok || node == nil || node.removed || node.owner.find() != h {
+		return InvalidLocatorError{}
+	}
+	if k > node.key {
+		return KeyIncreaseError{}
+	}
+	h.operationCredits += 7
+
+	node.key = k
+	h.operationCredits--
+
+	if parent := node.parent; parent != nil && node.key < parent.key {
+		h.cut(node)
+		h.cascadingCut(parent)
+	}
+	if node.key < h.min.key {
+		h.min = node
+	}
+	return nil
+}
+
+func (h *FibonacciHeap[K, V]) Len() int {
+	return h.size
+}
+
+// potential is the number of credits which must be saved up to pay for future work
+func (h *FibonacciHeap[K, V]) potential() int {
+	return 2*h.roots + 3*h.marked
+}
+
+// consolidate links roots of equal degree together until all roots have distinct degrees
+func (h *FibonacciHeap[K, V]) consolidate() {
+	roots := siblings(h.min)
+	h.min, h.roots = nil, 0
+
+	// byDegree[d] is the only root of degree d found so far
+	var byDegree []*fibonacciNode[K, V]
+	for _, root := range roots {
+		h.operationCredits--
+		root.left, root.right = root, root
+		for {
+			for len(byDegree) <= root.degree {
+				byDegree = append(byDegree, nil)
+			}
+			other := byDegree[root.degree]
+			if other == nil {
+				break
+			}
+			byDegree[root.degree] = nil
+			root = h.link(root, other)
+		}
+		byDegree[root.degree] = root
+	}
+
+	for _, root := range byDegree {
+		if root != nil {
+			h.addRoot(root)
+		}
+	}
+}
+
+// link makes the root with the larger key a child of the other, returning the new root
+func (h *FibonacciHeap[K, V]) link(a, b *fibonacciNode[K, V]) *fibonacciNode[K, V] {
+	h.operationCredits--
+	if b.key < a.key {
+		a, b = b, a
+	}
+	h.unmark(b)
+	b.parent = a
+	if a.child == nil {
+		b.left, b.right = b, b
+		a.child = b
+	} else {
+		spliceRight(a.child, b)
+	}
+	a.degree++
+	return a
+}
+
+// cut moves a node from its parent's child list into the root list
+func (h *FibonacciHeap[K, V]) cut(node *fibonacciNode[K, V]) {
+	h.operationCredits--
+	parent := node.parent
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		unsplice(node)
+	}
+	parent.degree--
+	h.unmark(node)
+	h.addRoot(node)
+}
+
+func (h *FibonacciHeap[K, V]) cascadingCut(node *fibonacciNode[K, V]) {
+	for node.parent != nil {
+		if !node.marked {
+			node.marked = true
+			h.marked++
+			return
+		}
+		parent := node.parent
+		h.cut(node)
+		node = parent
+	}
+}
+
+func (h *FibonacciHeap[K, V]) addRoot(node *fibonacciNode[K, V]) {
+	node.parent = nil
+	if h.min == nil {
+		node.left, node.right = node, node
+		h.min = node
+	} else {
+		spliceRight(h.min, node)
+		if node.key < h.min.key {
+			h.min = node
+		}
+	}
+	h.roots++
+}
+
+// removeRoot takes a node out of the root list, leaving h.min pointing at an arbitrary
+// remaining root; the true minimum is found again by the following consolidation
+func (h *FibonacciHeap[K, V]) removeRoot(node *fibonacciNode[K, V]) {
+	if node.right == node {
+		h.min = nil
+	} else {
+		h.min = node.right
+		unsplice(node)
+	}
+	node.left, node.right = nil, nil
+	h.roots--
+}
+
+func (h *FibonacciHeap[K, V]) unmark(node *fibonacciNode[K, V]) {
+	if node.marked {
+		node.marked = false
+		h.marked--
+	}
+}
+
+// maxFibonacciDegree is D(n), the maximum degree of any node in a heap of n nodes
+func maxFibonacciDegree(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return int(math.Log(float64(n)) / math.Log(goldenRatio))
+}
+
+// siblings returns the nodes of the circular list containing node, starting from node
+func siblings[K cmp.Ordered, V any](node *fibonacciNode[K, V]) []*fibonacciNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	nodes := []*fibonacciNode[K, V]{node}
+	for current := node.right; current != node; current = current.right {
+		nodes = append(nodes, current)
+	}
+	return nodes
+}
+
+// spliceRight inserts node into a circular list directly to the right of at
+func spliceRight[K cmp.Ordered, V any](at, node *fibonacciNode[K, V]) {
+	node.left, node.right = at, at.right
+	at.right.left = node
+	at.right = node
+}
+
+func unsplice[K cmp.Ordered, V any](node *fibonacciNode[K, V]) {
+	node.left.right = node.right
+	node.right.left = node.left
+	node.left, node.right = node, node
+}
@@ -0,0 +1,209 @@
+package priorityqueues
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkFibonacciTree verifies heap order, degrees, parent links and the subtree size
+// bound of F(k+2) >= goldenRatio^k nodes for a node of degree k, returning the size
+// of the subtree and the number of marked nodes in it
+func checkFibonacciTree(t *testing.T, node *fibonacciNode[int, int]) (size, marked int) {
+	size = 1
+	if node.marked {
+		marked++
+	}
+	children := siblings(node.child)
+	assert.Equal(t, node.degree, len(children))
+	for _, child := range children {
+		assert.Equal(t, node, child.parent)
+		assert.LessOrEqual(t, node.key, child.key)
+		childSize, childMarked := checkFibonacciTree(t, child)
+		size += childSize
+		marked += childMarked
+	}
+	assert.LessOrEqual(t, node.degree, maxFibonacciDegree(size))
+	return size, marked
+}
+
+func checkFibonacciHeap(t *testing.T, h *FibonacciHeap[int, int]) {
+	roots := siblings(h.min)
+	assert.Equal(t, h.roots, len(roots))
+
+	size, marked := 0, 0
+	for _, root := range roots {
+		assert.Nil(t, root.parent)
+		assert.LessOrEqual(t, h.min.key, root.key)
+		rootSize, rootMarked := checkFibonacciTree(t, root)
+		size += rootSize
+		marked += rootMarked
+	}
+	assert.Equal(t, h.Len(), size)
+	assert.Equal(t, h.marked, marked)
+
+	// Assert that the credits built up always cover the potential of the heap
+	assert.GreaterOrEqual(t, h.operationCredits, h.potential())
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	testMeldablePriorityQueue(t, NewFibonacciHeap[int, int], checkFibonacciHeap)
+}
+
+func TestFibonacciHeap_DecreaseKey(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	h := NewFibonacciHeap[int, int]()
+	var model modelQueue
+	var live []Entry[int, int]
+
+	for i := 0; i < 1000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			k := r.Intn(100000)
+			live = append(live, h.Insert(k, -k))
+			model.insert(k)
+		case op < 6 && len(model) > 0:
+			e, err := h.RemoveMin()
+			assert.Nil(t, err)
+			assert.Equal(t, model.removeMin(), e.Key())
+			for j, l := range live {
+				if l == e {
+					live = append(live[:j], live[j+1:]...)
+					break
+				}
+			}
+			assert.ErrorIs(t, h.DecreaseKey(e, 0), InvalidLocatorError{})
+		case len(live) > 0:
+			e := live[r.Intn(len(live))]
+			old := e.Key()
+			k := old - r.Intn(1000)
+			assert.Nil(t, h.DecreaseKey(e, k))
+			assert.ErrorIs(t, h.DecreaseKey(e, k+1), KeyIncreaseError{})
+
+			for j, modelKey := range model {
+				if modelKey == old {
+					model = append(model[:j], model[j+1:]...)
+					break
+				}
+			}
+			model.insert(k)
+		}
+
+		assert.Equal(t, len(model), h.Len())
+		if len(model) > 0 {
+			e, err := h.Min()
+			assert.Nil(t, err)
+			assert.Equal(t, model[0], e.Key())
+		}
+		checkFibonacciHeap(t, h)
+	}
+}
+
+func TestFibonacciHeap_DecreaseKeyOwner(t *testing.T) {
+	t.Run("entries of another heap are rejected", func(t *testing.T) {
+		a, b := NewFibonacciHeap[int, int](), NewFibonacciHeap[int, int]()
+		a.Insert(5, 5)
+		e := b.Insert(10, 10)
+
+		assert.ErrorIs(t, a.DecreaseKey(e, 1), InvalidLocatorError{})
+		min, err := a.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, 5, min.Key())
+		assert.Equal(t, 10, e.Key())
+		checkFibonacciHeap(t, a)
+		checkFibonacciHeap(t, b)
+	})
+
+	t.Run("entries are rejected by an empty heap", func(t *testing.T) {
+		a, b := NewFibonacciHeap[int, int](), NewFibonacciHeap[int, int]()
+		e := b.Insert(10, 10)
+
+		assert.ErrorIs(t, a.DecreaseKey(e, 1), InvalidLocatorError{})
+		assert.Equal(t, 0, a.Len())
+		checkFibonacciHeap(t, b)
+	})
+
+	t.Run("zero-value heaps own their entries", func(t *testing.T) {
+		var a, b, c FibonacciHeap[int, int]
+		e := a.Insert(1, 1)
+		assert.Nil(t, a.DecreaseKey(e, 0))
+		assert.ErrorIs(t, b.DecreaseKey(e, -1), InvalidLocatorError{})
+
+		// melding in an empty zero-value heap, and melding into one, keep the entries valid
+		assert.Nil(t, a.Meld(&b))
+		assert.Nil(t, c.Meld(&a))
+		assert.Nil(t, c.DecreaseKey(e, -1))
+		assert.ErrorIs(t, a.DecreaseKey(e, -2), InvalidLocatorError{})
+		checkFibonacciHeap(t, &a)
+		checkFibonacciHeap(t, &c)
+	})
+
+	t.Run("entries follow their nodes through melds", func(t *testing.T) {
+		a, b, c := NewFibonacciHeap[int, int](), NewFibonacciHeap[int, int](), NewFibonacciHeap[int, int]()
+		ea, eb, ec := a.Insert(10, 10), b.Insert(20, 20), c.Insert(30, 30)
+		assert.Nil(t, b.Meld(c))
+		assert.Nil(t, a.Meld(b))
+
+		// b and c are empty now, and start over with entries of their own
+		ebAfter := b.Insert(40, 40)
+		assert.ErrorIs(t, b.DecreaseKey(eb, 1), InvalidLocatorError{})
+		assert.ErrorIs(t, c.DecreaseKey(ec, 1), InvalidLocatorError{})
+		assert.ErrorIs(t, a.DecreaseKey(ebAfter, 1), InvalidLocatorError{})
+
+		assert.Nil(t, a.DecreaseKey(ec, 1))
+		assert.Nil(t, a.DecreaseKey(eb, 2))
+		assert.Nil(t, a.DecreaseKey(ea, 3))
+		assert.Nil(t, b.DecreaseKey(ebAfter, 4))
+		for _, k := range []int{1, 2, 3} {
+			e, err := a.RemoveMin()
+			assert.Nil(t, err)
+			assert.Equal(t, k, e.Key())
+		}
+		checkFibonacciHeap(t, a)
+		checkFibonacciHeap(t, b)
+	})
+}
+
+func TestFibonacciHeap_AmortizedCost(t *testing.T) {
+	assert := assert.New(t)
+	h := NewFibonacciHeap[int, int]()
+	const n = 1024
+
+	var entries []Entry[int, int]
+	t.Run("Insert is O(1) amortized", func(t *testing.T) {
+		for i := 0; i < n; i++ {
+			entries = append(entries, h.Insert(n+i, i))
+			// Assert that we have not run out of our amortized operation credits;
+			// the lazy root list has grown to n trees, all paid for in advance
+			assert.GreaterOrEqual(h.operationCredits, h.potential())
+		}
+		assert.Equal(n, h.roots)
+	})
+
+	t.Run("RemoveMin consolidates into O(log n) trees", func(t *testing.T) {
+		e, err := h.RemoveMin()
+		assert.Nil(err)
+		assert.Equal(n, e.Key())
+		assert.GreaterOrEqual(h.operationCredits, h.potential())
+		assert.LessOrEqual(h.roots, maxFibonacciDegree(n)+1)
+	})
+
+	t.Run("DecreaseKey is O(1) amortized through cascading cuts", func(t *testing.T) {
+		// decrease keys from the back of the heap to the front, cutting deep nodes
+		// and setting off long chains of cascading cuts
+		for i := n - 1; i > 0; i-- {
+			assert.Nil(h.DecreaseKey(entries[i], entries[i].Key()-n))
+			assert.GreaterOrEqual(h.operationCredits, h.potential())
+		}
+	})
+
+	t.Run("RemoveMin til it's gone", func(t *testing.T) {
+		for h.Len() > 0 {
+			_, err := h.RemoveMin()
+			assert.Nil(err)
+			assert.GreaterOrEqual(h.operationCredits, h.potential())
+		}
+		assert.GreaterOrEqual(h.operationCredits, 0)
+	})
+}
@@ -0,0 +1,181 @@
+package priorityqueues
+
+import "cmp"
+
+// IntervalHeap demonstrates a complete binary tree in which each node holds two entries,
+// a low and a high, representing the closed interval [low, high] of their keys.
+//
+// The interval of every node contains the intervals of its children, so the low entries
+// form a min-heap and the high entries form a max-heap, with the overall smallest and
+// largest keys both at the root. Only the last node may hold a single entry, which
+// counts as both its low and its high.
+//
+// The entries are stored flat in a slice, with node i holding its low entry at index 2i
+// and its high entry at index 2i+1, and the children of node i are nodes 2i+1 and 2i+2.
+type IntervalHeap[K cmp.Ordered, V any] struct {
+	data []*entry[K, V]
+}
+
+func NewIntervalHeap[K cmp.Ordered, V any]() *IntervalHeap[K, V] {
+	return &IntervalHeap[K, V]{}
+}
+
+func (h *IntervalHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	e := &entry[K, V]{key: k, value: v}
+	h.data = append(h.data, e)
+	i := len(h.data) - 1
+	node := i / 2
+
+	if i%2 == 1 {
+		// the last node now holds two entries; put them in order
+		// then bubble up whichever one is new
+		if h.data[i].key < h.data[i-1].key {
+			h.swap(i, i-1)
+			h.upMin(node)
+		} else {
+			h.upMax(node)
+		}
+		return e
+	}
+
+	// the new entry is alone in a new last node
+	if node == 0 {
+		return e
+	}
+	p := parent(node)
+	if e.key < h.data[h.low(p)].key {
+		h.upMin(node)
+	} else if e.key > h.data[h.high(p)].key {
+		h.upMax(node)
+	}
+	return e
+}
+
+func (h *IntervalHeap[K, V]) Min() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.data[h.low(0)], nil
+}
+
+func (h *IntervalHeap[K, V]) Max() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.data[h.high(0)], nil
+}
+
+func (h *IntervalHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	e := h.removeAt(h.low(0))
+	if len(h.data) > 0 {
+		h.downMin(0)
+	}
+	return e, nil
+}
+
+func (h *IntervalHeap[K, V]) RemoveMax() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	e := h.removeAt(h.high(0))
+	if len(h.data) > 0 {
+		h.downMax(0)
+	}
+	return e, nil
+}
+
+func (h *IntervalHeap[K, V]) Len() int {
+	return len(h.data)
+}
+
+// removeAt replaces the entry at index i with the last entry of the heap
+func (h *IntervalHeap[K, V]) removeAt(i int) *entry[K, V] {
+	e := h.data[i]
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	h.data[last] = nil
+	h.data = h.data[:last]
+	return e
+}
+
+// low is the index of the low entry of a node
+func (h *IntervalHeap[K, V]) low(node int) int {
+	return 2 * node
+}
+
+// high is the index of the high entry of a node, which is
+// the same as its low entry if the node only holds one
+func (h *IntervalHeap[K, V]) high(node int) int {
+	return min(2*node+1, len(h.data)-1)
+}
+
+func (h *IntervalHeap[K, V]) upMin(node int) {
+	for node > 0 {
+		p := parent(node)
+		if h.data[h.low(node)].key >= h.data[h.low(p)].key {
+			return
+		}
+		h.swap(h.low(node), h.low(p))
+		node = p
+	}
+}
+
+func (h *IntervalHeap[K, V]) upMax(node int) {
+	for node > 0 {
+		p := parent(node)
+		if h.data[h.high(node)].key <= h.data[h.high(p)].key {
+			return
+		}
+		h.swap(h.high(node), h.high(p))
+		node = p
+	}
+}
+
+// downMin trickles the low entry of a node down the min-heap of low entries.
+// At each node, the low entry first swaps with the high entry if it is out of order,
+// so the entry continuing down is never larger than the interval it came from.
+func (h *IntervalHeap[K, V]) downMin(node int) {
+	for {
+		if lo, hi := h.low(node), h.high(node); h.data[hi].key < h.data[lo].key {
+			h.swap(lo, hi)
+		}
+		child := -1
+		for _, c := range []int{left(node), right(node)} {
+			if h.low(c) < len(h.data) && (child < 0 || h.data[h.low(c)].key < h.data[h.low(child)].key) {
+				child = c
+			}
+		}
+		if child < 0 || h.data[h.low(child)].key >= h.data[h.low(node)].key {
+			return
+		}
+		h.swap(h.low(node), h.low(child))
+		node = child
+	}
+}
+
+// downMax trickles the high entry of a node down the max-heap of high entries
+func (h *IntervalHeap[K, V]) downMax(node int) {
+	for {
+		if lo, hi := h.low(node), h.high(node); h.data[hi].key < h.data[lo].key {
+			h.swap(lo, hi)
+		}
+		child := -1
+		for _, c := range []int{left(node), right(node)} {
+			if h.low(c) < len(h.data) && (child < 0 || h.data[h.high(c)].key > h.data[h.high(child)].key) {
+				child = c
+			}
+		}
+		if child < 0 || h.data[h.high(child)].key <= h.data[h.high(node)].key {
+			return
+		}
+		h.swap(h.high(node), h.high(child))
+		node = child
+	}
+}
+
+func (h *IntervalHeap[K, V]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
@@ -0,0 +1,25 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkIntervalHeap verifies that every node's interval is in order
+// and contains the intervals of its children
+func checkIntervalHeap(t *testing.T, h *IntervalHeap[int, int]) {
+	for node := 0; h.low(node) < len(h.data); node++ {
+		lo, hi := h.data[h.low(node)].key, h.data[h.high(node)].key
+		assert.LessOrEqual(t, lo, hi)
+		if node > 0 {
+			p := parent(node)
+			assert.LessOrEqual(t, h.data[h.low(p)].key, lo)
+			assert.GreaterOrEqual(t, h.data[h.high(p)].key, hi)
+		}
+	}
+}
+
+func TestIntervalHeap(t *testing.T) {
+	testDoubleEndedPriorityQueue(t, NewIntervalHeap[int, int], checkIntervalHeap)
+}
@@ -0,0 +1,104 @@
+package priorityqueues
+
+import "cmp"
+
+type leftistNode[K cmp.Ordered, V any] struct {
+	key        K
+	value      V
+	rank       int // length of the shortest path from this node down to a missing child
+	leftChild  *leftistNode[K, V]
+	rightChild *leftistNode[K, V]
+}
+
+func (n *leftistNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *leftistNode[K, V]) Value() V {
+	return n.value
+}
+
+func (n *leftistNode[K, V]) rankOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// LeftistHeap demonstrates a heap-ordered binary tree which is kept "leaning left":
+// the rank of every left child is at least the rank of its sibling on the right.
+//
+// As a consequence, the rightmost path from the root has at most log(n+1) nodes,
+// and two leftist heaps can be melded in O(log n) time by merging their right paths,
+// then swapping children on the way back up wherever the leftist property was broken.
+type LeftistHeap[K cmp.Ordered, V any] struct {
+	root *leftistNode[K, V]
+	size int
+}
+
+func NewLeftistHeap[K cmp.Ordered, V any]() *LeftistHeap[K, V] {
+	return &LeftistHeap[K, V]{}
+}
+
+func (h *LeftistHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	node := &leftistNode[K, V]{key: k, value: v, rank: 1}
+	h.root = mergeLeftist(h.root, node)
+	h.size++
+	return node
+}
+
+func (h *LeftistHeap[K, V]) Min() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.root, nil
+}
+
+func (h *LeftistHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	min := h.root
+	h.root = mergeLeftist(min.leftChild, min.rightChild)
+	min.leftChild, min.rightChild = nil, nil
+	h.size--
+	return min, nil
+}
+
+func (h *LeftistHeap[K, V]) Meld(other MeldablePriorityQueue[K, V]) error {
+	o, ok := other.(*LeftistHeap[K, V])
+	if !ok {
+		return IncompatibleMeldError{}
+	}
+	if o == h {
+		return nil
+	}
+	h.root = mergeLeftist(h.root, o.root)
+	h.size += o.size
+	o.root, o.size = nil, 0
+	return nil
+}
+
+func (h *LeftistHeap[K, V]) Len() int {
+	return h.size
+}
+
+// mergeLeftist recurses only down the right paths of a and b, so the recursion depth
+// is bounded by the sum of their ranks, which is O(log n)
+func mergeLeftist[K cmp.Ordered, V any](a, b *leftistNode[K, V]) *leftistNode[K, V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+	a.rightChild = mergeLeftist(a.rightChild, b)
+	if a.leftChild.rankOf() < a.rightChild.rankOf() {
+		a.leftChild, a.rightChild = a.rightChild, a.leftChild
+	}
+	a.rank = a.rightChild.rankOf() + 1
+	return a
+}
@@ -0,0 +1,29 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkLeftist verifies heap order, the leftist property, and the stored ranks,
+// returning the size of the subtree
+func checkLeftist(t *testing.T, node *leftistNode[int, int]) int {
+	if node == nil {
+		return 0
+	}
+	for _, child := range []*leftistNode[int, int]{node.leftChild, node.rightChild} {
+		if child != nil {
+			assert.LessOrEqual(t, node.key, child.key)
+		}
+	}
+	assert.GreaterOrEqual(t, node.leftChild.rankOf(), node.rightChild.rankOf())
+	assert.Equal(t, node.rightChild.rankOf()+1, node.rank)
+	return 1 + checkLeftist(t, node.leftChild) + checkLeftist(t, node.rightChild)
+}
+
+func TestLeftistHeap(t *testing.T) {
+	testMeldablePriorityQueue(t, NewLeftistHeap[int, int], func(t *testing.T, pq *LeftistHeap[int, int]) {
+		assert.Equal(t, pq.Len(), checkLeftist(t, pq.root))
+	})
+}
@@ -0,0 +1,18 @@
+package priorityqueues
+
+import "cmp"
+
+// MeldablePriorityQueue is a PriorityQueue which can absorb all the entries of another
+// priority queue of the same type faster than inserting them one by one.
+//
+// After a successful Meld, the other priority queue is left empty.
+type MeldablePriorityQueue[K cmp.Ordered, V any] interface {
+	PriorityQueue[K, V]
+	Meld(other MeldablePriorityQueue[K, V]) error
+}
+
+type IncompatibleMeldError struct{}
+
+func (e IncompatibleMeldError) Error() string {
+	return "cannot meld priority queues of different types"
+}
@@ -0,0 +1,80 @@
+package priorityqueues
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testMeldablePriorityQueue runs the randomized model check for a single priority queue,
+// then again over a handful of queues which are randomly melded into each other.
+// checkInvariants is called on every queue after each operation to verify the
+// structural properties of the particular heap being tested.
+func testMeldablePriorityQueue[Q MeldablePriorityQueue[int, int]](
+	t *testing.T, newQueue func() Q, checkInvariants func(t *testing.T, pq Q),
+) {
+	testPriorityQueue(t, func() PriorityQueue[int, int] {
+		return newQueue()
+	})
+
+	r := rand.New(rand.NewSource(27))
+	const numQueues = 5
+	queues := make([]Q, numQueues)
+	models := make([]modelQueue, numQueues)
+	for i := range queues {
+		queues[i] = newQueue()
+	}
+
+	for i := 0; i < 1500; i++ {
+		q, other := r.Intn(numQueues), r.Intn(numQueues)
+		switch op := r.Intn(10); {
+		case op < 6:
+			k := r.Intn(1000)
+			queues[q].Insert(k, -k)
+			models[q].insert(k)
+		case op < 9:
+			e, err := queues[q].RemoveMin()
+			if len(models[q]) == 0 {
+				assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, models[q].removeMin(), e.Key())
+			}
+		default:
+			assert.Nil(t, queues[q].Meld(queues[other]))
+			if other != q {
+				for _, k := range models[other] {
+					models[q].insert(k)
+				}
+				models[other] = nil
+			}
+		}
+
+		for _, j := range []int{q, other} {
+			assertMatchesModel(t, models[j], queues[j])
+			checkInvariants(t, queues[j])
+		}
+	}
+}
+
+func TestMeldablePriorityQueue_IncompatibleMeld(t *testing.T) {
+	queues := []MeldablePriorityQueue[int, int]{
+		NewLeftistHeap[int, int](),
+		NewSkewHeap[int, int](),
+		NewBinomialHeap[int, int](),
+		NewPairingHeap[int, int](),
+		NewFibonacciHeap[int, int](),
+	}
+	for i, pq := range queues {
+		pq.Insert(i, -i)
+	}
+	for i, pq := range queues {
+		for j, other := range queues {
+			if i != j {
+				assert.ErrorIs(t, pq.Meld(other), IncompatibleMeldError{})
+			}
+		}
+		assert.Equal(t, 1, pq.Len())
+	}
+}
@@ -0,0 +1,182 @@
+package priorityqueues
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// MinMaxHeap demonstrates an array-based complete binary tree whose levels alternate
+// between min levels and max levels, starting with the root on a min level.
+//
+// Every entry on a min level has a key no larger than any of its descendants, and every
+// entry on a max level has a key no smaller than any of its descendants. So the smallest
+// key is always at the root, and the largest key is always one of the root's children.
+//
+// The heap is stored in level order in a slice exactly like a regular binary heap, with
+// the children of index i at 2i+1 and 2i+2, but bubbling up and down skips over every
+// other level, comparing entries with their grandparents and grandchildren instead.
+type MinMaxHeap[K cmp.Ordered, V any] struct {
+	data []*entry[K, V]
+}
+
+func NewMinMaxHeap[K cmp.Ordered, V any]() *MinMaxHeap[K, V] {
+	return &MinMaxHeap[K, V]{}
+}
+
+func (h *MinMaxHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	e := &entry[K, V]{key: k, value: v}
+	h.data = append(h.data, e)
+	h.pushUp(len(h.data) - 1)
+	return e
+}
+
+func (h *MinMaxHeap[K, V]) Min() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.data[0], nil
+}
+
+func (h *MinMaxHeap[K, V]) Max() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.data[h.maxIndex()], nil
+}
+
+func (h *MinMaxHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.removeAt(0), nil
+}
+
+func (h *MinMaxHeap[K, V]) RemoveMax() (Entry[K, V], error) {
+	if len(h.data) == 0 {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.removeAt(h.maxIndex()), nil
+}
+
+func (h *MinMaxHeap[K, V]) Len() int {
+	return len(h.data)
+}
+
+// maxIndex is the index of the largest key: the root if it is the only entry,
+// otherwise the larger of the root's children on the first max level
+func (h *MinMaxHeap[K, V]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.data[2].key > h.data[1].key {
+		return 2
+	}
+	return 1
+}
+
+// removeAt replaces the entry at index i with the last entry, then trickles it down
+func (h *MinMaxHeap[K, V]) removeAt(i int) *entry[K, V] {
+	e := h.data[i]
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	h.data[last] = nil
+	h.data = h.data[:last]
+	if i < len(h.data) {
+		h.trickleDown(i)
+	}
+	return e
+}
+
+// pushUp first compares a new entry with its parent, which is on the opposite kind of level.
+// If it belongs on the parent's side, they are swapped; either way the entry then only
+// needs to bubble up through its grandparents on the levels of its own kind.
+func (h *MinMaxHeap[K, V]) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+	p := parent(i)
+	if isMinLevel(i) {
+		if h.data[i].key > h.data[p].key {
+			h.swap(i, p)
+			h.pushUpGrandparents(p, greater[K])
+		} else {
+			h.pushUpGrandparents(i, less[K])
+		}
+	} else {
+		if h.data[i].key < h.data[p].key {
+			h.swap(i, p)
+			h.pushUpGrandparents(p, less[K])
+		} else {
+			h.pushUpGrandparents(i, greater[K])
+		}
+	}
+}
+
+// pushUpGrandparents bubbles an entry up while it comes before its grandparent,
+// where before is less on min levels and greater on max levels
+func (h *MinMaxHeap[K, V]) pushUpGrandparents(i int, before func(a, b K) bool) {
+	for i > 2 {
+		gp := parent(parent(i))
+		if !before(h.data[i].key, h.data[gp].key) {
+			return
+		}
+		h.swap(i, gp)
+		i = gp
+	}
+}
+
+func (h *MinMaxHeap[K, V]) trickleDown(i int) {
+	if isMinLevel(i) {
+		h.trickleDownWith(i, less[K])
+	} else {
+		h.trickleDownWith(i, greater[K])
+	}
+}
+
+// trickleDownWith moves an entry down to the first of its children and grandchildren,
+// where first is the smallest on min levels and the largest on max levels.
+// When it lands on a grandchild, the entry may now come after its new parent on the
+// opposite kind of level, in which case they are swapped before continuing down.
+func (h *MinMaxHeap[K, V]) trickleDownWith(i int, before func(a, b K) bool) {
+	for left(i) < len(h.data) {
+		m := left(i)
+		for _, j := range []int{right(i), left(left(i)), right(left(i)), left(right(i)), right(right(i))} {
+			if j < len(h.data) && before(h.data[j].key, h.data[m].key) {
+				m = j
+			}
+		}
+		if !before(h.data[m].key, h.data[i].key) {
+			return
+		}
+		h.swap(i, m)
+		if m == left(i) || m == right(i) {
+			// a child has no children of its own kind below to push further into
+			return
+		}
+		if p := parent(m); before(h.data[p].key, h.data[m].key) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
+
+func (h *MinMaxHeap[K, V]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+// isMinLevel reports whether index i is on an even level of the tree, counting the root as level 0
+func isMinLevel(i int) bool {
+	level := bits.Len(uint(i+1)) - 1
+	return level%2 == 0
+}
+
+func less[K cmp.Ordered](a, b K) bool {
+	return a < b
+}
+
+func greater[K cmp.Ordered](a, b K) bool {
+	return a > b
+}
@@ -0,0 +1,34 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkMinMaxHeap verifies every entry against all of its descendants,
+// by checking it against its children and grandchildren
+func checkMinMaxHeap(t *testing.T, h *MinMaxHeap[int, int]) {
+	for i, e := range h.data {
+		for _, j := range []int{left(i), right(i), left(left(i)), right(left(i)), left(right(i)), right(right(i))} {
+			if j >= len(h.data) {
+				continue
+			}
+			if isMinLevel(i) {
+				assert.LessOrEqual(t, e.key, h.data[j].key)
+			} else {
+				assert.GreaterOrEqual(t, e.key, h.data[j].key)
+			}
+		}
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	testDoubleEndedPriorityQueue(t, NewMinMaxHeap[int, int], checkMinMaxHeap)
+}
+
+func TestIsMinLevel(t *testing.T) {
+	for i, expected := range []bool{true, false, false, true, true, true, true, false} {
+		assert.Equal(t, expected, isMinLevel(i))
+	}
+}
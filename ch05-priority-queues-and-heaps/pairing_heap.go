@@ -0,0 +1,111 @@
+package priorityqueues
+
+import "cmp"
+
+type pairingNode[K cmp.Ordered, V any] struct {
+	key      K
+	value    V
+	children []*pairingNode[K, V]
+}
+
+func (n *pairingNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *pairingNode[K, V]) Value() V {
+	return n.value
+}
+
+// PairingHeap demonstrates a single heap-ordered multiway tree with no structural
+// constraints at all.
+//
+// Insert and Meld simply link two roots together in O(1) time, making the root with
+// the larger key a child of the other. All of the real work is deferred to RemoveMin,
+// which has to combine the children of the old root into a single tree.
+// It does so in two passes: first linking the children together in pairs from left to
+// right, then linking the resulting trees together from right to left.
+// This two-pass pairing is what gives RemoveMin its O(log n) amortized running time.
+type PairingHeap[K cmp.Ordered, V any] struct {
+	root *pairingNode[K, V]
+	size int
+}
+
+func NewPairingHeap[K cmp.Ordered, V any]() *PairingHeap[K, V] {
+	return &PairingHeap[K, V]{}
+}
+
+func (h *PairingHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	node := &pairingNode[K, V]{key: k, value: v}
+	h.root = linkPairing(h.root, node)
+	h.size++
+	return node
+}
+
+func (h *PairingHeap[K, V]) Min() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.root, nil
+}
+
+func (h *PairingHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	min := h.root
+	h.root = pairChildren(min.children)
+	min.children = nil
+	h.size--
+	return min, nil
+}
+
+func (h *PairingHeap[K, V]) Meld(other MeldablePriorityQueue[K, V]) error {
+	o, ok := other.(*PairingHeap[K, V])
+	if !ok {
+		return IncompatibleMeldError{}
+	}
+	if o == h {
+		return nil
+	}
+	h.root = linkPairing(h.root, o.root)
+	h.size += o.size
+	o.root, o.size = nil, 0
+	return nil
+}
+
+func (h *PairingHeap[K, V]) Len() int {
+	return h.size
+}
+
+func linkPairing[K cmp.Ordered, V any](a, b *pairingNode[K, V]) *pairingNode[K, V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+	a.children = append(a.children, b)
+	return a
+}
+
+func pairChildren[K cmp.Ordered, V any](children []*pairingNode[K, V]) *pairingNode[K, V] {
+	// first pass: link children in pairs from left to right
+	var pairs []*pairingNode[K, V]
+	for i := 0; i < len(children); i += 2 {
+		if i+1 < len(children) {
+			pairs = append(pairs, linkPairing(children[i], children[i+1]))
+		} else {
+			pairs = append(pairs, children[i])
+		}
+	}
+
+	// second pass: link the pairs together from right to left
+	var root *pairingNode[K, V]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = linkPairing(pairs[i], root)
+	}
+	return root
+}
@@ -0,0 +1,26 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkPairing verifies heap order, returning the size of the subtree
+func checkPairing(t *testing.T, node *pairingNode[int, int]) int {
+	if node == nil {
+		return 0
+	}
+	size := 1
+	for _, child := range node.children {
+		assert.LessOrEqual(t, node.key, child.key)
+		size += checkPairing(t, child)
+	}
+	return size
+}
+
+func TestPairingHeap(t *testing.T) {
+	testMeldablePriorityQueue(t, NewPairingHeap[int, int], func(t *testing.T, pq *PairingHeap[int, int]) {
+		assert.Equal(t, pq.Len(), checkPairing(t, pq.root))
+	})
+}
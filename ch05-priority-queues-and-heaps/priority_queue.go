@@ -0,0 +1,59 @@
+package priorityqueues
+
+import "cmp"
+
+// Entry is a key-value pair stored in a PriorityQueue;
+// the key determines the priority, with smaller keys being removed first
+type Entry[K cmp.Ordered, V any] interface {
+	Key() K
+	Value() V
+}
+
+// entry is a plain Entry for priority queues which don't need to
+// store anything else alongside the key and value
+type entry[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+func (e *entry[K, V]) Key() K {
+	return e.key
+}
+
+func (e *entry[K, V]) Value() V {
+	return e.value
+}
+
+type PriorityQueue[K cmp.Ordered, V any] interface {
+	Insert(k K, v V) Entry[K, V]
+	Min() (Entry[K, V], error)
+	RemoveMin() (Entry[K, V], error)
+	Len() int
+}
+
+// AdaptablePriorityQueue is a PriorityQueue whose entries can be removed or updated
+// after insertion by handing back the Entry returned from Insert, which acts as a locator
+type AdaptablePriorityQueue[K cmp.Ordered, V any] interface {
+	PriorityQueue[K, V]
+	Remove(e Entry[K, V]) error
+	ReplaceKey(e Entry[K, V], k K) error
+	ReplaceValue(e Entry[K, V], v V) error
+}
+
+type PriorityQueueEmptyError struct{}
+
+func (e PriorityQueueEmptyError) Error() string {
+	return "priority queue empty"
+}
+
+type InvalidLocatorError struct{}
+
+func (e InvalidLocatorError) Error() string {
+	return "invalid locator"
+}
+
+type KeyIncreaseError struct{}
+
+func (e KeyIncreaseError) Error() string {
+	return "new key is larger than current key"
+}
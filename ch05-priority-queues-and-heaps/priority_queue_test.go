@@ -0,0 +1,83 @@
+package priorityqueues
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// modelQueue is the reference model for the randomized model-check tests;
+// a sorted slice of keys, which is trivially correct if not very efficient
+type modelQueue []int
+
+func (m *modelQueue) insert(k int) {
+	i := sort.SearchInts(*m, k)
+	*m = append(*m, 0)
+	copy((*m)[i+1:], (*m)[i:])
+	(*m)[i] = k
+}
+
+func (m *modelQueue) removeMin() int {
+	k := (*m)[0]
+	*m = (*m)[1:]
+	return k
+}
+
+// assertMatchesModel checks the size and minimum of a priority queue against its model.
+// Every entry is inserted with its value set to the negated key, so that we can also
+// check that entries are never split apart from their values.
+func assertMatchesModel(t *testing.T, model modelQueue, pq PriorityQueue[int, int]) {
+	assert.Equal(t, len(model), pq.Len())
+	e, err := pq.Min()
+	if len(model) == 0 {
+		assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+		return
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, model[0], e.Key())
+	assert.Equal(t, -e.Key(), e.Value())
+}
+
+// testPriorityQueue runs a random sequence of inserts and removals against
+// a new priority queue and the model, checking that they always agree
+func testPriorityQueue(t *testing.T, newQueue func() PriorityQueue[int, int]) {
+	r := rand.New(rand.NewSource(5))
+	pq := newQueue()
+	var model modelQueue
+
+	for i := 0; i < 2000; i++ {
+		if r.Intn(3) > 0 {
+			k := r.Intn(500)
+			e := pq.Insert(k, -k)
+			assert.Equal(t, k, e.Key())
+			model.insert(k)
+		} else {
+			e, err := pq.RemoveMin()
+			if len(model) == 0 {
+				assert.ErrorIs(t, err, PriorityQueueEmptyError{})
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, model.removeMin(), e.Key())
+				assert.Equal(t, -e.Key(), e.Value())
+			}
+		}
+		assertMatchesModel(t, model, pq)
+	}
+
+	for len(model) > 0 {
+		e, err := pq.RemoveMin()
+		assert.Nil(t, err)
+		assert.Equal(t, model.removeMin(), e.Key())
+	}
+	assertMatchesModel(t, model, pq)
+}
+
+func TestPriorityQueues(t *testing.T) {
+	t.Run("adaptable heap", func(t *testing.T) {
+		testPriorityQueue(t, func() PriorityQueue[int, int] {
+			return NewAdaptableHeapPriorityQueue[int, int]()
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package priorityqueues
+
+import "cmp"
+
+type skewNode[K cmp.Ordered, V any] struct {
+	key        K
+	value      V
+	leftChild  *skewNode[K, V]
+	rightChild *skewNode[K, V]
+}
+
+func (n *skewNode[K, V]) Key() K {
+	return n.key
+}
+
+func (n *skewNode[K, V]) Value() V {
+	return n.value
+}
+
+// SkewHeap demonstrates the self-adjusting counterpart of the LeftistHeap.
+//
+// Instead of storing ranks and only swapping children when the leftist property is
+// broken, a skew heap unconditionally swaps the children of every node on the merge path.
+// Individual melds can take O(n) time, but the amortized cost of every operation is
+// O(log n), and no balance information needs to be stored in the nodes at all.
+type SkewHeap[K cmp.Ordered, V any] struct {
+	root *skewNode[K, V]
+	size int
+}
+
+func NewSkewHeap[K cmp.Ordered, V any]() *SkewHeap[K, V] {
+	return &SkewHeap[K, V]{}
+}
+
+func (h *SkewHeap[K, V]) Insert(k K, v V) Entry[K, V] {
+	node := &skewNode[K, V]{key: k, value: v}
+	h.root = mergeSkew(h.root, node)
+	h.size++
+	return node
+}
+
+func (h *SkewHeap[K, V]) Min() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	return h.root, nil
+}
+
+func (h *SkewHeap[K, V]) RemoveMin() (Entry[K, V], error) {
+	if h.root == nil {
+		return nil, PriorityQueueEmptyError{}
+	}
+	min := h.root
+	h.root = mergeSkew(min.leftChild, min.rightChild)
+	min.leftChild, min.rightChild = nil, nil
+	h.size--
+	return min, nil
+}
+
+func (h *SkewHeap[K, V]) Meld(other MeldablePriorityQueue[K, V]) error {
+	o, ok := other.(*SkewHeap[K, V])
+	if !ok {
+		return IncompatibleMeldError{}
+	}
+	if o == h {
+		return nil
+	}
+	h.root = mergeSkew(h.root, o.root)
+	h.size += o.size
+	o.root, o.size = nil, 0
+	return nil
+}
+
+func (h *SkewHeap[K, V]) Len() int {
+	return h.size
+}
+
+// mergeSkew is the iterative form of the recursive definition
+//
+//	merge(a, b) = a with leftChild = merge(a.rightChild, b) and rightChild = a.leftChild
+//
+// for a.key <= b.key, as the merge path of a skew heap is not guaranteed to be short
+// and we do not want a single unlucky meld to recurse O(n) calls deep
+func mergeSkew[K cmp.Ordered, V any](a, b *skewNode[K, V]) *skewNode[K, V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+	root := a
+	for current := a; b != nil; {
+		right := current.rightChild
+		current.rightChild = current.leftChild
+		if right == nil {
+			current.leftChild = b
+			break
+		}
+		if b.key < right.key {
+			right, b = b, right
+		}
+		current.leftChild = right
+		current = right
+	}
+	return root
+}
@@ -0,0 +1,51 @@
+package priorityqueues
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkSkew verifies heap order, returning the size of the subtree;
+// unlike a leftist heap, a skew heap has no structural property to check
+func checkSkew(t *testing.T, node *skewNode[int, int]) int {
+	if node == nil {
+		return 0
+	}
+	for _, child := range []*skewNode[int, int]{node.leftChild, node.rightChild} {
+		if child != nil {
+			assert.LessOrEqual(t, node.key, child.key)
+		}
+	}
+	return 1 + checkSkew(t, node.leftChild) + checkSkew(t, node.rightChild)
+}
+
+func TestSkewHeap(t *testing.T) {
+	testMeldablePriorityQueue(t, NewSkewHeap[int, int], func(t *testing.T, pq *SkewHeap[int, int]) {
+		assert.Equal(t, pq.Len(), checkSkew(t, pq.root))
+	})
+}
+
+func TestSkewHeap_LongMergePath(t *testing.T) {
+	// inserting in decreasing order builds a skew heap whose merge path is a long chain;
+	// merging it must not recurse once per node
+	const n = 100000
+	pq := NewSkewHeap[int, int]()
+	for k := n; k > 0; k-- {
+		pq.Insert(k, -k)
+	}
+	other := NewSkewHeap[int, int]()
+	for k := n; k > 0; k-- {
+		other.Insert(k, -k)
+	}
+	assert.Nil(t, pq.Meld(other))
+	assert.Equal(t, 2*n, pq.Len())
+
+	for k := 1; k <= 3; k++ {
+		for i := 0; i < 2; i++ {
+			e, err := pq.RemoveMin()
+			assert.Nil(t, err)
+			assert.Equal(t, k, e.Key())
+		}
+	}
+}